@@ -0,0 +1,180 @@
+// Package analyzer reports the strings shown to users that are not
+// translated, so that the checks of show-missing-strings and checkup run in
+// go vet, gopls or golangci-lint
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/EverlongProject/i18n4go/common"
+)
+
+const DOC = `report untranslated user-facing strings
+
+The i18n4go analyzer reports the string literals and constants passed as
+string parameters, or as the variadic interface{} args, e.g., of fmt.Println,
+to the -sink-funcs functions without a T() call, and the T() calls whose
+literal ID is not in the -i18n-strings-filename source translation file. The
+literals get a suggested fix wrapping them with T().`
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "i18n4go",
+	Doc:      DOC,
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+var placeholderRegexp = regexp.MustCompile(common.PLACEHOLDER_REGEXP)
+
+var (
+	sinkFuncsFlag           string
+	i18nStringsFilenameFlag string
+	qualifierFlag           string
+)
+
+func init() {
+	Analyzer.Flags.StringVar(&sinkFuncsFlag, "sink-funcs", "", "a comma separated list of functions whose string parameters are shown to users, e.g., \"fmt.Printf,errors.New,ui.Say\"")
+	Analyzer.Flags.StringVar(&i18nStringsFilenameFlag, "i18n-strings-filename", "", "[optional] the source translation file, e.g., en.all.json, with the IDs of the T() calls")
+	Analyzer.Flags.StringVar(&qualifierFlag, "q", "", "[optional] the qualifier of the T() calls, e.g., i18n for i18n.T(...)")
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	sinkFuncs := common.NewSinkFuncs(common.ParseStringList(sinkFuncsFlag, ","))
+
+	var ids map[string]bool
+	if i18nStringsFilenameFlag != "" {
+		var err error
+		ids, err = loadIDs(i18nStringsFilenameFlag)
+		if err != nil {
+			return nil, fmt.Errorf("i18n4go: could not load i18n strings from file: %s: %s", i18nStringsFilenameFlag, err.Error())
+		}
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		callExpr := node.(*ast.CallExpr)
+
+		if common.IsTCallExpr(callExpr, qualifierFlag, nil) {
+			if ids != nil {
+				checkTCall(pass, callExpr, ids)
+			}
+			return
+		}
+
+		fn, _ := typeutil.Callee(pass.TypesInfo, callExpr).(*types.Func)
+		if fn == nil || !sinkFuncs.Matches(fn, callExpr) {
+			return
+		}
+
+		signature := fn.Type().(*types.Signature)
+		for _, index := range common.StringParamIndexes(signature, callExpr) {
+			checkSinkArg(pass, fn, callExpr.Args[index])
+		}
+		for _, index := range common.InterfaceArgIndexes(signature, callExpr) {
+			checkSinkArg(pass, fn, callExpr.Args[index])
+		}
+	})
+
+	return nil, nil
+}
+
+// checkSinkArg reports a string literal or constant passed to a sink, the
+// strings without letters, e.g., "%s\n", are not translated
+func checkSinkArg(pass *analysis.Pass, fn *types.Func, arg ast.Expr) {
+	typeAndValue, ok := pass.TypesInfo.Types[arg]
+	if !ok || typeAndValue.Value == nil || typeAndValue.Value.Kind() != constant.String {
+		return
+	}
+
+	s := constant.StringVal(typeAndValue.Value)
+	if !hasLetters(s) {
+		return
+	}
+
+	diagnostic := analysis.Diagnostic{
+		Pos:     arg.Pos(),
+		End:     arg.End(),
+		Message: fmt.Sprintf("string %q passed to %s is not translated with T()", s, fn.Name()),
+	}
+
+	if basicLit, ok := ast.Unparen(arg).(*ast.BasicLit); ok {
+		var buffer bytes.Buffer
+		if err := format.Node(&buffer, token.NewFileSet(), common.NewTCallExpr(basicLit, qualifierFlag)); err == nil {
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "Wrap the string with T()",
+				TextEdits: []analysis.TextEdit{{Pos: basicLit.Pos(), End: basicLit.End(), NewText: buffer.Bytes()}},
+			}}
+		}
+	}
+
+	pass.Report(diagnostic)
+}
+
+// checkTCall reports a T() call whose literal ID is not in the source
+// translation file
+func checkTCall(pass *analysis.Pass, callExpr *ast.CallExpr, ids map[string]bool) {
+	if len(callExpr.Args) == 0 {
+		return
+	}
+
+	basicLit, ok := callExpr.Args[0].(*ast.BasicLit)
+	if !ok || basicLit.Kind != token.STRING {
+		return
+	}
+
+	id, err := strconv.Unquote(basicLit.Value)
+	if err != nil || ids[id] {
+		return
+	}
+
+	pass.Reportf(basicLit.Pos(), "T() ID %q is missing from %s", id, i18nStringsFilenameFlag)
+}
+
+func hasLetters(s string) bool {
+	return strings.IndexFunc(placeholderRegexp.ReplaceAllString(s, ""), unicode.IsLetter) >= 0
+}
+
+var (
+	idsMutex sync.Mutex
+	idsCache = make(map[string]map[string]bool)
+)
+
+// loadIDs returns the IDs of a translation file, loaded once for all the
+// packages analyzed
+func loadIDs(fileName string) (map[string]bool, error) {
+	idsMutex.Lock()
+	defer idsMutex.Unlock()
+
+	if ids, ok := idsCache[fileName]; ok {
+		return ids, nil
+	}
+
+	i18nStringInfos, err := common.LoadI18nStringInfos(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool, len(i18nStringInfos))
+	for _, i18nStringInfo := range i18nStringInfos {
+		ids[i18nStringInfo.ID] = true
+	}
+	idsCache[fileName] = ids
+
+	return ids, nil
+}
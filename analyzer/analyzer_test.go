@@ -0,0 +1,29 @@
+package analyzer_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/EverlongProject/i18n4go/analyzer"
+)
+
+func TestAnalyzer(t *testing.T) {
+	fixturesPath, err := filepath.Abs(filepath.Join("..", "test_fixtures", "analyzer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flags := map[string]string{
+		"sink-funcs":            "fmt.Printf,fmt.Println,errors.New,UI.Say",
+		"i18n-strings-filename": filepath.Join(fixturesPath, "en.all.json"),
+	}
+	for name, value := range flags {
+		if err := analyzer.Analyzer.Flags.Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	analysistest.RunWithSuggestedFixes(t, fixturesPath, analyzer.Analyzer, "app")
+}
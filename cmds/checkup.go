@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -19,6 +21,16 @@ type Checkup struct {
 	options common.Options
 
 	I18nStringInfos []common.I18nStringInfo
+	Findings        []common.Finding
+}
+
+// checkupStrings are the strings of the code, or of the translation files of
+// a locale, by ID
+type checkupStrings struct {
+	name    string
+	locale  string
+	files   []string
+	strings map[string]common.StringInfo
 }
 
 func NewCheckup(options common.Options) Checkup {
@@ -49,6 +61,11 @@ func (cu *Checkup) Printf(msg string, a ...interface{}) (int, error) {
 }
 
 func (cu *Checkup) Run() error {
+	err := common.CheckFindingsFormat(cu.options.FormatFlag)
+	if err != nil {
+		return err
+	}
+
 	//FIND PROBLEMS HERE AND RETURN AN ERROR
 	sourceStrings, err := cu.findSourceStrings()
 
@@ -57,43 +74,60 @@ func (cu *Checkup) Run() error {
 		return err
 	}
 
-	locales := findTranslationFiles(".")
+	locales := findTranslationFiles(cu.options.ResourcesDir())
 
-	englishFiles := locales["en_US"]
-	if englishFiles == nil {
-		cu.Println("Could not find an i18n file for locale: en_US")
-		return errors.New("Could not find an i18n file for locale: en_US")
+	sourceLocale := cu.options.SourceLocale()
+	sourceLocaleFiles := locales[sourceLocale]
+	if sourceLocaleFiles == nil {
+		cu.Println("Could not find an i18n file for locale: " + sourceLocale)
+		return errors.New("Could not find an i18n file for locale: " + sourceLocale)
 	}
 
-	englishStrings, err := cu.findI18nStrings(englishFiles)
+	sourceLocaleStrings, err := cu.findI18nStrings(sourceLocale, sourceLocaleFiles)
 
 	if err != nil {
-		cu.Println(fmt.Sprintf("Couldn't find the english strings: %s", err.Error()))
+		cu.Println(fmt.Sprintf("Couldn't find the %s strings: %s", sourceLocale, err.Error()))
 		return err
 	}
 
-	err = cu.diffStrings("the code", "en_US", sourceStrings, englishStrings)
+	diffErr := cu.diffStrings(sourceStrings, sourceLocaleStrings)
 
-	for locale, i18nFiles := range locales {
-		if locale == "en_US" {
+	for _, locale := range sortedLocales(locales) {
+		if locale == sourceLocale {
 			continue
 		}
 
-		translatedStrings, err := cu.findI18nStrings(i18nFiles)
+		translatedStrings, err := cu.findI18nStrings(locale, locales[locale])
 
 		if err != nil {
 			cu.Println(fmt.Sprintf("Couldn't get the strings from %s: %s", locale, err.Error()))
 			return err
 		}
 
-		err = cu.diffStrings("en_US", locale, englishStrings, translatedStrings)
+		if err = cu.diffStrings(sourceLocaleStrings, translatedStrings); err != nil {
+			diffErr = err
+		}
 	}
 
-	if err == nil {
+	if !common.IsTextFormat(cu.options.FormatFlag) {
+		if err = common.WriteFindings(os.Stdout, cu.options.FormatFlag, "checkup", cu.Findings); err != nil {
+			return err
+		}
+	} else if diffErr == nil {
 		cu.Printf("OK")
 	}
 
-	return err
+	return diffErr
+}
+
+func sortedLocales(locales map[string][]string) []string {
+	var sortedLocales []string
+	for locale := range locales {
+		sortedLocales = append(sortedLocales, locale)
+	}
+	sort.Strings(sortedLocales)
+
+	return sortedLocales
 }
 
 func getGoFiles(dir string) (files []string) {
@@ -114,46 +148,31 @@ func getGoFiles(dir string) (files []string) {
 	return
 }
 
-func (cu *Checkup) inspectFile(file string) (translatedStrings []string, err error) {
+func (cu *Checkup) inspectFile(file string) (translatedStrings []common.StringInfo, err error) {
 	fset := token.NewFileSet()
-	astFile, err := parser.ParseFile(fset, file, nil, parser.AllErrors)
+	astFile, err := parser.ParseFile(fset, file, nil, parser.ParseComments|parser.AllErrors)
 	if err != nil {
 		cu.Println(err)
 		return
 	}
 
+	directives := common.NewDirectives(fset, astFile)
 	ast.Inspect(astFile, func(n ast.Node) bool {
-		switch x := n.(type) {
-		case *ast.CallExpr:
-			switch x.Fun.(type) {
-			case *ast.Ident:
-				funName := x.Fun.(*ast.Ident).Name
-
-				if funName == "T" || funName == "t" {
-					if stringArg, ok := x.Args[0].(*ast.BasicLit); ok {
-						translatedString, err := strconv.Unquote(stringArg.Value)
-						if err != nil {
-							panic(err.Error())
-						}
-						translatedStrings = append(translatedStrings, translatedString)
-					}
-				}
-			case *ast.SelectorExpr:
-				expr := x.Fun.(*ast.SelectorExpr)
-				if ident, ok := expr.X.(*ast.Ident); ok {
-					funName := expr.Sel.Name
-					if ident.Name == cu.options.QualifierFlag && (funName == "T" || funName == "t") {
-						if stringArg, ok := x.Args[0].(*ast.BasicLit); ok {
-							translatedString, err := strconv.Unquote(stringArg.Value)
-							if err != nil {
-								panic(err.Error())
-							}
-							translatedStrings = append(translatedStrings, translatedString)
-						}
-					}
+		if x, ok := n.(*ast.CallExpr); ok && len(x.Args) > 0 && common.IsTCallExpr(x, cu.options.QualifierFlag, cu.options.TFuncs()) {
+			if stringArg, ok := x.Args[0].(*ast.BasicLit); ok && !directives.IsIgnored(stringArg.Pos()) {
+				translatedString, err := strconv.Unquote(stringArg.Value)
+				if err != nil {
+					panic(err.Error())
 				}
-			default:
-				//Skip!
+
+				position := fset.Position(stringArg.Pos())
+				translatedStrings = append(translatedStrings, common.StringInfo{
+					Filename: file,
+					Value:    translatedString,
+					Offset:   position.Offset,
+					Line:     position.Line,
+					Column:   position.Column,
+				})
 			}
 		}
 		return true
@@ -162,9 +181,9 @@ func (cu *Checkup) inspectFile(file string) (translatedStrings []string, err err
 	return
 }
 
-func (cu *Checkup) findSourceStrings() (sourceStrings map[string]string, err error) {
-	sourceStrings = make(map[string]string)
-	files := getGoFiles(".")
+func (cu *Checkup) findSourceStrings() (sourceStrings checkupStrings, err error) {
+	sourceStrings = checkupStrings{name: "the code", strings: make(map[string]common.StringInfo)}
+	files := getGoFiles(cu.options.SourcesDir())
 
 	for _, file := range files {
 		fileStrings, err := cu.inspectFile(file)
@@ -173,8 +192,10 @@ func (cu *Checkup) findSourceStrings() (sourceStrings map[string]string, err err
 			return sourceStrings, err
 		}
 
-		for _, string := range fileStrings {
-			sourceStrings[string] = string
+		for _, stringInfo := range fileStrings {
+			if _, ok := sourceStrings.strings[stringInfo.Value]; !ok {
+				sourceStrings.strings[stringInfo.Value] = stringInfo
+			}
 		}
 	}
 
@@ -239,38 +260,82 @@ func findTranslationFiles(dir string) (locales map[string][]string) {
 	return
 }
 
-func (cu *Checkup) findI18nStrings(i18nFiles []string) (i18nStrings map[string]string, err error) {
-	i18nStrings = make(map[string]string)
+// findI18nStrings returns the strings of the translation files of a locale,
+// the strings without translation are missing
+func (cu *Checkup) findI18nStrings(locale string, i18nFiles []string) (i18nStrings checkupStrings, err error) {
+	i18nStrings = checkupStrings{name: locale, locale: locale, files: i18nFiles, strings: make(map[string]common.StringInfo)}
 
 	for _, i18nFile := range i18nFiles {
 		stringInfos, err := common.LoadI18nStringInfos(i18nFile)
 
 		if err != nil {
-			return nil, err
+			return i18nStrings, err
 		}
 
 		for _, info := range stringInfos {
-			i18nStrings[info.ID] = info.Translation
+			if info.Translation != "" {
+				i18nStrings.strings[info.ID] = common.StringInfo{Filename: i18nFile, Value: info.ID}
+			}
 		}
 	}
 
 	return
 }
 
-func (cu *Checkup) diffStrings(sourceNameOne, sourceNameTwo string, stringsOne, stringsTwo map[string]string) (err error) {
-	for key, _ := range stringsOne {
-		if stringsTwo[key] == "" {
-			cu.Printf("\"%s\" exists in %s, but not in %s\n", key, sourceNameOne, sourceNameTwo)
-			err = errors.New("Strings don't match")
+// diffStrings reports the strings of one that are not in two and the other
+// way around, in the text format these are printed even without -v
+func (cu *Checkup) diffStrings(stringsOne, stringsTwo checkupStrings) (err error) {
+	for _, diff := range [][2]checkupStrings{{stringsOne, stringsTwo}, {stringsTwo, stringsOne}} {
+		from, to := diff[0], diff[1]
+
+		var keys []string
+		for key := range from.strings {
+			if _, ok := to.strings[key]; !ok {
+				keys = append(keys, key)
+			}
 		}
-	}
+		sort.Strings(keys)
 
-	for key, _ := range stringsTwo {
-		if stringsOne[key] == "" {
-			cu.Printf("\"%s\" exists in %s, but not in %s\n", key, sourceNameTwo, sourceNameOne)
+		for _, key := range keys {
+			finding := cu.newFinding(from, to, from.strings[key])
+			if common.IsTextFormat(cu.options.FormatFlag) {
+				fmt.Println(finding.Message)
+			}
+			cu.Findings = append(cu.Findings, finding)
 			err = errors.New("Strings don't match")
 		}
 	}
 
 	return
 }
+
+// newFinding returns the finding of a string of from that is not in to, the
+// code, the source locale or another locale
+func (cu *Checkup) newFinding(from, to checkupStrings, stringInfo common.StringInfo) common.Finding {
+	finding := common.Finding{
+		Severity: common.SEVERITY_ERROR,
+		Message:  fmt.Sprintf("\"%s\" exists in %s, but not in %s", stringInfo.Value, from.name, to.name),
+		Key:      stringInfo.Value,
+		Filename: stringInfo.Filename,
+		Line:     stringInfo.Line,
+		Column:   stringInfo.Column,
+	}
+
+	switch {
+	case from.locale == "":
+		finding.RuleID, finding.Locale = common.RULE_MISSING_STRING, to.locale
+		finding.Fix = fmt.Sprintf("Add %q to %s", stringInfo.Value, to.files[0])
+	case to.locale == "":
+		finding.RuleID, finding.Locale = common.RULE_ADDITIONAL_STRING, from.locale
+		finding.Fix = fmt.Sprintf("Remove %q from %s", stringInfo.Value, stringInfo.Filename)
+	case from.locale == cu.options.SourceLocale():
+		finding.RuleID, finding.Locale = common.RULE_MISSING_TRANSLATION, to.locale
+		finding.Filename = to.files[0]
+		finding.Fix = fmt.Sprintf("Add a translation of %q to %s", stringInfo.Value, to.files[0])
+	default:
+		finding.RuleID, finding.Locale = common.RULE_EXTRA_TRANSLATION, from.locale
+		finding.Fix = fmt.Sprintf("Remove %q from %s", stringInfo.Value, stringInfo.Filename)
+	}
+
+	return finding
+}
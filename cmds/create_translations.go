@@ -2,16 +2,15 @@ package cmds
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"encoding/json"
-	"io/ioutil"
-
-	"net/http"
-	"net/url"
 	"path/filepath"
 
 	"github.com/EverlongProject/i18n4go/common"
+	"github.com/EverlongProject/i18n4go/tm"
+	"github.com/EverlongProject/i18n4go/translators"
 )
 
 type createTranslations struct {
@@ -23,25 +22,14 @@ type createTranslations struct {
 
 	Languages []string
 
+	Memory *tm.Memory
+
 	ExtractedStrings map[string]common.StringInfo
 
 	TotalStrings int
 	TotalFiles   int
 }
 
-type GoogleTranslateData struct {
-	Data GoogleTranslateTranslations `json:"data"`
-}
-
-type GoogleTranslateTranslations struct {
-	Translations []GoogleTranslateTranslation `json:"translations"`
-}
-
-type GoogleTranslateTranslation struct {
-	TranslatedText         string `json:"translatedText"`
-	DetectedSourceLanguage string `json:"detectedSourceLanguage"`
-}
-
 func NewCreateTranslations(options common.Options) createTranslations {
 	languages := common.ParseStringList(options.LanguagesFlag, ",")
 
@@ -75,18 +63,39 @@ func (ct *createTranslations) Printf(msg string, a ...interface{}) (int, error)
 }
 
 func (ct *createTranslations) Run() error {
+	translator, err := ct.newTranslator()
+	if err != nil {
+		ct.Println(err)
+		return err
+	}
+
+	if ct.options.TmFilenameFlag != "" {
+		ct.Memory, err = tm.Load(ct.options.TmFilenameFlag)
+		if err != nil {
+			ct.Println(err)
+			return fmt.Errorf("i18n4go: could not load translation memory: %s", ct.options.TmFilenameFlag)
+		}
+		ct.Memory.FuzzyThreshold = ct.options.TmFuzzyThresholdFlag
+	}
+
 	ct.Println("i18n4go: creating translation files for:", ct.Filename)
 	ct.Println()
 
 	for _, language := range ct.Languages {
 		ct.Println("i18n4go: creating translation file copy for language:", language)
 
-		if ct.options.GoogleTranslateApiKeyFlag != "" {
-			destFilename, err := ct.createTranslationFileWithGoogleTranslate(language)
+		if translator != nil {
+			destFilename, err := ct.createTranslationFileWithTranslator(translator, language)
+			if err != nil {
+				return fmt.Errorf("i18n4go: could not create translation file for language: %s with translator: %s\nerr:%s", language, translator.Name(), err.Error())
+			}
+			ct.Println("i18n4go: created translation file with translator:", translator.Name(), destFilename)
+		} else if ct.Memory != nil {
+			destFilename, err := ct.createTranslationFileWithMemory(language)
 			if err != nil {
-				return fmt.Errorf("i18n4go: could not create translation file for language: %s with Google Translate", language)
+				return fmt.Errorf("i18n4go: could not create translation file for language: %s with translation memory\nerr:%s", language, err.Error())
 			}
-			ct.Println("i18n4go: created translation file with Google Translate:", destFilename)
+			ct.Println("i18n4go: created translation file with translation memory:", destFilename)
 		} else {
 			destFilename, err := ct.createTranslationFile(ct.Filename, language)
 			if err != nil {
@@ -98,10 +107,53 @@ func (ct *createTranslations) Run() error {
 
 	ct.Println()
 
+	if ct.Memory != nil && !ct.options.DryRunFlag {
+		err = ct.Memory.Save(ct.options.TmFilenameFlag)
+		if err != nil {
+			ct.Println(err)
+			return fmt.Errorf("i18n4go: could not save translation memory: %s", ct.options.TmFilenameFlag)
+		}
+	}
+
 	return nil
 }
 
-func (ct *createTranslations) createTranslationFileWithGoogleTranslate(language string) (string, error) {
+// newTranslator returns the translator selected with --translator or in the
+// --translator-config file, --google-translate-api-key selects google-v2, nil
+// means the translation files are copies of the source file
+func (ct *createTranslations) newTranslator() (translators.Translator, error) {
+	name := ct.options.TranslatorFlag
+	configFile := translators.ConfigFile{}
+
+	if ct.options.TranslatorConfigFlag != "" {
+		var err error
+		configFile, err = translators.LoadConfigFile(ct.options.TranslatorConfigFlag)
+		if err != nil {
+			return nil, err
+		}
+
+		if name == "" {
+			name = configFile.Translator
+		}
+	}
+
+	if name == "" && ct.options.GoogleTranslateApiKeyFlag != "" {
+		name = "google-v2"
+	}
+
+	if name == "" {
+		return nil, nil
+	}
+
+	config := configFile.Translators[name]
+	if config.APIKey == "" && config.APIKeyEnv == "" && ct.options.GoogleTranslateApiKeyFlag != "" {
+		config.APIKey = ct.options.GoogleTranslateApiKeyFlag
+	}
+
+	return translators.New(name, config)
+}
+
+func (ct *createTranslations) createTranslationFileWithTranslator(translator translators.Translator, language string) (string, error) {
 	fileName, _, err := common.CheckFile(ct.Filename)
 	if err != nil {
 		return "", err
@@ -125,26 +177,71 @@ func (ct *createTranslations) createTranslationFileWithGoogleTranslate(language
 		return "", fmt.Errorf("i18n4go: input file: %s is empty", ct.Filename)
 	}
 
-	ct.Println("i18n4go: attempting to use Google Translate to translate source strings in: ", language)
-	modifiedI18nStringInfos := make([]common.I18nStringInfo, len(i18nStringInfos))
-	for i, i18nStringInfo := range i18nStringInfos {
-		translation, _, err := ct.googleTranslate(i18nStringInfo.Translation, language)
+	remembered := ct.translateWithMemory(i18nStringInfos, language, destFilename)
+
+	progressFilename := destFilename + ".progress"
+	progress, err := ct.loadProgress(progressFilename)
+	if err != nil {
+		ct.Println(err)
+		return "", fmt.Errorf("i18n4go: could not load translation progress file: %s", progressFilename)
+	}
+
+	var ids, texts []string
+	for _, i18nStringInfo := range i18nStringInfos {
+		if _, ok := remembered[i18nStringInfo.ID]; ok || i18nStringInfo.IsPlural() {
+			continue
+		}
+
+		if progress[i18nStringInfo.ID].Source != i18nStringInfo.Translation {
+			ids = append(ids, i18nStringInfo.ID)
+			texts = append(texts, i18nStringInfo.Translation)
+		}
+	}
+
+	ct.Printf("i18n4go: attempting to use translator: %s to translate %d source strings in: %s, %d already translated\n", translator.Name(), len(texts), language, len(i18nStringInfos)-len(texts))
+	results := translators.TranslateAll(translator, texts, ct.SourceLanguage, language, ct.batchOptions(), func(results []translators.Result) {
+		for _, result := range results {
+			if result.Err == nil {
+				progress[ids[result.Index]] = translatedString{Source: result.Text, Translation: result.Translation}
+			}
+		}
+
+		err := ct.saveProgress(progress, progressFilename)
 		if err != nil {
-			ct.Println("i18n4go: error invoking Google Translate for string:", i18nStringInfo.Translation)
-		} else {
-			modifiedI18nStringInfos[i] = common.I18nStringInfo{ID: i18nStringInfo.ID, Translation: translation}
+			ct.Println("i18n4go: could not save translation progress file:", progressFilename, err)
+		}
+	})
+
+	var failures int
+	for _, result := range results {
+		if result.Err != nil {
+			failures++
+			fmt.Printf("i18n4go: ERROR could not translate string %q to %s: %s\n", ids[result.Index], language, result.Err.Error())
+		}
+	}
+
+	modifiedI18nStringInfos := []common.I18nStringInfo{}
+	for _, i18nStringInfo := range i18nStringInfos {
+		if rememberedI18nStringInfo, ok := remembered[i18nStringInfo.ID]; ok {
+			modifiedI18nStringInfos = append(modifiedI18nStringInfos, rememberedI18nStringInfo)
+		} else if i18nStringInfo.IsPlural() {
+			// translators do not know the plural categories of the language, copy the source ones for review
+			i18nStringInfo.Modified = true
+			modifiedI18nStringInfos = append(modifiedI18nStringInfos, i18nStringInfo)
+		} else if translated, ok := progress[i18nStringInfo.ID]; ok && translated.Source == i18nStringInfo.Translation {
+			modifiedI18nStringInfos = append(modifiedI18nStringInfos, common.I18nStringInfo{ID: i18nStringInfo.ID, Translation: translated.Translation})
 		}
 	}
 
 	err = common.SaveI18nStringInfos(ct, ct.Options(), modifiedI18nStringInfos, destFilename)
 	if err != nil {
 		ct.Println(err)
-		return "", fmt.Errorf("i18n4go: could not save Google Translate i18n strings to file: %s", destFilename)
+		return "", fmt.Errorf("i18n4go: could not save translated i18n strings to file: %s", destFilename)
 	}
 
 	if ct.options.PoFlag {
 		poFilename := destFilename[:len(destFilename)-len(".json")] + ".po"
-		err = common.SaveI18nStringsInPo(ct, ct.Options(), modifiedI18nStringInfos, poFilename)
+		err = common.SaveI18nStringsInPo(ct, ct.Options(), modifiedI18nStringInfos, language, poFilename)
 		if err != nil {
 			ct.Println(err)
 			return "", fmt.Errorf("i18n4go: could not save PO file: %s", poFilename)
@@ -153,55 +250,210 @@ func (ct *createTranslations) createTranslationFileWithGoogleTranslate(language
 
 	ct.Println()
 
+	if failures > 0 {
+		return "", fmt.Errorf("i18n4go: %d of %d strings could not be translated and are not in: %s, run the command again to retry them", failures, len(i18nStringInfos), destFilename)
+	}
+
+	if !ct.options.DryRunFlag {
+		os.Remove(progressFilename)
+	}
+
 	return destFilename, nil
 }
 
-func (ct *createTranslations) createTranslationFile(sourceFilename string, language string) (string, error) {
-	fileName, _, err := common.CheckFile(sourceFilename)
+// translatedString is a translation saved in the progress file, it is only
+// reused while its source string does not change
+type translatedString struct {
+	Source      string `json:"source"`
+	Translation string `json:"translation"`
+}
+
+// loadProgress loads the translations saved by a previous run that did not
+// translate all the strings, the progress file is removed once all are
+func (ct *createTranslations) loadProgress(progressFilename string) (map[string]translatedString, error) {
+	progress := make(map[string]translatedString)
+
+	content, err := os.ReadFile(progressFilename)
+	if os.IsNotExist(err) {
+		return progress, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(content, &progress)
+	if err != nil {
+		return nil, err
+	}
+
+	ct.Println("i18n4go: resuming translation with progress file:", progressFilename)
+
+	return progress, nil
+}
+
+func (ct *createTranslations) saveProgress(progress map[string]translatedString, progressFilename string) error {
+	if ct.options.DryRunFlag {
+		return nil
+	}
+
+	content, err := json.MarshalIndent(progress, "", "   ")
+	if err != nil {
+		return err
+	}
+
+	tmpFilename := progressFilename + ".tmp"
+	err = os.WriteFile(tmpFilename, common.UnescapeHTML(content), 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFilename, progressFilename)
+}
+
+func (ct *createTranslations) batchOptions() translators.BatchOptions {
+	return translators.BatchOptions{
+		BatchSize:   ct.options.TranslateBatchSizeFlag,
+		Concurrency: ct.options.TranslateConcurrencyFlag,
+		MaxRetries:  ct.options.TranslateMaxRetriesFlag,
+		RateLimit:   ct.options.TranslateRateLimitFlag,
+	}
+}
+
+// createTranslationFileWithMemory creates the translation file with the
+// strings found in the translation memory, the others are copies of the
+// source strings marked as modified
+func (ct *createTranslations) createTranslationFileWithMemory(language string) (string, error) {
+	fileName, _, err := common.CheckFile(ct.Filename)
 	if err != nil {
 		return "", err
 	}
 
-	i18nStringInfos, err := common.LoadI18nStringInfos(sourceFilename)
+	i18nStringInfos, err := common.LoadI18nStringInfos(ct.Filename)
 	if err != nil {
 		ct.Println(err)
-		return "", fmt.Errorf("i18n4go: could not load i18n strings from file: %s", sourceFilename)
+		return "", fmt.Errorf("i18n4go: could not load i18n strings from file: %s", ct.Filename)
 	}
 
 	if len(i18nStringInfos) == 0 {
-		return "", fmt.Errorf("i18n4go: input file: %s is empty", sourceFilename)
+		return "", fmt.Errorf("i18n4go: input file: %s is empty", ct.Filename)
+	}
+
+	err = common.CreateOutputDirsIfNeeded(ct.OutputDirname)
+	if err != nil {
+		ct.Println(err)
+		return "", fmt.Errorf("i18n4go: could not create output directory: %s", ct.OutputDirname)
 	}
 
 	destFilename := filepath.Join(ct.OutputDirname, strings.Replace(fileName, ct.options.SourceLanguageFlag, language, -1))
-	ct.Println("i18n4go: creating translation file:", destFilename)
+	remembered := ct.translateWithMemory(i18nStringInfos, language, destFilename)
+
+	modifiedI18nStringInfos := make([]common.I18nStringInfo, len(i18nStringInfos))
+	for i, i18nStringInfo := range i18nStringInfos {
+		rememberedI18nStringInfo, ok := remembered[i18nStringInfo.ID]
+		if !ok {
+			rememberedI18nStringInfo = i18nStringInfo
+			rememberedI18nStringInfo.Modified = true
+		}
+		modifiedI18nStringInfos[i] = rememberedI18nStringInfo
+	}
+
+	err = common.SaveI18nStringInfos(ct, ct.Options(), modifiedI18nStringInfos, destFilename)
+	if err != nil {
+		ct.Println(err)
+		return "", fmt.Errorf("i18n4go: could not save i18n strings to file: %s", destFilename)
+	}
 
-	return destFilename, common.CopyFileContents(sourceFilename, destFilename)
+	if ct.options.PoFlag {
+		poFilename := destFilename[:len(destFilename)-len(".json")] + ".po"
+		err = common.SaveI18nStringsInPo(ct, ct.Options(), modifiedI18nStringInfos, language, poFilename)
+		if err != nil {
+			ct.Println(err)
+			return "", fmt.Errorf("i18n4go: could not save PO file: %s", poFilename)
+		}
+	}
+
+	return destFilename, nil
 }
 
-func (ct *createTranslations) googleTranslate(translateString string, language string) (string, string, error) {
-	escapedTranslateString := url.QueryEscape(translateString)
-	googleTranslateUrl := "https://www.googleapis.com/language/translate/v2?key=" + ct.options.GoogleTranslateApiKeyFlag + "&target=" + language + "&q=" + escapedTranslateString
+// translateWithMemory adds the reviewed translations of the existing
+// translation file to the translation memory and returns the strings found
+// in it, fuzzy matches are marked as modified so reviewers check them
+func (ct *createTranslations) translateWithMemory(i18nStringInfos []common.I18nStringInfo, language, destFilename string) map[string]common.I18nStringInfo {
+	remembered := make(map[string]common.I18nStringInfo)
+	if ct.Memory == nil {
+		return remembered
+	}
 
-	response, err := http.Get(googleTranslateUrl)
-	if err != nil {
-		ct.Println("i18n4go: ERROR invoking Google Translate: ", googleTranslateUrl)
-		return "", "", err
+	if _, err := os.Stat(destFilename); err == nil {
+		existingI18nStringInfos, err := common.LoadI18nStringInfos(destFilename)
+		if err != nil {
+			ct.Println("i18n4go: could not add the translations of:", destFilename, "to the translation memory:", err)
+		} else {
+			count := ct.Memory.AddTranslations(ct.SourceLanguage, i18nStringInfos, language, existingI18nStringInfos)
+			ct.Printf("i18n4go: added %d translations of %s to the translation memory\n", count, destFilename)
+		}
+	}
+
+	var fuzzy int
+	for _, i18nStringInfo := range i18nStringInfos {
+		if i18nStringInfo.IsPlural() {
+			continue
+		}
+
+		match, ok := ct.Memory.Lookup(ct.SourceLanguage, i18nStringInfo.Translation, language)
+		if !ok {
+			continue
+		}
+
+		remembered[i18nStringInfo.ID] = common.I18nStringInfo{ID: i18nStringInfo.ID, Translation: match.Translation, Modified: !match.Exact()}
+		if !match.Exact() {
+			fuzzy++
+		}
 	}
 
-	defer response.Body.Close()
+	ct.Printf("i18n4go: found %d strings in the translation memory for: %s, %d of them fuzzy\n", len(remembered), language, fuzzy)
 
-	body, err := ioutil.ReadAll(response.Body)
+	return remembered
+}
+
+func (ct *createTranslations) createTranslationFile(sourceFilename string, language string) (string, error) {
+	fileName, _, err := common.CheckFile(sourceFilename)
 	if err != nil {
-		ct.Println("i18n4go: ERROR parsing Google Translate response body")
-		return "", "", err
+		return "", err
 	}
 
-	var googleTranslateData GoogleTranslateData
-	err = json.Unmarshal(body, &googleTranslateData)
+	i18nStringInfos, err := common.LoadI18nStringInfos(sourceFilename)
 	if err != nil {
-		ct.Println("i18n4go: ERROR parsing Google Translate response body")
-		return "", "", err
+		ct.Println(err)
+		return "", fmt.Errorf("i18n4go: could not load i18n strings from file: %s", sourceFilename)
 	}
 
-	return googleTranslateData.Data.Translations[0].TranslatedText, googleTranslateData.Data.Translations[0].DetectedSourceLanguage, err
+	if len(i18nStringInfos) == 0 {
+		return "", fmt.Errorf("i18n4go: input file: %s is empty", sourceFilename)
+	}
+
+	destFilename := filepath.Join(ct.OutputDirname, strings.Replace(fileName, ct.options.SourceLanguageFlag, language, -1))
+	ct.Println("i18n4go: creating translation file:", destFilename)
+
+	err = common.CopyFileContents(sourceFilename, destFilename)
+	if err != nil {
+		return "", err
+	}
+
+	if ct.options.PoFlag {
+		// the strings are not translated yet, so translators see them as such
+		untranslatedI18nStringInfos := make([]common.I18nStringInfo, len(i18nStringInfos))
+		for i, i18nStringInfo := range i18nStringInfos {
+			untranslatedI18nStringInfos[i] = common.I18nStringInfo{ID: i18nStringInfo.ID}
+		}
+
+		poFilename := destFilename[:len(destFilename)-len(".json")] + ".po"
+		err = common.SaveI18nStringsInPo(ct, ct.Options(), untranslatedI18nStringInfos, language, poFilename)
+		if err != nil {
+			ct.Println(err)
+			return "", fmt.Errorf("i18n4go: could not save PO file: %s", poFilename)
+		}
+	}
+
+	return destFilename, nil
 }
@@ -0,0 +1,61 @@
+package cmds
+
+import (
+	"fmt"
+
+	"github.com/EverlongProject/i18n4go/common"
+	"github.com/EverlongProject/i18n4go/tm"
+)
+
+type exportTmx struct {
+	options common.Options
+
+	TmFilename     string
+	TmxFilename    string
+	SourceLanguage string
+}
+
+func NewExportTmx(options common.Options) exportTmx {
+	return exportTmx{options: options,
+		TmFilename:     options.TmFilenameFlag,
+		TmxFilename:    options.TmxFilenameFlag,
+		SourceLanguage: options.SourceLanguageFlag,
+	}
+}
+
+func (et *exportTmx) Options() common.Options {
+	return et.options
+}
+
+func (et *exportTmx) Println(a ...interface{}) (int, error) {
+	if et.options.VerboseFlag {
+		return fmt.Println(a...)
+	}
+
+	return 0, nil
+}
+
+func (et *exportTmx) Printf(msg string, a ...interface{}) (int, error) {
+	if et.options.VerboseFlag {
+		return fmt.Printf(msg, a...)
+	}
+
+	return 0, nil
+}
+
+// Run writes the translations of the source language strings of the
+// translation memory in a TMX file, e.g., to share them with a vendor
+func (et *exportTmx) Run() error {
+	memory, err := tm.Load(et.TmFilename)
+	if err != nil {
+		et.Println(err)
+		return fmt.Errorf("i18n4go: could not load translation memory: %s", et.TmFilename)
+	}
+
+	et.Printf("i18n4go: exporting the translation memory %s to TMX file: %s\n", et.TmFilename, et.TmxFilename)
+	if et.options.DryRunFlag {
+		return nil
+	}
+
+	return memory.SaveTMX(et.TmxFilename, et.SourceLanguage)
+}
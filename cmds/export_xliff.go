@@ -0,0 +1,206 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/EverlongProject/i18n4go/common"
+)
+
+type exportXliff struct {
+	options common.Options
+
+	Filename          string
+	Dirname           string
+	OutputDirname     string
+	SourceLanguage    string
+	Languages         []string
+	LanguageFilenames []string
+	Version           string
+}
+
+func NewExportXliff(options common.Options) exportXliff {
+	return exportXliff{options: options,
+		Filename:          options.FilenameFlag,
+		Dirname:           options.DirnameFlag,
+		OutputDirname:     options.OutputDirFlag,
+		SourceLanguage:    options.SourceLanguageFlag,
+		Languages:         common.ParseStringList(options.LanguagesFlag, ","),
+		LanguageFilenames: common.ParseStringList(options.LanguageFilesFlag, ","),
+		Version:           options.XliffVersionFlag,
+	}
+}
+
+func (ex *exportXliff) Options() common.Options {
+	return ex.options
+}
+
+func (ex *exportXliff) Println(a ...interface{}) (int, error) {
+	if ex.options.VerboseFlag {
+		return fmt.Println(a...)
+	}
+
+	return 0, nil
+}
+
+func (ex *exportXliff) Printf(msg string, a ...interface{}) (int, error) {
+	if ex.options.VerboseFlag {
+		return fmt.Printf(msg, a...)
+	}
+
+	return 0, nil
+}
+
+func (ex *exportXliff) Run() error {
+	sourceI18nStringInfos, err := common.LoadI18nStringInfos(ex.Filename)
+	if err != nil {
+		ex.Println(err)
+		return fmt.Errorf("i18n4go: could not load i18n strings from file: %s", ex.Filename)
+	}
+
+	notes, comments := make(map[string][]string), make(map[string][]string)
+	if ex.Dirname != "" {
+		notes, comments, err = ex.loadNotes(ex.Dirname)
+		if err != nil {
+			ex.Println(err)
+			return fmt.Errorf("i18n4go: could not load the *.extracted.json files in: %s", ex.Dirname)
+		}
+	}
+
+	fileName, filePath, err := common.CheckFile(ex.Filename)
+	if err != nil {
+		return err
+	}
+
+	outputDirname := ex.OutputDirname
+	if outputDirname == "" {
+		outputDirname = filePath
+	}
+
+	if !ex.options.DryRunFlag {
+		err = common.CreateOutputDirsIfNeeded(outputDirname)
+		if err != nil {
+			ex.Println(err)
+			return fmt.Errorf("i18n4go: could not create output directory: %s", outputDirname)
+		}
+	}
+
+	languages, targetFilenames := ex.determineTargetFilenames(fileName, filePath)
+	for i, targetFilename := range targetFilenames {
+		xliffFilename := filepath.Join(outputDirname, strings.TrimSuffix(filepath.Base(targetFilename), ".json")+".xlf")
+
+		err = ex.exportXliffFile(sourceI18nStringInfos, notes, comments, languages[i], targetFilename, xliffFilename)
+		if err != nil {
+			ex.Println(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// determineTargetFilenames returns the languages and translation files to
+// export, either from --language-files or from --languages like verify-strings
+func (ex *exportXliff) determineTargetFilenames(fileName, filePath string) ([]string, []string) {
+	if len(ex.LanguageFilenames) != 0 {
+		languages := make([]string, len(ex.LanguageFilenames))
+		for i, languageFilename := range ex.LanguageFilenames {
+			languages[i] = strings.SplitN(filepath.Base(languageFilename), ".", 2)[0]
+		}
+		return languages, ex.LanguageFilenames
+	}
+
+	targetFilenames := make([]string, len(ex.Languages))
+	for i, language := range ex.Languages {
+		targetFilenames[i] = filepath.Join(filePath, strings.Replace(fileName, ex.SourceLanguage, language, -1))
+	}
+
+	return ex.Languages, targetFilenames
+}
+
+func (ex *exportXliff) exportXliffFile(sourceI18nStringInfos []common.I18nStringInfo, notes, comments map[string][]string, language, targetFilename, xliffFilename string) error {
+	xliff, err := common.NewXliffFile(ex.Version, filepath.Base(ex.Filename), ex.SourceLanguage, language)
+	if err != nil {
+		return err
+	}
+
+	targetI18nStringInfos := map[string]common.I18nStringInfo{}
+	if _, err := os.Stat(targetFilename); err == nil {
+		targetStringInfos, err := common.LoadI18nStringInfos(targetFilename)
+		if err != nil {
+			return fmt.Errorf("i18n4go: could not load i18n strings from file: %s", targetFilename)
+		}
+
+		targetI18nStringInfos, err = common.CreateI18nStringInfoMap(targetStringInfos)
+		if err != nil {
+			return err
+		}
+	} else {
+		ex.Println("i18n4go: no translation file, exporting the strings without targets:", targetFilename)
+	}
+
+	for _, sourceI18nStringInfo := range sourceI18nStringInfos {
+		if sourceI18nStringInfo.IsPlural() {
+			ex.Println("i18n4go: XLIFF units are not plural, not exporting plural string:", sourceI18nStringInfo.ID)
+			continue
+		}
+
+		unit := common.XliffUnit{
+			ID:       sourceI18nStringInfo.ID,
+			Source:   sourceI18nStringInfo.Translation,
+			State:    common.XLIFF_STATE_NEW,
+			Notes:    notes[sourceI18nStringInfo.ID],
+			Comments: comments[sourceI18nStringInfo.ID],
+		}
+
+		if targetI18nStringInfo, ok := targetI18nStringInfos[sourceI18nStringInfo.ID]; ok {
+			unit.Target, unit.HasTarget = targetI18nStringInfo.Translation, true
+			unit.State = common.XLIFF_STATE_TRANSLATED
+			if targetI18nStringInfo.Modified {
+				unit.State = common.XLIFF_STATE_NEEDS_REVIEW
+			}
+		}
+
+		xliff.Units = append(xliff.Units, unit)
+	}
+
+	ex.Printf("i18n4go: exporting %d strings to XLIFF %s file: %s\n", len(xliff.Units), ex.Version, xliffFilename)
+	if ex.options.DryRunFlag {
+		return nil
+	}
+
+	return xliff.Save(xliffFilename)
+}
+
+// loadNotes returns the file:line locations and the contexts and translator
+// comments of the strings in the *.extracted.json files created by
+// extract-strings --meta, the units are keyed by the IDs of the translation
+// files which have no context so the contexts of a string are notes of its
+// single unit
+func (ex *exportXliff) loadNotes(dirName string) (map[string][]string, map[string][]string, error) {
+	stringInfosByValue, err := common.LoadExtractedStringInfos(dirName)
+
+	notes, comments := make(map[string][]string), make(map[string][]string)
+	for value, stringInfos := range stringInfosByValue {
+		contexts := make(map[string]bool)
+		for _, stringInfo := range stringInfos {
+			notes[value] = append(notes[value], stringInfo.Filename+":"+strconv.Itoa(stringInfo.Line))
+			if stringInfo.Context != "" {
+				comments[value] = common.MergeNotes(comments[value], []string{"context: " + stringInfo.Context})
+			}
+			comments[value] = common.MergeNotes(comments[value], stringInfo.Comments)
+			contexts[stringInfo.Context] = true
+		}
+		sort.Strings(notes[value])
+
+		if len(contexts) > 1 {
+			ex.Println("i18n4go: WARNING string in several contexts exported as a single unit with the contexts as notes:", value)
+		}
+	}
+
+	return notes, comments, err
+}
@@ -5,20 +5,18 @@ import (
 	"os"
 	"regexp"
 	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"go/ast"
-	"go/build"
 	"go/parser"
 	"go/token"
 
 	"path/filepath"
 
-	"bufio"
 	"encoding/json"
-	"errors"
-	"io"
 	"io/ioutil"
 
 	"github.com/EverlongProject/i18n4go/common"
@@ -48,6 +46,15 @@ type extractStrings struct {
 	TotalFiles      int
 
 	IgnoreRegexp *regexp.Regexp
+
+	annotations     *common.Annotations
+	directives      *common.Directives
+	sourceLines     []string
+	importedStrings map[string]bool
+
+	cache            *common.Cache
+	changedFiles     map[string]bool
+	TotalCachedFiles int
 }
 
 func NewExtractStrings(options common.Options) extractStrings {
@@ -100,6 +107,7 @@ func (es *extractStrings) Run() error {
 		es.Println(err)
 		return err
 	}
+	es.excludeStrings(es.options.Config.Excluded)
 	es.Println(fmt.Sprintf("Loaded %d excluded strings", len(es.FilteredStrings)))
 
 	err = es.loadExcludedRegexps()
@@ -107,6 +115,7 @@ func (es *extractStrings) Run() error {
 		es.Println(err)
 		return err
 	}
+	es.excludeRegexps(es.options.Config.Excluded.ExcludedRegexps)
 	es.Println(fmt.Sprintf("Loaded %d excluded regexps", len(es.FilteredRegexps)))
 
 	if es.options.SubstringFilenameFlag != "" {
@@ -117,83 +126,207 @@ func (es *extractStrings) Run() error {
 		}
 		es.Println(fmt.Sprintf("Loaded %d substring regexps", len(es.FilteredRegexps)))
 	}
-	if es.options.FilenameFlag != "" {
-		return es.InspectFile(es.options.FilenameFlag)
-	} else {
-		err := es.InspectDir(es.options.DirnameFlag, es.options.RecurseFlag)
+	if es.options.SinkFuncsFlag != "" {
+		if es.options.CacheDirFlag != "" || es.options.SinceFlag != "" {
+			es.Println("WARNING the extraction cache and --since are not used with --sink-funcs")
+		}
+
+		err := es.InspectTypedPackages()
 		if err != nil {
-			es.Println("i18n4go: could not extract strings from directory:", es.options.DirnameFlag)
+			es.Println("i18n4go: could not extract strings from packages with type information")
 			return err
 		}
 		es.Println()
 		es.Println("Total files parsed:", es.TotalFiles)
 		es.Println("Total extracted strings:", es.TotalStrings)
+	} else {
+		err := es.loadCache()
+		if err != nil {
+			es.Println("i18n4go: could not load the extraction cache or the changed files:", err)
+			return err
+		}
+
+		if es.options.FilenameFlag != "" {
+			err = es.InspectFile(es.options.FilenameFlag)
+			if err != nil {
+				return err
+			}
+		} else {
+			err = es.InspectDir(es.options.DirnameFlag, es.options.RecurseFlag)
+			if err != nil {
+				es.Println("i18n4go: could not extract strings from directory:", es.options.DirnameFlag)
+				return err
+			}
+			es.Println()
+			es.Println("Total files parsed:", es.TotalFiles)
+			if es.cache != nil {
+				es.Println("Total files unchanged:", es.TotalCachedFiles)
+			}
+			es.Println("Total extracted strings:", es.TotalStrings)
+		}
+
+		err = es.saveCache()
+		if err != nil {
+			es.Println("i18n4go: could not save the extraction cache:", err)
+			return err
+		}
 	}
 	return nil
 }
 
-func (es *extractStrings) InspectFile(filename string) error {
-	es.Println("i18n4go: extracting strings from file:", filename)
-	if es.options.DryRunFlag {
-		es.Println("WARNING running in -dry-run mode")
-	}
+// extractedFile is the extraction of a file, the files of a dir are extracted
+// in parallel and then saved in order
+type extractedFile struct {
+	fileName    string
+	absFilePath string
+	hash        string
+	strings     map[string]common.StringInfo
+
+	// skipped files have nothing to save, unchanged files have their outputs
+	skipped   bool
+	unchanged bool
+	err       error
+}
 
-	es.setFilename(filename)
-	es.setI18nFilename(filename)
-	es.setPoFilename(filename)
+func (es *extractStrings) InspectFile(filename string) error {
+	return es.saveExtractedFile(es.extractFile(filename))
+}
 
-	fset := token.NewFileSet()
+// extractFile extracts the strings of a file without changing es, so that
+// several files are extracted at the same time
+func (es *extractStrings) extractFile(filename string) extractedFile {
+	es.Println("i18n4go: extracting strings from file:", filename)
 
 	var absFilePath = filename
 	if !filepath.IsAbs(absFilePath) {
 		absFilePath = filepath.Join(os.Getenv("PWD"), absFilePath)
 	}
+	extracted := extractedFile{fileName: filename, absFilePath: absFilePath}
 
 	fileInfo, err := common.GetAbsFileInfo(absFilePath)
 	if err != nil {
-		es.Println(err)
+		extracted.err = err
+		return extracted
 	}
 
 	if strings.HasPrefix(fileInfo.Name(), ".") {
 		es.Println("WARNING ignoring file:", absFilePath)
-		return nil
+		extracted.skipped = true
+		return extracted
+	}
+
+	if es.changedFiles != nil && !es.changedFiles[common.RealPath(absFilePath)] {
+		es.Println("i18n4go: skipping file unchanged since", es.options.SinceFlag+":", absFilePath)
+		extracted.skipped = true
+		return extracted
 	}
 
-	astFile, err := parser.ParseFile(fset, absFilePath, nil, parser.ParseComments|parser.AllErrors)
+	src, err := os.ReadFile(absFilePath)
 	if err != nil {
-		es.Println(err)
-		return err
+		extracted.err = err
+		return extracted
 	}
 
-	es.excludeImports(astFile)
+	extracted.hash = common.HashContent(src)
+	if entry, ok := es.cache.Get(absFilePath, extracted.hash); ok {
+		if outputsExist(entry.Outputs) {
+			es.Println("i18n4go: skipping file unchanged since the previous extraction:", absFilePath)
+			extracted.unchanged = true
+			return extracted
+		}
 
-	es.extractString(astFile, fset)
+		// the outputs were removed, save them again without parsing the file
+		extracted.strings = make(map[string]common.StringInfo)
+		for _, stringInfo := range entry.Strings {
+			extracted.strings[stringInfo.Key()] = stringInfo
+		}
+		return extracted
+	}
+
+	// the strings of the file are collected by a copy of es
+	worker := *es
+	worker.ExtractedStrings = make(map[string]common.StringInfo)
+	if common.IsTemplateFile(absFilePath) {
+		extracted.err = worker.extractTemplateStrings(absFilePath, src)
+	} else {
+		fset := token.NewFileSet()
+		astFile, err := parser.ParseFile(fset, absFilePath, src, parser.ParseComments|parser.AllErrors)
+		if err != nil {
+			extracted.err = err
+			return extracted
+		}
+		worker.sourceLines = strings.Split(string(src), "\n")
+
+		worker.excludeImports(astFile)
+
+		worker.extractString(astFile, fset)
+	}
+	extracted.strings = worker.ExtractedStrings
+
+	return extracted
+}
+
+// saveExtractedFile saves the outputs of an extracted file and caches them
+func (es *extractStrings) saveExtractedFile(extracted extractedFile) error {
+	if extracted.err != nil {
+		es.Println(extracted.err)
+		return extracted.err
+	}
+	if extracted.skipped {
+		return nil
+	}
+	if extracted.unchanged {
+		es.TotalCachedFiles += 1
+		return nil
+	}
+
+	if es.options.DryRunFlag {
+		es.Println("WARNING running in -dry-run mode")
+	}
+
+	es.setFilename(extracted.fileName)
+	es.setI18nFilename(extracted.fileName)
+	es.setPoFilename(extracted.fileName)
+
+	es.ExtractedStrings = extracted.strings
 	es.TotalStringsDir += len(es.ExtractedStrings)
 	es.TotalStrings += len(es.ExtractedStrings)
 	es.TotalFiles += 1
 
-	es.Printf("Extracted %d strings from file: %s\n", len(es.ExtractedStrings), absFilePath)
+	es.Printf("Extracted %d strings from file: %s\n", len(es.ExtractedStrings), extracted.absFilePath)
+
+	outputs, err := es.saveExtractedFiles(extracted.absFilePath)
+	if err != nil {
+		return err
+	}
+
+	return es.cacheExtractedStrings(extracted.absFilePath, extracted.hash, outputs)
+}
 
+// saveExtractedFiles saves the strings extracted from a file and returns the
+// output files written
+func (es *extractStrings) saveExtractedFiles(absFilePath string) ([]string, error) {
+	var err error
 	var outputDirname = es.OutputDirname
 	if es.options.OutputDirFlag != "" {
 		if es.options.OutputMatchImportFlag {
 			outputDirname, err = es.findImportPath(absFilePath)
 			if err != nil {
 				es.Println(err)
-				return err
+				return nil, err
 			}
 		} else if es.options.OutputMatchPackageFlag {
 			outputDirname, err = es.findPackagePath(absFilePath)
 			if err != nil {
 				es.Println(err)
-				return err
+				return nil, err
 			}
 		}
 	} else {
 		outputDirname, err = common.FindFilePath(absFilePath)
 		if err != nil {
 			es.Println(err)
-			return err
+			return nil, err
 		}
 	}
 
@@ -201,43 +334,85 @@ func (es *extractStrings) InspectFile(filename string) error {
 		err = es.saveExtractedStrings(outputDirname)
 		if err != nil {
 			es.Println(err)
-			return err
+			return nil, err
 		}
 	}
 
 	err = common.SaveStrings(es, es.Options(), es.ExtractedStrings, outputDirname, es.i18nFilename)
 	if err != nil {
 		es.Println(err)
-		return err
+		return nil, err
 	}
 
 	if es.options.PoFlag {
 		err = common.SaveStringsInPo(es, es.Options(), es.ExtractedStrings, outputDirname, es.poFilename)
 		if err != nil {
 			es.Println(err)
-			return err
+			return nil, err
 		}
 	}
 
-	return nil
+	return es.outputFilenames(outputDirname), nil
+}
+
+// outputFilenames returns the output files of the strings extracted from a
+// file, the outputs are only written when there are strings
+func (es *extractStrings) outputFilenames(outputDirname string) []string {
+	if es.options.DryRunFlag || len(es.ExtractedStrings) == 0 {
+		return nil
+	}
+
+	outputFilenames := []string{filepath.Join(outputDirname, strings.Replace(es.i18nFilename, string(os.PathSeparator), "-", -1))}
+	if es.options.MetaFlag {
+		outputFilenames = append(outputFilenames, filepath.Join(outputDirname, filepath.Base(es.Filename)))
+	}
+	if es.options.PoFlag {
+		outputFilenames = append(outputFilenames, filepath.Join(outputDirname, filepath.Base(es.poFilename)))
+	}
+
+	return outputFilenames
 }
 
 func (es *extractStrings) InspectDir(dirName string, recursive bool) error {
 	es.Printf("i18n4go: inspecting dir %s, recursive: %t\n", dirName, recursive)
 	es.Println()
 
-	fset := token.NewFileSet()
-	es.TotalStringsDir = 0
-
-	packages, err := parser.ParseDir(fset, dirName, nil, parser.ParseComments)
+	fileNames, err := es.dirFilenames(dirName, recursive)
 	if err != nil {
 		es.Println(err)
 		return err
 	}
 
-	for k, pkg := range packages {
-		es.Println("Extracting strings in package:", k)
-		for fileName := range pkg.Files {
+	es.TotalStringsDir = 0
+	for _, extracted := range es.extractFiles(fileNames) {
+		// the errors of a file do not stop the extraction of the others
+		es.saveExtractedFile(extracted)
+	}
+	es.Printf("Extracted total of %d strings\n\n", es.TotalStringsDir)
+
+	return nil
+}
+
+// dirFilenames returns the sorted Go and template files of a dir followed by
+// those of its subdirs when recursive
+func (es *extractStrings) dirFilenames(dirName string, recursive bool) ([]string, error) {
+	// the files are parsed by the workers, only their packages are needed here
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, dirName, es.changedFilter(dirName), parser.PackageClauseOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	packageNames := make([]string, 0, len(packages))
+	for packageName := range packages {
+		packageNames = append(packageNames, packageName)
+	}
+	sort.Strings(packageNames)
+
+	var fileNames []string
+	for _, packageName := range packageNames {
+		es.Println("Extracting strings in package:", packageName)
+		for fileName := range packages[packageName].Files {
 			if es.IgnoreRegexp != nil && es.IgnoreRegexp.MatchString(fileName) {
 				es.Println("Using ignore-regexp:", es.options.IgnoreRegexpFlag)
 				continue
@@ -253,28 +428,58 @@ func (es *extractStrings) InspectDir(dirName string, recursive bool) error {
 			}
 
 			if strings.HasSuffix(fileName, ".go") {
-				err = es.InspectFile(fileName)
-				if err != nil {
-					es.Println(err)
-				}
+				fileNames = append(fileNames, fileName)
 			}
 		}
 	}
-	es.Printf("Extracted total of %d strings\n\n", es.TotalStringsDir)
+	sort.Strings(fileNames)
+	fileNames = append(fileNames, es.templateFilenames(dirName)...)
 
 	if recursive {
 		fileInfos, _ := ioutil.ReadDir(dirName)
 		for _, fileInfo := range fileInfos {
 			if fileInfo.IsDir() && !strings.HasPrefix(fileInfo.Name(), ".") {
-				err = es.InspectDir(filepath.Join(dirName, fileInfo.Name()), recursive)
+				subdirFilenames, err := es.dirFilenames(filepath.Join(dirName, fileInfo.Name()), recursive)
 				if err != nil {
 					es.Println(err)
+					continue
 				}
+				fileNames = append(fileNames, subdirFilenames...)
 			}
 		}
 	}
 
-	return nil
+	return fileNames, nil
+}
+
+// extractFiles extracts the files with a bounded pool of workers, the
+// extracted files are in the order of the file names
+func (es *extractStrings) extractFiles(fileNames []string) []extractedFile {
+	concurrency := es.options.ExtractConcurrencyFlag
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	extractedFiles := make([]extractedFile, len(fileNames))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				extractedFiles[index] = es.extractFile(fileNames[index])
+			}
+		}()
+	}
+
+	for index := range fileNames {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	return extractedFiles
 }
 
 func (es *extractStrings) findImportPath(filename string) (string, error) {
@@ -286,31 +491,29 @@ func (es *extractStrings) findImportPath(filename string) (string, error) {
 		return "", err
 	}
 
-	pkg, err := build.ImportDir(filePath, 0)
-	srcPath := "src" + string(os.PathSeparator)
-	if strings.HasPrefix(pkg.Dir, srcPath) {
-		path = filepath.Join(path, pkg.Dir[len(srcPath):len(pkg.Dir)])
+	importPath, err := common.ImportPathForDir(filePath)
+	if err != nil {
+		fmt.Println("ERROR determining import path", err)
+		return "", err
 	}
 
-	return path, nil
+	return filepath.Join(path, filepath.FromSlash(importPath)), nil
 }
 
 func (es *extractStrings) findPackagePath(filename string) (string, error) {
 	path := es.OutputDirname
 
-	filePath, err := common.FindFilePath(filename)
-	if err != nil {
-		fmt.Println("ERROR opening file", err)
-		return "", err
+	if common.IsTemplateFile(filename) {
+		return filepath.Join(path, templatePackageName(filename)), nil
 	}
 
-	pkg, err := build.ImportDir(filePath, 0)
+	astFile, err := parser.ParseFile(token.NewFileSet(), filename, nil, parser.PackageClauseOnly)
 	if err != nil {
 		fmt.Println("ERROR opening file", err)
 		return "", err
 	}
 
-	return filepath.Join(path, pkg.Name), nil
+	return filepath.Join(path, astFile.Name.Name), nil
 }
 
 func (es *extractStrings) saveExtractedStrings(outputDirname string) error {
@@ -327,7 +530,7 @@ func (es *extractStrings) saveExtractedStrings(outputDirname string) error {
 	}
 
 	stringInfos := make([]common.StringInfo, 0)
-	for _, stringInfo := range es.ExtractedStrings {
+	for _, stringInfo := range common.SortedStringInfos(es.ExtractedStrings) {
 		stringInfo.Filename = strings.Split(es.Filename, ".extracted.json")[0]
 
 		stringInfos = append(stringInfos, stringInfo)
@@ -388,6 +591,14 @@ func (es *extractStrings) loadExcludedStrings() error {
 		return err
 	}
 
+	es.excludeStrings(excludedStrings)
+
+	return nil
+}
+
+// excludeStrings adds the excluded strings, lines, file regexps and enforced
+// funcs of an excluded.json file or of the configuration file
+func (es *extractStrings) excludeStrings(excludedStrings common.ExcludedStrings) {
 	for i := range excludedStrings.ExcludedStrings {
 		es.FilteredStrings[excludedStrings.ExcludedStrings[i]] = excludedStrings.ExcludedStrings[i]
 	}
@@ -398,14 +609,15 @@ func (es *extractStrings) loadExcludedStrings() error {
 
 	if len(excludedStrings.ExcludedFileRegexps) > 0 {
 		excludeFileRegexs := strings.Join(excludedStrings.ExcludedFileRegexps, "|")
+		if es.FilteredFileRegexps != nil {
+			excludeFileRegexs = es.FilteredFileRegexps.String() + "|" + excludeFileRegexs
+		}
 		es.FilteredFileRegexps = regexp.MustCompile(excludeFileRegexs)
 	}
 
 	for _, enforcedFunc := range excludedStrings.EnforcedFuncs {
 		es.EnforcedFuncs = append(es.EnforcedFuncs, enforcedFunc)
 	}
-
-	return nil
 }
 
 func (es *extractStrings) loadExcludedRegexps() error {
@@ -430,7 +642,13 @@ func (es *extractStrings) loadExcludedRegexps() error {
 		return err
 	}
 
-	for _, regexpString := range excludedRegexps.ExcludedRegexps {
+	es.excludeRegexps(excludedRegexps.ExcludedRegexps)
+
+	return nil
+}
+
+func (es *extractStrings) excludeRegexps(regexpStrings []string) {
+	for _, regexpString := range regexpStrings {
 		compiledRegexp, err := regexp.Compile(regexpString)
 		if err != nil {
 			fmt.Println("WARNING error compiling regexp:", regexpString)
@@ -438,8 +656,6 @@ func (es *extractStrings) loadExcludedRegexps() error {
 
 		es.FilteredRegexps = append(es.FilteredRegexps, compiledRegexp)
 	}
-
-	return nil
 }
 
 type CaptureGroupSubstrings struct {
@@ -480,11 +696,27 @@ func (es *extractStrings) loadSubstringRegexps() error {
 }
 
 func (es *extractStrings) extractString(f *ast.File, fset *token.FileSet) error {
+	es.directives = common.NewDirectives(fset, f)
+	if es.directives.IsFileIgnored() {
+		es.Println("i18n4go: ignoring strings of file with", common.IGNORE_FILE_DIRECTIVE, "directive:", fset.Position(f.Pos()).Filename)
+		return nil
+	}
+
+	es.annotations = common.NewAnnotations(fset, f)
+	templateLits := make(map[*ast.BasicLit]bool)
 	ast.Inspect(f, func(n ast.Node) bool {
 		switch x := n.(type) {
 		case *ast.CallExpr:
+			if basicLit := common.TemplateParseLit(x, f); basicLit != nil {
+				// the strings of templates are their texts, not the whole template
+				templateLits[basicLit] = true
+				es.processTemplateLit(basicLit, fset)
+			}
 			es.processEnforcedFunc(x, fset, f.Comments)
 		case *ast.BasicLit:
+			if templateLits[x] {
+				return true
+			}
 			es.processBasicLit(x, n, fset, f.Comments, false)
 		case *ast.Comment:
 		}
@@ -497,6 +729,9 @@ func (es *extractStrings) extractString(f *ast.File, fset *token.FileSet) error
 var commentRegex = regexp.MustCompile(`locales:([\w\-\,]+)`)
 
 func (es *extractStrings) processBasicLit(basicLit *ast.BasicLit, n ast.Node, fset *token.FileSet, comments []*ast.CommentGroup, mustInclude bool) {
+	if es.directives.IsIgnored(basicLit.Pos()) {
+		return
+	}
 
 	var locales []string
 	commentMap := ast.NewCommentMap(fset, n, comments)
@@ -509,6 +744,8 @@ func (es *extractStrings) processBasicLit(basicLit *ast.BasicLit, n ast.Node, fs
 		}
 	}
 
+	notes, context := es.annotations.Find(basicLit.Pos())
+
 	foundSubstring := false
 	for _, compiledRegexp := range es.SubstringRegexps {
 		if compiledRegexp.MatchString(basicLit.Value) {
@@ -520,18 +757,15 @@ func (es *extractStrings) processBasicLit(basicLit *ast.BasicLit, n ast.Node, fs
 			captureGroup := submatches[1]
 			position := fset.Position(n.Pos())
 
-			stringInfo := common.StringInfo{Value: captureGroup,
+			es.addStringInfo(common.StringInfo{Value: captureGroup,
 				Filename: position.Filename,
 				Offset:   position.Offset,
 				Line:     position.Line,
 				Column:   position.Column,
 				Locales:  locales,
-			}
-			if existing, ok := es.ExtractedStrings[captureGroup]; ok {
-				// we already found a string matching this, take the union of their locales so we don't miss any
-				stringInfo.Locales = mergeLocales(stringInfo.Locales, existing.Locales)
-			}
-			es.ExtractedStrings[captureGroup] = stringInfo
+				Comments: notes,
+				Context:  context,
+			})
 			foundSubstring = true
 		}
 	}
@@ -541,7 +775,7 @@ func (es *extractStrings) processBasicLit(basicLit *ast.BasicLit, n ast.Node, fs
 
 	if len(es.FilteredRegexps) > 0 && !mustInclude {
 		// If we want to filter out some strings based on a substring in that line of code
-		if line, err := readLine(fset.Position(n.Pos()).Filename, fset.Position(n.Pos()).Line); err == nil {
+		if line := es.sourceLine(fset.Position(n.Pos()).Line); line != "" {
 			for _, exclude := range es.FilteredLines {
 				if strings.Contains(line, exclude) {
 					return
@@ -554,19 +788,27 @@ func (es *extractStrings) processBasicLit(basicLit *ast.BasicLit, n ast.Node, fs
 	if len(s) > 0 && basicLit.Kind == token.STRING && s != "\t" && s != "\n" && s != " " && !es.filter(s) { // TODO: fix to remove these: s != "\\t" && s != "\\n" && s != " "
 		position := fset.Position(n.Pos())
 
-		stringInfo := common.StringInfo{Value: s,
+		es.addStringInfo(common.StringInfo{Value: s,
 			Filename: position.Filename,
 			Offset:   position.Offset,
 			Line:     position.Line,
 			Column:   position.Column,
 			Locales:  locales,
-		}
-		if existing, ok := es.ExtractedStrings[s]; ok {
-			// we already found a string matching this, take the union of their locales so we don't miss any
-			stringInfo.Locales = mergeLocales(stringInfo.Locales, existing.Locales)
-		}
-		es.ExtractedStrings[s] = stringInfo
+			Comments: notes,
+			Context:  context,
+		})
+	}
+}
+
+// addStringInfo adds an extracted string by its key, so the same string in
+// different contexts is kept apart
+func (es *extractStrings) addStringInfo(stringInfo common.StringInfo) {
+	if existing, ok := es.ExtractedStrings[stringInfo.Key()]; ok {
+		// we already found a string matching this, take the union of their locales so we don't miss any
+		stringInfo.Locales = mergeLocales(stringInfo.Locales, existing.Locales)
+		stringInfo.Comments = common.MergeNotes(existing.Comments, stringInfo.Comments)
 	}
+	es.ExtractedStrings[stringInfo.Key()] = stringInfo
 }
 
 func mergeLocales(a, b []string) []string {
@@ -578,45 +820,22 @@ func mergeLocales(a, b []string) []string {
 	return slices.Compact(slices.Sorted(slices.Values(append(a, b...))))
 }
 
-func readLine(fn string, n int) (string, error) {
-	if n < 1 {
-		return "", fmt.Errorf("invalid request: line %d", n)
-	}
-	f, err := os.Open(fn)
-	if err != nil {
-		return "", err
-	}
-	defer f.Close()
-	bf := bufio.NewReader(f)
-	var line string
-	for lnum := 0; lnum < n; lnum++ {
-		line, err = bf.ReadString('\n')
-		if err == io.EOF {
-			switch lnum {
-			case 0:
-				return "", errors.New("no lines in file")
-			case 1:
-				return "", errors.New("only 1 line")
-			default:
-				return "", fmt.Errorf("only %d lines", lnum)
-			}
-		}
-		if err != nil {
-			return "", err
-		}
-	}
-	if line == "" {
-		return "", fmt.Errorf("line %d empty", n)
+// sourceLine returns a line of the file being inspected, read once with the file
+func (es *extractStrings) sourceLine(n int) string {
+	if n < 1 || n > len(es.sourceLines) {
+		return ""
 	}
-	return line, nil
+
+	return es.sourceLines[n-1]
 }
 
+// excludeImports excludes the import paths of the file from its strings
 func (es *extractStrings) excludeImports(astFile *ast.File) {
+	es.importedStrings = make(map[string]bool)
 	for i := range astFile.Imports {
 		importString, _ := strconv.Unquote(astFile.Imports[i].Path.Value)
-		es.FilteredStrings[importString] = importString
+		es.importedStrings[importString] = true
 	}
-
 }
 
 func (es *extractStrings) filter(aString string) bool {
@@ -630,6 +849,10 @@ func (es *extractStrings) filter(aString string) bool {
 		return true
 	}
 
+	if es.importedStrings[aString] {
+		return true
+	}
+
 	for _, compiledRegexp := range es.FilteredRegexps {
 		if compiledRegexp.MatchString(aString) {
 			return true
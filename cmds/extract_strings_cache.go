@@ -0,0 +1,156 @@
+package cmds
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/EverlongProject/i18n4go/common"
+)
+
+// cacheConfig is the configuration of the extraction, the cached strings are
+// invalid when it changes
+type cacheConfig struct {
+	ExcludedStrings     []string
+	ExcludedRegexps     []string
+	ExcludedLines       []string
+	ExcludedFileRegexps string
+	EnforcedFuncs       []string
+	SubstringRegexps    []string
+	TFuncs              []string
+
+	OutputDir    string
+	MatchImport  bool
+	MatchPackage bool
+	Po           bool
+	Meta         bool
+}
+
+// loadCache loads the extraction cache of --cache-dir and the files changed
+// since the --since git ref
+func (es *extractStrings) loadCache() error {
+	if es.options.SinceFlag != "" {
+		dirName := es.options.DirnameFlag
+		if es.options.FilenameFlag != "" {
+			dirName = filepath.Dir(es.options.FilenameFlag)
+		}
+
+		changedFiles, err := common.GitChangedFiles(dirName, es.options.SinceFlag)
+		if err != nil {
+			return err
+		}
+		es.changedFiles = changedFiles
+		es.Printf("i18n4go: %d files changed since %s\n", len(changedFiles), es.options.SinceFlag)
+	}
+
+	if es.options.CacheDirFlag == "" || es.options.DryRunFlag {
+		return nil
+	}
+
+	configHash, err := common.HashConfig(es.cacheConfig())
+	if err != nil {
+		return err
+	}
+
+	es.cache, err = common.LoadCache(es.options.CacheDirFlag, configHash)
+	if err != nil {
+		return err
+	}
+	es.Printf("i18n4go: loaded %d files from the cache dir: %s\n", len(es.cache.Entries), es.options.CacheDirFlag)
+
+	return nil
+}
+
+// saveCache removes the outputs of the deleted files and saves the cache
+func (es *extractStrings) saveCache() error {
+	if es.cache == nil {
+		return nil
+	}
+
+	es.removeOutputs(es.cache.Prune())
+
+	return es.cache.Save()
+}
+
+func (es *extractStrings) cacheConfig() cacheConfig {
+	config := cacheConfig{
+		ExcludedLines: es.FilteredLines,
+		EnforcedFuncs: es.EnforcedFuncs,
+		TFuncs:        es.options.TFuncs(),
+
+		OutputDir:    es.options.OutputDirFlag,
+		MatchImport:  es.options.OutputMatchImportFlag,
+		MatchPackage: es.options.OutputMatchPackageFlag,
+		Po:           es.options.PoFlag,
+		Meta:         es.options.MetaFlag,
+	}
+
+	for excludedString := range es.FilteredStrings {
+		config.ExcludedStrings = append(config.ExcludedStrings, excludedString)
+	}
+	sort.Strings(config.ExcludedStrings)
+
+	for _, compiledRegexp := range es.FilteredRegexps {
+		config.ExcludedRegexps = append(config.ExcludedRegexps, compiledRegexp.String())
+	}
+	if es.FilteredFileRegexps != nil {
+		config.ExcludedFileRegexps = es.FilteredFileRegexps.String()
+	}
+	for _, compiledRegexp := range es.SubstringRegexps {
+		config.SubstringRegexps = append(config.SubstringRegexps, compiledRegexp.String())
+	}
+
+	return config
+}
+
+// cacheExtractedStrings caches the strings extracted from a file and removes
+// its previous outputs that were not written again
+func (es *extractStrings) cacheExtractedStrings(absFilePath, hash string, outputs []string) error {
+	if es.cache == nil {
+		return nil
+	}
+
+	// the outputs are removed later, maybe from another working directory
+	for i := range outputs {
+		if absOutput, err := filepath.Abs(outputs[i]); err == nil {
+			outputs[i] = absOutput
+		}
+	}
+
+	stringInfos := common.SortedStringInfos(es.ExtractedStrings)
+	es.removeOutputs(es.cache.Put(absFilePath, common.CacheEntry{Hash: hash, Strings: stringInfos, Outputs: outputs}))
+
+	return nil
+}
+
+func (es *extractStrings) removeOutputs(outputs []string) {
+	for _, output := range outputs {
+		es.Println("i18n4go: removing stale output file:", output)
+		if err := os.Remove(output); err != nil && !os.IsNotExist(err) {
+			es.Println(err)
+		}
+	}
+}
+
+// changedFilter returns the filter of the files of a dir changed since the
+// --since git ref, or nil to parse all of them
+func (es *extractStrings) changedFilter(dirName string) func(fs.FileInfo) bool {
+	if es.changedFiles == nil {
+		return nil
+	}
+
+	return func(fileInfo fs.FileInfo) bool {
+		return es.changedFiles[common.RealPath(filepath.Join(dirName, fileInfo.Name()))]
+	}
+}
+
+func outputsExist(outputs []string) bool {
+	for _, output := range outputs {
+		if _, err := os.Stat(output); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,122 @@
+package cmds
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go/ast"
+	"go/parser"
+	"go/token"
+
+	"github.com/EverlongProject/i18n4go/common"
+)
+
+// extractTemplateStrings extracts the texts and the {{T "..."}} strings of a
+// text/template or html/template file
+func (es *extractStrings) extractTemplateStrings(absFilePath string, content []byte) error {
+	position := token.Position{Filename: absFilePath, Offset: 0, Line: 1, Column: 1}
+	return es.processTemplate(string(content), position, true)
+}
+
+// processTemplateLit extracts the strings of a template literal of a Go file,
+// the strings of a raw string literal are located exactly while those of an
+// interpreted string literal are at the position of the literal
+func (es *extractStrings) processTemplateLit(basicLit *ast.BasicLit, fset *token.FileSet) {
+	if es.directives.IsIgnored(basicLit.Pos()) {
+		return
+	}
+
+	text, err := strconv.Unquote(basicLit.Value)
+	if err != nil {
+		return
+	}
+
+	position := fset.Position(basicLit.Pos())
+	raw := strings.HasPrefix(basicLit.Value, "`")
+	if raw {
+		position.Offset++
+		position.Column++
+	}
+
+	if err := es.processTemplate(text, position, raw); err != nil {
+		es.Println("i18n4go: WARNING could not parse the template at", position.String(), err)
+	}
+}
+
+func (es *extractStrings) processTemplate(text string, position token.Position, exact bool) error {
+	templateStrings, err := common.FindTemplateStrings(text, es.options.TFuncs())
+	if err != nil {
+		return err
+	}
+
+	for _, templateString := range templateStrings {
+		if !templateString.Translated && es.filter(templateString.Value) {
+			continue
+		}
+
+		stringInfo := common.StringInfo{Value: templateString.Value,
+			Filename: position.Filename,
+			Offset:   position.Offset,
+			Line:     position.Line,
+			Column:   position.Column,
+		}
+		if exact {
+			stringInfo.Offset += templateString.Offset
+			stringInfo.Line, stringInfo.Column = common.TemplatePosition(text, templateString.Offset, position.Line, position.Column)
+		}
+
+		es.addStringInfo(stringInfo)
+	}
+
+	return nil
+}
+
+// templateFilenames returns the sorted template files of a dir to extract
+func (es *extractStrings) templateFilenames(dirName string) []string {
+	var fileNames []string
+	dirEntries, _ := os.ReadDir(dirName)
+	for _, dirEntry := range dirEntries {
+		fileName := filepath.Join(dirName, dirEntry.Name())
+		if dirEntry.IsDir() || !common.IsTemplateFile(fileName) {
+			continue
+		}
+
+		if es.IgnoreRegexp != nil && es.IgnoreRegexp.MatchString(fileName) {
+			es.Println("Using ignore-regexp:", es.options.IgnoreRegexpFlag)
+			continue
+		}
+
+		if es.FilteredFileRegexps != nil && es.FilteredFileRegexps.MatchString(fileName) {
+			continue
+		}
+
+		if es.changedFiles != nil && !es.changedFiles[common.RealPath(fileName)] {
+			continue
+		}
+
+		fileNames = append(fileNames, fileName)
+	}
+
+	return fileNames
+}
+
+// templatePackageName returns the package of the Go files next to a
+// template, or the name of its dir when there are none
+func templatePackageName(filename string) string {
+	dirName := filepath.Dir(filename)
+	goFilenames, _ := filepath.Glob(filepath.Join(dirName, "*.go"))
+	for _, goFilename := range goFilenames {
+		if strings.HasSuffix(goFilename, "_test.go") {
+			continue
+		}
+
+		astFile, err := parser.ParseFile(token.NewFileSet(), goFilename, nil, parser.PackageClauseOnly)
+		if err == nil {
+			return astFile.Name.Name
+		}
+	}
+
+	return filepath.Base(dirName)
+}
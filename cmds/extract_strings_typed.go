@@ -0,0 +1,286 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/EverlongProject/i18n4go/common"
+)
+
+const TYPED_PACKAGES_LOAD_MODE = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo
+
+// InspectTypedPackages loads the packages for -f or -d with full type
+// information and only extracts the string literals and string constants
+// that are passed as string parameters to one of the -sink-funcs functions
+func (es *extractStrings) InspectTypedPackages() error {
+	dirName, pattern := es.options.DirnameFlag, "."
+	if es.options.FilenameFlag != "" {
+		dirName = filepath.Dir(es.options.FilenameFlag)
+	} else if es.options.RecurseFlag {
+		pattern = "./..."
+	}
+
+	es.Printf("i18n4go: loading packages %s in dir %s with sink funcs: %s\n", pattern, dirName, es.options.SinkFuncsFlag)
+
+	fileStrings, err := es.extractTypedStrings(dirName, pattern)
+	if err != nil {
+		es.Println(err)
+		return err
+	}
+
+	fileNames := make([]string, 0, len(fileStrings))
+	for fileName := range fileStrings {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	for _, absFilePath := range fileNames {
+		if es.options.FilenameFlag != "" && !sameFile(absFilePath, es.options.FilenameFlag) {
+			continue
+		}
+
+		fileName := absFilePath
+		if wd, err := os.Getwd(); err == nil {
+			if relFilePath, err := filepath.Rel(wd, absFilePath); err == nil {
+				fileName = relFilePath
+			}
+		}
+
+		es.setFilename(fileName)
+		es.setI18nFilename(fileName)
+		es.setPoFilename(fileName)
+
+		es.ExtractedStrings = fileStrings[absFilePath]
+		es.TotalStrings += len(es.ExtractedStrings)
+		es.TotalFiles += 1
+
+		es.Printf("Extracted %d strings from file: %s\n", len(es.ExtractedStrings), absFilePath)
+
+		_, err = es.saveExtractedFiles(absFilePath)
+		if err != nil {
+			es.Println(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extractTypedStrings returns the extracted strings keyed by the absolute
+// path of the file where the literal is declared, which for named constants
+// is not necessarily the file where the sink function is called
+func (es *extractStrings) extractTypedStrings(dirName, pattern string) (map[string]map[string]common.StringInfo, error) {
+	config := &packages.Config{
+		Mode: TYPED_PACKAGES_LOAD_MODE,
+		Dir:  dirName,
+	}
+
+	pkgs, err := packages.Load(config, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("i18n4go: errors loading packages in dir: %s", dirName)
+	}
+
+	sinkFuncs := common.NewSinkFuncs(common.ParseStringList(es.options.SinkFuncsFlag, ","))
+	fileStrings := make(map[string]map[string]common.StringInfo)
+	fileComments := make(map[*ast.File]*typedFileComments)
+
+	for _, pkg := range pkgs {
+		for _, astFile := range pkg.Syntax {
+			fileName := pkg.Fset.Position(astFile.Pos()).Filename
+			if es.IgnoreRegexp != nil && es.IgnoreRegexp.MatchString(filepath.Base(fileName)) {
+				continue
+			}
+			if es.FilteredFileRegexps != nil && es.FilteredFileRegexps.MatchString(fileName) {
+				continue
+			}
+			if commentsOf(pkg, astFile.Pos(), fileComments).directives.IsFileIgnored() {
+				continue
+			}
+
+			ast.Inspect(astFile, func(n ast.Node) bool {
+				callExpr, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+
+				fn, _ := typeutil.Callee(pkg.TypesInfo, callExpr).(*types.Func)
+				if fn == nil || !sinkFuncs.Matches(fn, callExpr) {
+					return true
+				}
+
+				for _, index := range common.StringParamIndexes(fn.Type().(*types.Signature), callExpr) {
+					es.processTypedArg(pkg, callExpr.Args[index], fileStrings, fileComments)
+				}
+
+				return true
+			})
+		}
+	}
+
+	return fileStrings, nil
+}
+
+func (es *extractStrings) processTypedArg(pkg *packages.Package, arg ast.Expr, fileStrings map[string]map[string]common.StringInfo, fileComments map[*ast.File]*typedFileComments) {
+	if commentsOf(pkg, arg.Pos(), fileComments).directives.IsIgnored(arg.Pos()) {
+		return
+	}
+
+	typeAndValue, ok := pkg.TypesInfo.Types[arg]
+	if !ok || typeAndValue.Value == nil || typeAndValue.Value.Kind() != constant.String {
+		return
+	}
+
+	s := constant.StringVal(typeAndValue.Value)
+	if len(s) == 0 || s == "\t" || s == "\n" || s == " " || es.filter(s) {
+		return
+	}
+
+	// only the literals are strings of the code, not the values folded from
+	// expressions, e.g., greeting + " world"
+	var basicLit *ast.BasicLit
+	switch x := ast.Unparen(arg).(type) {
+	case *ast.BasicLit:
+		basicLit = x
+	case *ast.Ident, *ast.SelectorExpr:
+		if obj, ok := pkg.TypesInfo.Uses[identOf(x)].(*types.Const); ok {
+			// use the literal of the named constant rather than its use
+			pkg, basicLit = constValueLit(pkg, obj)
+		}
+	}
+	if basicLit == nil {
+		position := pkg.Fset.Position(arg.Pos())
+		es.Printf("i18n4go: skipping the string %q of an expression that is not a literal at %s:%d:%d\n", s, position.Filename, position.Line, position.Column)
+		return
+	}
+	pos := basicLit.Pos()
+
+	comments := commentsOf(pkg, pos, fileComments)
+	if comments.directives.IsIgnored(pos) {
+		return
+	}
+
+	position := pkg.Fset.Position(pos)
+	if fileStrings[position.Filename] == nil {
+		fileStrings[position.Filename] = make(map[string]common.StringInfo)
+	}
+
+	stringInfo := common.StringInfo{Value: s,
+		Filename: position.Filename,
+		Offset:   position.Offset,
+		Line:     position.Line,
+		Column:   position.Column,
+	}
+	if comments.annotations != nil {
+		stringInfo.Comments, stringInfo.Context = comments.annotations.Find(pos)
+	}
+
+	if existing, ok := fileStrings[position.Filename][stringInfo.Key()]; ok {
+		stringInfo.Comments = common.MergeNotes(existing.Comments, stringInfo.Comments)
+	}
+	fileStrings[position.Filename][stringInfo.Key()] = stringInfo
+}
+
+// typedFileComments are the annotations and directives of a file of the
+// loaded packages, found once per file
+type typedFileComments struct {
+	annotations *common.Annotations
+	directives  *common.Directives
+}
+
+// commentsOf returns the comments of the file of the package at pos, or
+// empty comments for a position outside of the package
+func commentsOf(pkg *packages.Package, pos token.Pos, fileComments map[*ast.File]*typedFileComments) *typedFileComments {
+	for _, astFile := range pkg.Syntax {
+		if astFile.Pos() <= pos && pos <= astFile.End() {
+			if fileComments[astFile] == nil {
+				fileComments[astFile] = &typedFileComments{
+					annotations: common.NewAnnotations(pkg.Fset, astFile),
+					directives:  common.NewDirectives(pkg.Fset, astFile),
+				}
+			}
+			return fileComments[astFile]
+		}
+	}
+
+	return &typedFileComments{}
+}
+
+func identOf(expr ast.Expr) *ast.Ident {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return x
+	case *ast.SelectorExpr:
+		return x.Sel
+	case *ast.ParenExpr:
+		return identOf(x.X)
+	}
+
+	return nil
+}
+
+// constValueLit returns the literal value of a constant declared in the
+// package or in one of its imports with its package, or nil when the
+// constant is not declared by a literal, e.g., const hello = greeting + "!"
+func constValueLit(pkg *packages.Package, obj *types.Const) (*packages.Package, *ast.BasicLit) {
+	declPkg := pkg
+	if obj.Pkg() != nil && obj.Pkg() != pkg.Types {
+		declPkg = pkg.Imports[obj.Pkg().Path()]
+		if declPkg == nil {
+			return pkg, nil
+		}
+	}
+
+	for _, astFile := range declPkg.Syntax {
+		if astFile.Pos() > obj.Pos() || obj.Pos() > astFile.End() {
+			continue
+		}
+
+		var basicLit *ast.BasicLit
+		ast.Inspect(astFile, func(n ast.Node) bool {
+			valueSpec, ok := n.(*ast.ValueSpec)
+			if !ok {
+				return basicLit == nil
+			}
+
+			for i, name := range valueSpec.Names {
+				if name.Pos() == obj.Pos() && i < len(valueSpec.Values) {
+					basicLit, _ = ast.Unparen(valueSpec.Values[i]).(*ast.BasicLit)
+				}
+			}
+
+			return false
+		})
+
+		return declPkg, basicLit
+	}
+
+	return declPkg, nil
+}
+
+func sameFile(fileName, otherFileName string) bool {
+	fileInfo, err := os.Stat(fileName)
+	if err != nil {
+		return false
+	}
+
+	otherFileInfo, err := os.Stat(otherFileName)
+	if err != nil {
+		return false
+	}
+
+	return os.SameFile(fileInfo, otherFileInfo)
+}
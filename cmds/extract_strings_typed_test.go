@@ -0,0 +1,83 @@
+package cmds
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/EverlongProject/i18n4go/common"
+)
+
+const typedTestSource = `package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const greeting = "Hello, world"
+
+const farewell = greeting + ", goodbye"
+
+type Message string
+
+type UI struct{}
+
+func (ui *UI) Say(message Message, args ...interface{}) {}
+
+var ui = &UI{}
+
+func main() {
+	counts := map[string]int{"map key": 1}
+	fmt.Printf("Count: %d\n", counts["map key"])
+	fmt.Printf(greeting)
+	fmt.Println("not a string parameter")
+	ui.Say("Said to the user", "not a string parameter either")
+	_ = strconv.Quote("not a sink")
+	_ = errors.New("something " + "failed")
+	fmt.Printf(greeting + " again")
+	fmt.Printf(farewell)
+	fmt.Printf(("Parenthesized"))
+}
+`
+
+func TestExtractTypedStrings(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/typed\n\ngo 1.21\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "main.go"), []byte(typedTestSource), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	es := NewExtractStrings(common.Options{SinkFuncsFlag: "fmt.Printf,errors.New,ui.Say"})
+	fileStrings, err := es.extractTypedStrings(dir, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fileStrings) != 1 {
+		t.Fatalf("got strings for %d files, expected 1", len(fileStrings))
+	}
+
+	var got []string
+	for _, stringInfos := range fileStrings {
+		for value, stringInfo := range stringInfos {
+			got = append(got, value)
+			if value == "Hello, world" && stringInfo.Line != 9 {
+				t.Fatalf("got line %d for constant, expected the line of its declaration", stringInfo.Line)
+			}
+		}
+	}
+	sort.Strings(got)
+
+	exp := []string{"Count: %d\n", "Hello, world", "Parenthesized", "Said to the user"}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got %q, expected %q", got, exp)
+	}
+}
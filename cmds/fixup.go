@@ -20,12 +20,33 @@ import (
 type Fixup struct {
 	options common.Options
 
+	Updates []FixupUpdate
+
 	I18nStringInfos []common.I18nStringInfo
 	English         []common.I18nStringInfo
 	Source          map[string]int
 	Locales         map[string]map[string]string
 }
 
+type FixupReport struct {
+	SourceLocale string        `json:"sourceLocale"`
+	Added        []string      `json:"added"`
+	Updated      []FixupUpdate `json:"updated"`
+	Removed      []string      `json:"removed"`
+}
+
+type FixupUpdate struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason"`
+}
+
+type fixupPair struct {
+	from, to string
+	score    float64
+}
+
 func NewFixup(options common.Options) Fixup {
 	return Fixup{
 		options:         options,
@@ -63,36 +84,37 @@ func (fix *Fixup) Run() error {
 		return err
 	}
 
-	locales := findTranslationFiles(".")
-	englishFiles, ok := locales["en_US"]
+	locales := findTranslationFiles(fix.options.ResourcesDir())
+	sourceLocale := fix.options.SourceLocale()
+	sourceLocaleFiles, ok := locales[sourceLocale]
 	if !ok {
-		fmt.Println("Unable to find english translation files")
-		return errors.New("Unable to find english translation files")
+		fmt.Printf("Unable to find %s translation files\n", sourceLocale)
+		return fmt.Errorf("Unable to find %s translation files", sourceLocale)
 	}
 
-	englishFile := englishFiles[0]
-	if englishFile == "" {
-		fmt.Println("Could not find an i18n file for locale: en_US")
-		return errors.New("Could not find an i18n file for locale: en_US")
+	sourceLocaleFile := sourceLocaleFiles[0]
+	if sourceLocaleFile == "" {
+		fmt.Println("Could not find an i18n file for locale: " + sourceLocale)
+		return errors.New("Could not find an i18n file for locale: " + sourceLocale)
 	}
 
-	englishStringInfos, err := fix.findI18nStrings(englishFile)
+	sourceLocaleStringInfos, err := fix.findI18nStrings(sourceLocaleFile)
 
 	if err != nil {
-		fmt.Println(fmt.Sprintf("Couldn't find the english strings: %s", err.Error()))
+		fmt.Println(fmt.Sprintf("Couldn't find the %s strings: %s", sourceLocale, err.Error()))
 		return err
 	}
 
-	//Check english to all other files before source
+	//Check the source locale to all other files before source
 	for locale, i18nFile := range locales {
-		if locale != "en_US" {
+		if locale != sourceLocale {
 			foreignStringInfos, _ := fix.findI18nStrings(i18nFile[0])
-			foreignAdditionalTranslations := getAdditionalForeignTranslations(englishStringInfos, foreignStringInfos)
+			foreignAdditionalTranslations := getAdditionalForeignTranslations(sourceLocaleStringInfos, foreignStringInfos)
 
-			foreignMissingTranslations := getMissingForeignTranslations(englishStringInfos, foreignStringInfos)
+			foreignMissingTranslations := getMissingForeignTranslations(sourceLocaleStringInfos, foreignStringInfos)
 
 			if len(foreignMissingTranslations) > 0 {
-				addTranslations(foreignStringInfos, i18nFile[0], foreignMissingTranslations)
+				addTranslations(foreignStringInfos, i18nFile[0], sourceLocaleStringInfos, foreignMissingTranslations)
 			}
 
 			if len(foreignAdditionalTranslations) > 0 {
@@ -104,9 +126,57 @@ func (fix *Fixup) Run() error {
 	}
 
 	//rewrite everything now
-	potentialAdditionalTranslations := getAdditionalTranslations(source, englishStringInfos)
-	removedTranslations := getRemovedTranslations(source, englishStringInfos)
+	potentialAdditionalTranslations := getAdditionalTranslations(source, sourceLocaleStringInfos)
+	removedTranslations := getRemovedTranslations(source, sourceLocaleStringInfos)
 
+	var additionalTranslations []string
+	var updatedTranslations map[string]string
+	if fix.options.NonInteractiveFlag {
+		additionalTranslations, updatedTranslations, removedTranslations, err = fix.pairTranslations(potentialAdditionalTranslations, removedTranslations)
+		if err != nil {
+			fmt.Println(fmt.Sprintf("Couldn't pair the new and removed strings: %s", err.Error()))
+			return err
+		}
+	} else {
+		additionalTranslations, updatedTranslations, removedTranslations = fix.promptForUpdates(potentialAdditionalTranslations, removedTranslations)
+	}
+
+	for locale, i18nFiles := range locales {
+		translatedStrings, err := fix.findI18nStrings(i18nFiles[0])
+		if err != nil {
+			fmt.Println(fmt.Sprintf("Couldn't get the strings from %s: %s", locale, err.Error()))
+			return err
+		}
+
+		if len(updatedTranslations) > 0 {
+			updateTranslations(translatedStrings, i18nFiles[0], locale == sourceLocale, updatedTranslations)
+		}
+
+		if len(additionalTranslations) > 0 {
+			addTranslations(translatedStrings, i18nFiles[0], sourceLocaleStringInfos, additionalTranslations)
+		}
+
+		if len(removedTranslations) > 0 {
+			removeTranslations(translatedStrings, i18nFiles[0], removedTranslations)
+		}
+
+		err = writeStringInfoMapToJSON(translatedStrings, i18nFiles[0])
+	}
+
+	if err == nil && fix.options.ReportFilenameFlag != "" {
+		err = fix.writeReport(additionalTranslations, updatedTranslations, removedTranslations)
+	}
+
+	if err == nil {
+		fmt.Printf("OK")
+	}
+
+	return err
+}
+
+// promptForUpdates asks the user for each string added to the code whether it
+// is new or an update of one of the strings removed from the code
+func (fix *Fixup) promptForUpdates(potentialAdditionalTranslations, removedTranslations []string) ([]string, map[string]string, []string) {
 	additionalTranslations := []string{}
 	updatedTranslations := make(map[string]string)
 
@@ -170,33 +240,133 @@ func (fix *Fixup) Run() error {
 		additionalTranslations = potentialAdditionalTranslations
 	}
 
-	for locale, i18nFiles := range locales {
-		translatedStrings, err := fix.findI18nStrings(i18nFiles[0])
+	return additionalTranslations, updatedTranslations, removedTranslations
+}
+
+// pairTranslations decides without user input which of the strings added to
+// the code are updates of strings removed from the code, first using the
+// -rename-file mapping and then pairing the most similar strings whose score
+// is at least -rename-threshold
+func (fix *Fixup) pairTranslations(potentialAdditionalTranslations, removedTranslations []string) ([]string, map[string]string, []string, error) {
+	added := make(map[string]bool)
+	for _, id := range potentialAdditionalTranslations {
+		added[id] = true
+	}
+
+	removed := make(map[string]bool)
+	for _, id := range removedTranslations {
+		removed[id] = true
+	}
+
+	updatedTranslations := make(map[string]string)
+	fix.Updates = []FixupUpdate{}
+
+	if fix.options.RenameFilenameFlag != "" {
+		renames, err := loadRenames(fix.options.RenameFilenameFlag)
 		if err != nil {
-			fmt.Println(fmt.Sprintf("Couldn't get the strings from %s: %s", locale, err.Error()))
-			return err
+			return nil, nil, nil, err
 		}
 
-		if len(updatedTranslations) > 0 {
-			updateTranslations(translatedStrings, i18nFiles[0], locale, updatedTranslations)
+		for _, from := range sortedKeys(renames) {
+			to := renames[from]
+			if !removed[from] || !added[to] {
+				fix.Println("i18n4go: ignoring rename that does not match a removed and a new string:", from, "->", to)
+				continue
+			}
+
+			updatedTranslations[from] = to
+			fix.Updates = append(fix.Updates, FixupUpdate{From: from, To: to, Score: common.Similarity(from, to), Reason: "rename-file"})
+			delete(removed, from)
+			delete(added, to)
 		}
+	}
 
-		if len(additionalTranslations) > 0 {
-			addTranslations(translatedStrings, i18nFiles[0], additionalTranslations)
+	var pairs []fixupPair
+	for from := range removed {
+		for to := range added {
+			score := common.Similarity(from, to)
+			if score >= fix.options.RenameThresholdFlag {
+				pairs = append(pairs, fixupPair{from: from, to: to, score: score})
+			}
 		}
+	}
 
-		if len(removedTranslations) > 0 {
-			removeTranslations(translatedStrings, i18nFiles[0], removedTranslations)
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].score != pairs[j].score {
+			return pairs[i].score > pairs[j].score
+		}
+		if pairs[i].from != pairs[j].from {
+			return pairs[i].from < pairs[j].from
 		}
+		return pairs[i].to < pairs[j].to
+	})
 
-		err = writeStringInfoMapToJSON(translatedStrings, i18nFiles[0])
+	for _, pair := range pairs {
+		if !removed[pair.from] || !added[pair.to] {
+			continue
+		}
+
+		updatedTranslations[pair.from] = pair.to
+		fix.Updates = append(fix.Updates, FixupUpdate{From: pair.from, To: pair.to, Score: pair.score, Reason: "similarity"})
+		delete(removed, pair.from)
+		delete(added, pair.to)
 	}
 
-	if err == nil {
-		fmt.Printf("OK")
+	return sortedKeys(added), updatedTranslations, sortedKeys(removed), nil
+}
+
+func (fix *Fixup) writeReport(additionalTranslations []string, updatedTranslations map[string]string, removedTranslations []string) error {
+	report := FixupReport{
+		SourceLocale: fix.options.SourceLocale(),
+		Added:        append([]string{}, additionalTranslations...),
+		Updated:      fix.Updates,
+		Removed:      append([]string{}, removedTranslations...),
+	}
+	sort.Strings(report.Added)
+	sort.Strings(report.Removed)
+
+	if report.Updated == nil {
+		// updates chosen interactively
+		report.Updated = []FixupUpdate{}
+		for _, from := range sortedKeys(updatedTranslations) {
+			to := updatedTranslations[from]
+			report.Updated = append(report.Updated, FixupUpdate{From: from, To: to, Score: common.Similarity(from, to), Reason: "user"})
+		}
 	}
 
-	return err
+	encodedReport, err := json.MarshalIndent(report, "", "   ")
+	if err != nil {
+		return err
+	}
+
+	fix.Println("i18n4go: writing fixup report to:", fix.options.ReportFilenameFlag)
+	return ioutil.WriteFile(fix.options.ReportFilenameFlag, encodedReport, 0644)
+}
+
+// loadRenames reads a JSON object mapping old string IDs to new string IDs
+func loadRenames(fileName string) (map[string]string, error) {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	renames := make(map[string]string)
+	err = json.Unmarshal(content, &renames)
+	if err != nil {
+		return nil, err
+	}
+
+	return renames, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
 }
 
 func (fix *Fixup) inspectFile(file string) (translatedStrings []string, err error) {
@@ -208,23 +378,13 @@ func (fix *Fixup) inspectFile(file string) (translatedStrings []string, err erro
 	}
 
 	ast.Inspect(astFile, func(n ast.Node) bool {
-		switch x := n.(type) {
-		case *ast.CallExpr:
-			switch x.Fun.(type) {
-			case *ast.Ident:
-				funName := x.Fun.(*ast.Ident).Name
-
-				if funName == "T" || funName == "t" {
-					if stringArg, ok := x.Args[0].(*ast.BasicLit); ok {
-						translatedString, err := strconv.Unquote(stringArg.Value)
-						if err != nil {
-							panic(err.Error())
-						}
-						translatedStrings = append(translatedStrings, translatedString)
-					}
+		if x, ok := n.(*ast.CallExpr); ok && len(x.Args) > 0 && common.IsTCallExpr(x, fix.options.QualifierFlag, fix.options.TFuncs()) {
+			if stringArg, ok := x.Args[0].(*ast.BasicLit); ok {
+				translatedString, err := strconv.Unquote(stringArg.Value)
+				if err != nil {
+					panic(err.Error())
 				}
-			default:
-				//Skip!
+				translatedStrings = append(translatedStrings, translatedString)
 			}
 		}
 		return true
@@ -235,7 +395,7 @@ func (fix *Fixup) inspectFile(file string) (translatedStrings []string, err erro
 
 func (fix *Fixup) findSourceStrings() (sourceStrings map[string]int, err error) {
 	sourceStrings = make(map[string]int)
-	files := getGoFiles(".")
+	files := getGoFiles(fix.options.SourcesDir())
 
 	for _, file := range files {
 		fileStrings, err := fix.inspectFile(file)
@@ -278,7 +438,7 @@ func getAdditionalTranslations(sourceTranslations map[string]int, englishTransla
 func getAdditionalForeignTranslations(englishTranslations, foreignTranslations map[string]common.I18nStringInfo) []string {
 	additionalForeignTranslations := []string{}
 	for key, _ := range foreignTranslations {
-		if (englishTranslations[key] == common.I18nStringInfo{}) {
+		if englishTranslations[key].IsEmpty() {
 			additionalForeignTranslations = append(additionalForeignTranslations, key)
 		}
 	}
@@ -300,7 +460,7 @@ func getRemovedTranslations(sourceTranslations map[string]int, englishTranslatio
 func getMissingForeignTranslations(englishTranslations, foreignTranslations map[string]common.I18nStringInfo) []string {
 	missingForeignTranslations := []string{}
 	for key, _ := range englishTranslations {
-		if (foreignTranslations[key] == common.I18nStringInfo{}) {
+		if foreignTranslations[key].IsEmpty() {
 			missingForeignTranslations = append(missingForeignTranslations, key)
 		}
 	}
@@ -324,11 +484,16 @@ func writeStringInfoMapToJSON(localeMap map[string]common.I18nStringInfo, locale
 	return nil
 }
 
-func addTranslations(localeMap map[string]common.I18nStringInfo, localeFile string, addTranslations []string) {
+func addTranslations(localeMap map[string]common.I18nStringInfo, localeFile string, sourceStringInfos map[string]common.I18nStringInfo, addTranslations []string) {
 	fmt.Printf("Adding these strings to the %s translation file:\n", localeFile)
 
 	for _, id := range addTranslations {
-		localeMap[id] = common.I18nStringInfo{ID: id, Translation: id}
+		if sourceInfo, ok := sourceStringInfos[id]; ok && sourceInfo.IsPlural() {
+			// the plural forms of the source locale are the untranslated ones
+			localeMap[id] = common.NewPluralI18nStringInfo(id, copyPlurals(sourceInfo.Plurals), false)
+		} else {
+			localeMap[id] = common.I18nStringInfo{ID: id, Translation: id}
+		}
 		fmt.Println("\t", id)
 	}
 }
@@ -345,21 +510,41 @@ func removeTranslations(localeMap map[string]common.I18nStringInfo, localeFile s
 	return err
 }
 
-func updateTranslations(localMap map[string]common.I18nStringInfo, localeFile string, locale string, updTranslations map[string]string) {
+func updateTranslations(localMap map[string]common.I18nStringInfo, localeFile string, isSourceLocale bool, updTranslations map[string]string) {
 	fmt.Printf("Updating the following strings from the %s translation file:\n", localeFile)
 
 	for key, value := range updTranslations {
 		fmt.Println("\t", key)
 
-		if locale == "en_US" {
-			localMap[value] = common.I18nStringInfo{ID: value, Translation: value}
-		} else {
-			localMap[value] = common.I18nStringInfo{ID: value, Translation: localMap[key].Translation, Modified: true}
+		info := localMap[key]
+		info.ID = value
+		info.Modified = !isSourceLocale
+		if info.IsPlural() {
+			info.Plurals = copyPlurals(info.Plurals)
 		}
+
+		if isSourceLocale {
+			// the id of a plural string is its "other" form
+			if info.IsPlural() {
+				info.Plurals["other"] = value
+			}
+			info.Translation = value
+		}
+
+		localMap[value] = info
 		delete(localMap, key)
 	}
 }
 
+func copyPlurals(plurals map[string]string) map[string]string {
+	copiedPlurals := make(map[string]string, len(plurals))
+	for category, translation := range plurals {
+		copiedPlurals[category] = translation
+	}
+
+	return copiedPlurals
+}
+
 func removeFromSlice(slice []string, index int) []string {
 	return append(slice[:index], slice[index+1:]...)
 }
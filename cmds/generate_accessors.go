@@ -0,0 +1,161 @@
+package cmds
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/EverlongProject/i18n4go/common"
+)
+
+type generateAccessors struct {
+	options common.Options
+
+	Filename      string
+	OutputDirname string
+}
+
+func NewGenerateAccessors(options common.Options) generateAccessors {
+	return generateAccessors{options: options,
+		Filename:      options.FilenameFlag,
+		OutputDirname: options.OutputDirFlag,
+	}
+}
+
+func (ga *generateAccessors) Options() common.Options {
+	return ga.options
+}
+
+func (ga *generateAccessors) Println(a ...interface{}) (int, error) {
+	if ga.options.VerboseFlag {
+		return fmt.Println(a...)
+	}
+
+	return 0, nil
+}
+
+func (ga *generateAccessors) Printf(msg string, a ...interface{}) (int, error) {
+	if ga.options.VerboseFlag {
+		return fmt.Printf(msg, a...)
+	}
+
+	return 0, nil
+}
+
+func (ga *generateAccessors) Run() error {
+	i18nStringInfos, err := common.LoadI18nStringInfos(ga.Filename)
+	if err != nil {
+		ga.Println(err)
+		return fmt.Errorf("i18n4go: could not load i18n strings from file: %s", ga.Filename)
+	}
+
+	packageName, err := ga.determinePackageName(ga.OutputDirname)
+	if err != nil {
+		ga.Println(err)
+		return fmt.Errorf("i18n4go: could not determine the package name of: %s", ga.OutputDirname)
+	}
+
+	content, err := ga.generate(packageName, i18nStringInfos)
+	if err != nil {
+		return err
+	}
+
+	accessorsFilename := filepath.Join(ga.OutputDirname, common.ACCESSORS_FILENAME)
+	ga.Printf("i18n4go: generating %d accessors of package %s to file: %s\n", len(i18nStringInfos), packageName, accessorsFilename)
+	if ga.options.DryRunFlag {
+		return nil
+	}
+
+	err = common.CreateOutputDirsIfNeeded(ga.OutputDirname)
+	if err != nil {
+		ga.Println(err)
+		return fmt.Errorf("i18n4go: could not create output directory: %s", ga.OutputDirname)
+	}
+
+	return os.WriteFile(accessorsFilename, content, 0666)
+}
+
+// determinePackageName returns the package of the Go files in the output
+// directory, e.g., the package of its i18n_init.go, else the directory name
+func (ga *generateAccessors) determinePackageName(dirName string) (string, error) {
+	fileNames, err := filepath.Glob(filepath.Join(dirName, "*.go"))
+	if err != nil {
+		return "", err
+	}
+
+	for _, fileName := range fileNames {
+		if strings.HasSuffix(fileName, "_test.go") || filepath.Base(fileName) == common.ACCESSORS_FILENAME {
+			continue
+		}
+
+		astFile, err := parser.ParseFile(token.NewFileSet(), fileName, nil, parser.PackageClauseOnly)
+		if err != nil {
+			return "", err
+		}
+		return astFile.Name.Name, nil
+	}
+
+	absDirName, err := filepath.Abs(dirName)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Replace(filepath.Base(absDirName), "-", "_", -1), nil
+}
+
+// generate returns the gofmt'ed source of the accessors, in the order of the
+// strings in the source translation file
+func (ga *generateAccessors) generate(packageName string, i18nStringInfos []common.I18nStringInfo) ([]byte, error) {
+	accessors, err := common.NewAccessors(i18nStringInfos)
+	if err != nil {
+		ga.Println(err)
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "// Code generated by i18n4go -c generate-accessors from %s; DO NOT EDIT.\n\n", filepath.Base(ga.Filename))
+	fmt.Fprintf(&buffer, "package %s\n", packageName)
+
+	generated := make(map[string]bool)
+	for _, i18nStringInfo := range i18nStringInfos {
+		if generated[i18nStringInfo.ID] {
+			continue
+		}
+		generated[i18nStringInfo.ID] = true
+
+		accessor := accessors[i18nStringInfo.ID]
+		ga.Println("i18n4go: generating accessor:", accessor.Name)
+
+		var params, data []string
+		args := []string{strconv.Quote(accessor.ID)}
+		if accessor.Plural {
+			params = append(params, "count int")
+			args = append(args, "count")
+		}
+		for i, param := range accessor.Params {
+			params = append(params, param+" string")
+			data = append(data, strconv.Quote(accessor.Args[i])+": "+param)
+		}
+		if len(data) > 0 {
+			args = append(args, "map[string]interface{}{"+strings.Join(data, ", ")+"}")
+		}
+
+		fmt.Fprintf(&buffer, "\n// %s translates %s\n", accessor.Name, strconv.Quote(accessor.ID))
+		fmt.Fprintf(&buffer, "func %s(%s) string {\n", accessor.Name, strings.Join(params, ", "))
+		fmt.Fprintf(&buffer, "\treturn T(%s)\n}\n", strings.Join(args, ", "))
+	}
+
+	content, err := format.Source(buffer.Bytes())
+	if err != nil {
+		ga.Println(err)
+		return nil, fmt.Errorf("i18n4go: could not generate the accessors of: %s", ga.Filename)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,183 @@
+package cmds
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/EverlongProject/i18n4go/common"
+)
+
+type importPo struct {
+	options common.Options
+
+	Filename      string
+	Dirname       string
+	OutputDirname string
+	Recurse       bool
+}
+
+func NewImportPo(options common.Options) importPo {
+	return importPo{options: options,
+		Filename:      options.FilenameFlag,
+		Dirname:       options.DirnameFlag,
+		OutputDirname: options.OutputDirFlag,
+		Recurse:       options.RecurseFlag,
+	}
+}
+
+func (ip *importPo) Options() common.Options {
+	return ip.options
+}
+
+func (ip *importPo) Println(a ...interface{}) (int, error) {
+	if ip.options.VerboseFlag {
+		return fmt.Println(a...)
+	}
+
+	return 0, nil
+}
+
+func (ip *importPo) Printf(msg string, a ...interface{}) (int, error) {
+	if ip.options.VerboseFlag {
+		return fmt.Printf(msg, a...)
+	}
+
+	return 0, nil
+}
+
+func (ip *importPo) Run() error {
+	if ip.Filename != "" {
+		_, err := ip.importPoFile(ip.Filename)
+		return err
+	}
+
+	return filepath.WalkDir(ip.Dirname, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != ip.Dirname && !ip.Recurse {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if filepath.Ext(path) == ".po" {
+			_, err = ip.importPoFile(path)
+		}
+		return err
+	})
+}
+
+// importPoFile converts a translated PO file, e.g., fr_FR.all.po, into the
+// fr_FR.all.json translation file, fuzzy or untranslated messages are marked
+// as modified and plural messages use the CLDR categories of the language
+func (ip *importPo) importPoFile(poFilename string) (string, error) {
+	ip.Println("i18n4go: importing PO file:", poFilename)
+
+	po, err := common.ReadPoFile(poFilename)
+	if err != nil {
+		ip.Println(err)
+		return "", err
+	}
+
+	baseName := strings.TrimSuffix(filepath.Base(poFilename), ".po")
+	if !strings.HasSuffix(baseName, ".all") {
+		baseName += ".all"
+	}
+
+	language := po.Language()
+	if language == "" {
+		language = strings.TrimSuffix(baseName, ".all")
+		po.SetHeader("Language", language)
+	}
+
+	i18nStringInfos := []common.I18nStringInfo{}
+	ids := make(map[string]string)
+	for _, entry := range po.Entries {
+		if context, ok := ids[entry.ID]; ok {
+			fmt.Printf("i18n4go: WARNING skipping string %q with context %q, already imported with context %q\n", entry.ID, entry.Context, context)
+			continue
+		}
+		ids[entry.ID] = entry.Context
+
+		i18nStringInfos = append(i18nStringInfos, ip.i18nStringInfo(po, entry))
+	}
+
+	sort.Slice(i18nStringInfos, func(i, j int) bool { return i18nStringInfos[i].ID < i18nStringInfos[j].ID })
+
+	outputDirname := ip.OutputDirname
+	if outputDirname == "" {
+		outputDirname = filepath.Dir(poFilename)
+	}
+
+	jsonFilename := filepath.Join(outputDirname, baseName+".json")
+	err = ip.saveI18nStringInfos(i18nStringInfos, outputDirname, jsonFilename)
+	if err != nil {
+		ip.Println(err)
+		return "", err
+	}
+
+	ip.Printf("i18n4go: imported %d strings from %s to %s\n", len(i18nStringInfos), poFilename, jsonFilename)
+
+	return jsonFilename, nil
+}
+
+func (ip *importPo) i18nStringInfo(po *common.PoFile, entry *common.PoEntry) common.I18nStringInfo {
+	modified := entry.HasFlag(common.PO_FLAG_FUZZY)
+
+	if !entry.IsPlural() {
+		translation := entry.Translation
+		if translation == "" {
+			translation, modified = entry.ID, true
+		}
+
+		return common.I18nStringInfo{ID: entry.ID, Translation: translation, Modified: modified}
+	}
+
+	categories := po.PluralCategories()
+	if len(entry.Translations) > len(categories) {
+		fmt.Printf("i18n4go: WARNING string %q has %d plural forms, %s only has %d\n", entry.ID, len(entry.Translations), po.Language(), len(categories))
+	}
+
+	translations := make(map[string]string)
+	for i, category := range categories {
+		translation := ""
+		if i < len(entry.Translations) {
+			translation = entry.Translations[i]
+		}
+
+		if translation == "" {
+			translation, modified = entry.IDPlural, true
+			if category == "one" {
+				translation = entry.ID
+			}
+		}
+		translations[category] = translation
+	}
+
+	// gettext has no form for some CLDR categories, e.g., "other" in Russian,
+	// they take the translation of the last form
+	for _, category := range common.PluralRuleForLanguage(po.Language()).Categories {
+		if _, ok := translations[category]; !ok {
+			translations[category] = translations[categories[len(categories)-1]]
+		}
+	}
+
+	return common.NewPluralI18nStringInfo(entry.ID, translations, modified)
+}
+
+func (ip *importPo) saveI18nStringInfos(i18nStringInfos []common.I18nStringInfo, outputDirname, fileName string) error {
+	if !ip.options.DryRunFlag {
+		err := common.CreateOutputDirsIfNeeded(outputDirname)
+		if err != nil {
+			return err
+		}
+	}
+
+	return common.SaveI18nStringInfos(ip, ip.options, i18nStringInfos, fileName)
+}
@@ -0,0 +1,72 @@
+package cmds
+
+import (
+	"fmt"
+
+	"github.com/EverlongProject/i18n4go/common"
+	"github.com/EverlongProject/i18n4go/tm"
+)
+
+type importTmx struct {
+	options common.Options
+
+	TmFilename  string
+	TmxFilename string
+}
+
+func NewImportTmx(options common.Options) importTmx {
+	return importTmx{options: options,
+		TmFilename:  options.TmFilenameFlag,
+		TmxFilename: options.TmxFilenameFlag,
+	}
+}
+
+func (it *importTmx) Options() common.Options {
+	return it.options
+}
+
+func (it *importTmx) Println(a ...interface{}) (int, error) {
+	if it.options.VerboseFlag {
+		return fmt.Println(a...)
+	}
+
+	return 0, nil
+}
+
+func (it *importTmx) Printf(msg string, a ...interface{}) (int, error) {
+	if it.options.VerboseFlag {
+		return fmt.Printf(msg, a...)
+	}
+
+	return 0, nil
+}
+
+// Run adds the translations of a TMX file, e.g., from a translation vendor,
+// to the translation memory, they replace the ones of the same strings
+func (it *importTmx) Run() error {
+	memory, err := tm.Load(it.TmFilename)
+	if err != nil {
+		it.Println(err)
+		return fmt.Errorf("i18n4go: could not load translation memory: %s", it.TmFilename)
+	}
+
+	entries, err := tm.ReadTMX(it.TmxFilename)
+	if err != nil {
+		it.Println(err)
+		return fmt.Errorf("i18n4go: could not read TMX file: %s", it.TmxFilename)
+	}
+
+	var count int
+	for _, entry := range entries {
+		if memory.Add(entry) {
+			count++
+		}
+	}
+
+	it.Printf("i18n4go: imported %d of the %d translations of %s, the translation memory %s has %d translations\n", count, len(entries), it.TmxFilename, it.TmFilename, memory.Len())
+	if it.options.DryRunFlag {
+		return nil
+	}
+
+	return memory.Save(it.TmFilename)
+}
@@ -0,0 +1,202 @@
+package cmds
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/EverlongProject/i18n4go/common"
+)
+
+type importXliff struct {
+	options common.Options
+
+	Filename       string
+	OutputDirname  string
+	XliffFilenames []string
+}
+
+func NewImportXliff(options common.Options) importXliff {
+	return importXliff{options: options,
+		Filename:       options.FilenameFlag,
+		OutputDirname:  options.OutputDirFlag,
+		XliffFilenames: common.ParseStringList(options.XliffFilesFlag, ","),
+	}
+}
+
+func (ix *importXliff) Options() common.Options {
+	return ix.options
+}
+
+func (ix *importXliff) Println(a ...interface{}) (int, error) {
+	if ix.options.VerboseFlag {
+		return fmt.Println(a...)
+	}
+
+	return 0, nil
+}
+
+func (ix *importXliff) Printf(msg string, a ...interface{}) (int, error) {
+	if ix.options.VerboseFlag {
+		return fmt.Printf(msg, a...)
+	}
+
+	return 0, nil
+}
+
+func (ix *importXliff) Run() error {
+	sourceI18nStringInfos, err := common.LoadI18nStringInfos(ix.Filename)
+	if err != nil {
+		ix.Println(err)
+		return fmt.Errorf("i18n4go: could not load i18n strings from file: %s", ix.Filename)
+	}
+
+	for _, xliffFilename := range ix.XliffFilenames {
+		err = ix.importXliffFile(sourceI18nStringInfos, xliffFilename)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importXliffFile converts an XLIFF file, e.g., fr.all.xlf, into the
+// fr.all.json translation file once all its units are valid
+func (ix *importXliff) importXliffFile(sourceI18nStringInfos []common.I18nStringInfo, xliffFilename string) error {
+	ix.Println("i18n4go: importing XLIFF file:", xliffFilename)
+
+	xliff, err := common.ReadXliffFile(xliffFilename)
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+
+	sourceIDs := make(map[string]bool)
+	for _, sourceI18nStringInfo := range sourceI18nStringInfos {
+		sourceIDs[sourceI18nStringInfo.ID] = true
+	}
+
+	units := make(map[string]common.XliffUnit)
+	var invalidUnits []string
+	for _, unit := range xliff.Units {
+		if problem := ix.validateUnit(sourceIDs, unit); problem != "" {
+			invalidUnits = append(invalidUnits, fmt.Sprintf("%q %s", unit.ID, problem))
+			continue
+		}
+		units[unit.ID] = unit
+	}
+
+	if len(invalidUnits) > 0 {
+		for _, invalidUnit := range invalidUnits {
+			fmt.Println("i18n4go: ERROR invalid XLIFF unit:", invalidUnit)
+		}
+		return fmt.Errorf("i18n4go: %s has %d invalid units", xliffFilename, len(invalidUnits))
+	}
+
+	outputDirname := ix.OutputDirname
+	if outputDirname == "" {
+		outputDirname = filepath.Dir(xliffFilename)
+	}
+
+	if !ix.options.DryRunFlag {
+		err = common.CreateOutputDirsIfNeeded(outputDirname)
+		if err != nil {
+			ix.Println(err)
+			return err
+		}
+	}
+
+	baseName := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(xliffFilename), ".xlf"), ".xliff")
+	jsonFilename := filepath.Join(outputDirname, baseName+".json")
+
+	pluralI18nStringInfos := ix.loadPluralI18nStringInfos(jsonFilename)
+	var i18nStringInfos []common.I18nStringInfo
+	for _, sourceI18nStringInfo := range sourceI18nStringInfos {
+		if sourceI18nStringInfo.IsPlural() {
+			// XLIFF units are not plural, keep the plural translations already imported
+			pluralI18nStringInfo, ok := pluralI18nStringInfos[sourceI18nStringInfo.ID]
+			if !ok {
+				pluralI18nStringInfo = sourceI18nStringInfo
+				pluralI18nStringInfo.Modified = true
+			}
+			i18nStringInfos = append(i18nStringInfos, pluralI18nStringInfo)
+			continue
+		}
+
+		unit, ok := units[sourceI18nStringInfo.ID]
+		if !ok {
+			fmt.Printf("i18n4go: WARNING string %q is not in %s, it is not imported\n", sourceI18nStringInfo.ID, xliffFilename)
+			continue
+		}
+
+		translation := unit.Target
+		if !unit.HasTarget {
+			translation = sourceI18nStringInfo.Translation
+		}
+		i18nStringInfos = append(i18nStringInfos, common.I18nStringInfo{ID: unit.ID, Translation: translation, Modified: unit.Modified()})
+	}
+
+	ix.Printf("i18n4go: imported %d strings from %s to %s\n", len(i18nStringInfos), xliffFilename, jsonFilename)
+
+	return common.SaveI18nStringInfos(ix, ix.Options(), i18nStringInfos, jsonFilename)
+}
+
+// loadPluralI18nStringInfos returns the plural translations of an existing
+// translation file, if any
+func (ix *importXliff) loadPluralI18nStringInfos(jsonFilename string) map[string]common.I18nStringInfo {
+	pluralI18nStringInfos := make(map[string]common.I18nStringInfo)
+	if _, err := os.Stat(jsonFilename); err != nil {
+		return pluralI18nStringInfos
+	}
+
+	i18nStringInfos, err := common.LoadI18nStringInfos(jsonFilename)
+	if err != nil {
+		ix.Println("i18n4go: could not load the plural translations of:", jsonFilename, err)
+		return pluralI18nStringInfos
+	}
+
+	for _, i18nStringInfo := range i18nStringInfos {
+		if i18nStringInfo.IsPlural() {
+			pluralI18nStringInfos[i18nStringInfo.ID] = i18nStringInfo
+		}
+	}
+
+	return pluralI18nStringInfos
+}
+
+// validateUnit returns why a unit cannot be imported, i.e., its ID is not in
+// the source file or its target does not keep the templated placeholders
+func (ix *importXliff) validateUnit(sourceIDs map[string]bool, unit common.XliffUnit) string {
+	if !sourceIDs[unit.ID] {
+		return "is not in the source file: " + ix.Filename
+	}
+
+	if unit.HasTarget {
+		err := samePlaceholders(unit.Source, unit.Target)
+		if err != nil {
+			return err.Error()
+		}
+	}
+
+	return ""
+}
+
+func samePlaceholders(source, target string) error {
+	sourceArgs := common.GetTemplatedStringArgs(source)
+	targetArgs := common.GetTemplatedStringArgs(target)
+	sort.Strings(sourceArgs)
+	sort.Strings(targetArgs)
+
+	if len(sourceArgs) != 0 || len(targetArgs) != 0 {
+		if !reflect.DeepEqual(sourceArgs, targetArgs) {
+			return errors.New("has a target whose placeholders do not match the source: " + target)
+		}
+	}
+
+	return nil
+}
@@ -131,7 +131,7 @@ func getFilesAndDir(dir string) (files []string, dirs []string) {
 func (ms MergeStrings) matchFileToSourceLanguage(files []string, lang string) (list []string) {
 	languageMatcher := "go." + lang + ".json"
 	for _, file := range files {
-		if strings.Contains(file, languageMatcher) {
+		if strings.Contains(file, languageMatcher) || common.IsTemplateFile(strings.TrimSuffix(file, "."+lang+".json")) {
 			list = append(list, file)
 			ms.Println("i18n4go: scanning file: " + file)
 		}
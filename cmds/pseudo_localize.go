@@ -0,0 +1,121 @@
+package cmds
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/EverlongProject/i18n4go/common"
+)
+
+type pseudoLocalize struct {
+	options common.Options
+
+	Filename       string
+	OutputDirname  string
+	SourceLanguage string
+	Languages      []string
+
+	PseudoOptions common.PseudoOptions
+}
+
+func NewPseudoLocalize(options common.Options) pseudoLocalize {
+	languages := common.ParseStringList(options.LanguagesFlag, ",")
+	if len(languages) == 0 {
+		languages = []string{common.PSEUDO_LANGUAGE}
+		if options.PseudoRightToLeftFlag {
+			languages = []string{common.PSEUDO_RTL_LANGUAGE}
+		}
+	}
+
+	return pseudoLocalize{options: options,
+		Filename:       options.FilenameFlag,
+		OutputDirname:  options.OutputDirFlag,
+		SourceLanguage: options.SourceLanguageFlag,
+		Languages:      languages,
+		PseudoOptions: common.PseudoOptions{
+			Expansion:   options.PseudoExpansionFlag,
+			RightToLeft: options.PseudoRightToLeftFlag,
+		},
+	}
+}
+
+func (pl *pseudoLocalize) Options() common.Options {
+	return pl.options
+}
+
+func (pl *pseudoLocalize) Println(a ...interface{}) (int, error) {
+	if pl.options.VerboseFlag {
+		return fmt.Println(a...)
+	}
+
+	return 0, nil
+}
+
+func (pl *pseudoLocalize) Printf(msg string, a ...interface{}) (int, error) {
+	if pl.options.VerboseFlag {
+		return fmt.Printf(msg, a...)
+	}
+
+	return 0, nil
+}
+
+func (pl *pseudoLocalize) Run() error {
+	if pl.PseudoOptions.Expansion < 0 {
+		return fmt.Errorf("i18n4go: the pseudo expansion must be a positive percentage, not: %d", pl.PseudoOptions.Expansion)
+	}
+
+	fileName, filePath, err := common.CheckFile(pl.Filename)
+	if err != nil {
+		return err
+	}
+
+	i18nStringInfos, err := common.LoadI18nStringInfos(pl.Filename)
+	if err != nil {
+		pl.Println(err)
+		return fmt.Errorf("i18n4go: could not load i18n strings from file: %s", pl.Filename)
+	}
+
+	if len(i18nStringInfos) == 0 {
+		return fmt.Errorf("i18n4go: input file: %s is empty", pl.Filename)
+	}
+
+	outputDirname := pl.OutputDirname
+	if outputDirname == "" {
+		outputDirname = filePath
+	}
+
+	if !pl.options.DryRunFlag {
+		err = common.CreateOutputDirsIfNeeded(outputDirname)
+		if err != nil {
+			pl.Println(err)
+			return fmt.Errorf("i18n4go: could not create output directory: %s", outputDirname)
+		}
+	}
+
+	for _, language := range pl.Languages {
+		pseudoOptions := pl.PseudoOptions
+		pseudoOptions.RightToLeft = pseudoOptions.RightToLeft || language == common.PSEUDO_RTL_LANGUAGE
+
+		pseudoI18nStringInfos := make([]common.I18nStringInfo, len(i18nStringInfos))
+		for i, i18nStringInfo := range i18nStringInfos {
+			pseudoI18nStringInfo := i18nStringInfo.MapTranslations(func(translation string) string {
+				return common.PseudoLocalize(translation, pseudoOptions)
+			})
+			pseudoI18nStringInfo.Modified = false
+			pseudoI18nStringInfos[i] = pseudoI18nStringInfo
+		}
+
+		// same naming as create-translations, e.g., en.all.json becomes en_XA.all.json
+		destFilename := filepath.Join(outputDirname, strings.Replace(fileName, pl.SourceLanguage, language, -1))
+		pl.Printf("i18n4go: pseudo-localizing %d strings to: %s\n", len(pseudoI18nStringInfos), destFilename)
+
+		err = common.SaveI18nStringInfos(pl, pl.Options(), pseudoI18nStringInfos, destFilename)
+		if err != nil {
+			pl.Println(err)
+			return fmt.Errorf("i18n4go: could not save pseudo-localized i18n strings to file: %s", destFilename)
+		}
+	}
+
+	return nil
+}
@@ -7,7 +7,6 @@ import (
 	"regexp"
 
 	"go/ast"
-	"go/build"
 	"go/format"
 	"go/parser"
 	"go/token"
@@ -35,6 +34,33 @@ var T goi18n.TranslateFunc
 func init() {
 	T = i18n.Init(__FULL_IMPORT_PATH__, i18n.GetResourcesPath())
 }`
+
+	INIT_EMBED_CODE_SNIPPET = `package __PACKAGE__NAME__
+
+import (
+	"embed"
+	"io/fs"
+
+	goi18n "github.com/EverlongProject/go-i18n/i18n"
+	i18n "github.com/EverlongProject/i18n4go/i18n"
+)
+
+//go:embed __EMBED_DIRNAME__
+var i18nResources embed.FS
+
+var T goi18n.TranslateFunc
+
+func init() {
+	resources, err := fs.Sub(i18nResources, "__EMBED_DIRNAME__")
+	if err != nil {
+		panic(err)
+	}
+
+	T, err = i18n.InitFS(resources, __FULL_IMPORT_PATH__)
+	if err != nil {
+		panic(err)
+	}
+}`
 )
 
 type rewritePackage struct {
@@ -46,6 +72,8 @@ type rewritePackage struct {
 	I18nStringsDirname      string
 	RootPath                string
 	InitCodeSnippetFilename string
+	InitEmbedDirname        string
+	AccessorsFilename       string
 
 	Dirname string
 	Recurse bool
@@ -54,10 +82,14 @@ type rewritePackage struct {
 	UpdatedExtractedStrings map[string]common.I18nStringInfo
 	SaveExtractedStrings    bool
 
+	Accessors map[string]common.Accessor
+
 	TotalStrings int
 	TotalFiles   int
 
 	IgnoreRegexp *regexp.Regexp
+
+	directives *common.Directives
 }
 
 func NewRewritePackage(options common.Options) rewritePackage {
@@ -77,6 +109,8 @@ func NewRewritePackage(options common.Options) rewritePackage {
 		I18nStringsDirname:      options.I18nStringsDirnameFlag,
 		RootPath:                options.RootPathFlag,
 		InitCodeSnippetFilename: options.InitCodeSnippetFilenameFlag,
+		InitEmbedDirname:        options.InitEmbedDirnameFlag,
+		AccessorsFilename:       options.AccessorsFilenameFlag,
 
 		ExtractedStrings:        nil,
 		UpdatedExtractedStrings: nil,
@@ -111,6 +145,19 @@ func (rp *rewritePackage) Printf(msg string, a ...interface{}) (int, error) {
 func (rp *rewritePackage) Run() error {
 	var err error
 
+	if rp.AccessorsFilename != "" {
+		i18nStringInfos, err := common.LoadI18nStringInfos(rp.AccessorsFilename)
+		if err != nil {
+			rp.Println(err)
+			return fmt.Errorf("i18n4go: could not load the accessors strings from file: %s", rp.AccessorsFilename)
+		}
+		rp.Accessors, err = common.NewAccessors(i18nStringInfos)
+		if err != nil {
+			rp.Println(err)
+			return err
+		}
+	}
+
 	if rp.options.FilenameFlag != "" {
 		if err = rp.loadStringsToBeTranslated(rp.I18nStringsFilename); err != nil {
 			return err
@@ -192,13 +239,17 @@ func (rp *rewritePackage) resetProcessing() {
 }
 
 func (rp *rewritePackage) ignoreFile(fileName string) bool {
-	return fileName != "i18n_init.go" &&
+	return fileName != "i18n_init.go" && fileName != common.ACCESSORS_FILENAME &&
 		!strings.HasPrefix(fileName, ".") &&
-		strings.HasSuffix(fileName, ".go") &&
+		(strings.HasSuffix(fileName, ".go") || rp.options.TemplatesFlag && common.IsTemplateFile(fileName)) &&
 		rp.IgnoreRegexp != nil && !rp.IgnoreRegexp.MatchString(fileName)
 }
 
 func (rp *rewritePackage) processFilename(fileName string) error {
+	if common.IsTemplateFile(fileName) {
+		return rp.processTemplateFilename(fileName)
+	}
+
 	rp.TotalFiles += 1
 	rp.Println("i18n4go: rewriting strings for source file:", fileName)
 
@@ -237,10 +288,19 @@ func (rp *rewritePackage) processFilename(fileName string) error {
 		return err
 	}
 
-	err = rp.insertTFuncCall(astFile)
-	if err != nil {
-		rp.Println("i18n4go: error appending T() to AST file:", err.Error())
-		return err
+	rp.directives = common.NewDirectives(fileSet, astFile)
+	if rp.directives.IsFileIgnored() {
+		rp.Println("i18n4go: ignoring strings of file with", common.IGNORE_FILE_DIRECTIVE, "directive:", fileName)
+	} else {
+		err = rp.insertTFuncCall(astFile)
+		if err != nil {
+			rp.Println("i18n4go: error appending T() to AST file:", err.Error())
+			return err
+		}
+	}
+
+	if rp.Accessors != nil {
+		rp.rewriteAccessorCalls(fileSet, astFile)
 	}
 
 	relativeFilePath := rp.relativePathForFile(fileName)
@@ -269,25 +329,11 @@ func (rp *rewritePackage) determineImportPath(filePath string) (string, error) {
 		rp.RootPath = os.Getenv("PWD")
 	}
 	rp.Println("i18n4go: determining import path using root path:", rp.RootPath)
-	pkg, err := build.Default.ImportDir(rp.RootPath, build.ImportMode(1))
+	importPath, err := common.RelativeImportPath(rp.RootPath, dirName)
 	if err != nil {
-		rp.Println("i18n4go: error getting root path import:", err.Error())
+		rp.Println("i18n4go: error getting import path:", err.Error())
 		return "", err
 	}
-	rp.Println("i18n4go: got a root pkg with import path:", pkg.ImportPath)
-
-	otherPkg, err := build.Default.ImportDir(dirName, build.ImportMode(0))
-	if err != nil {
-		rp.Println("i18n4go: error getting root path import:", err.Error())
-		return "", err
-	}
-	rp.Println("i18n4go: got a pkg with import:", otherPkg.ImportPath)
-
-	importPath := otherPkg.ImportPath
-	importPath = strings.Replace(importPath, pkg.ImportPath, "", 1)
-	if strings.HasPrefix(importPath, "/") {
-		importPath = strings.TrimLeft(importPath, "/")
-	}
 	rp.Println("i18n4go: using import path as:", importPath)
 
 	return importPath, nil
@@ -306,6 +352,10 @@ func (rp *rewritePackage) insertTFuncCall(astFile *ast.File) error {
 		ast.Inspect(decl, func(node ast.Node) bool {
 			switch node.(type) {
 			case *ast.CallExpr:
+				if basicLit := common.TemplateParseLit(node.(*ast.CallExpr), astFile); basicLit != nil && rp.options.TemplatesFlag {
+					rp.templateLitTFunc(basicLit)
+					return false
+				}
 				if !rp.callExprTFunc(node.(*ast.CallExpr)) {
 					return false // don't recurse infinitely
 				}
@@ -397,8 +447,7 @@ func (rp *rewritePackage) valueSpecTFunc(valueSpec *ast.ValueSpec) bool {
 }
 
 func (rp *rewritePackage) callExprTFunc(callExpr *ast.CallExpr) bool {
-	callFuncIdent, ok := callExpr.Fun.(*ast.Ident)
-	if ok && (callFuncIdent.Name == "T") { // yeah, not the best
+	if common.IsTCallExpr(callExpr, rp.options.QualifierFlag, rp.options.TFuncs()) {
 		return false
 	}
 
@@ -438,6 +487,11 @@ func (rp *rewritePackage) wrapMultiArgsCallExpr(callExpr *ast.CallExpr) {
 }
 
 func (rp *rewritePackage) wrapCallExprWithInterpolatedT(basicLit *ast.BasicLit, callExpr *ast.CallExpr, argIndex int) {
+	if rp.directives.IsIgnored(basicLit.Pos()) {
+		rp.wrapExprArgs(callExpr.Args)
+		return
+	}
+
 	valueWithoutQuotes, _ := strconv.Unquote(basicLit.Value)
 
 	i18nStringInfo, ok := rp.ExtractedStrings[valueWithoutQuotes]
@@ -484,6 +538,10 @@ func (rp *rewritePackage) wrapExprArgs(exprArgs []ast.Expr) {
 }
 
 func (rp *rewritePackage) wrapBasicLitWithTemplatedT(basicLit *ast.BasicLit, args []ast.Expr, callExpr *ast.CallExpr, argIndex int) ast.Expr {
+	if rp.directives.IsIgnored(basicLit.Pos()) {
+		return callExpr
+	}
+
 	valueWithoutQuotes, _ := strconv.Unquote(basicLit.Value) //basicLit.Value[1 : len(basicLit.Value)-1]
 
 	_, ok := rp.ExtractedStrings[valueWithoutQuotes]
@@ -528,7 +586,7 @@ func (rp *rewritePackage) wrapBasicLitWithTemplatedT(basicLit *ast.BasicLit, arg
 }
 
 func (rp *rewritePackage) wrapBasicLitWithT(basicLit *ast.BasicLit) ast.Expr {
-	if basicLit.Kind != token.STRING {
+	if basicLit.Kind != token.STRING || rp.directives.IsIgnored(basicLit.Pos()) {
 		return basicLit
 	}
 
@@ -539,8 +597,7 @@ func (rp *rewritePackage) wrapBasicLitWithT(basicLit *ast.BasicLit) ast.Expr {
 	}
 
 	rp.TotalStrings++
-	tIdent := &ast.Ident{Name: "T"}
-	return &ast.CallExpr{Fun: tIdent, Args: []ast.Expr{basicLit}}
+	return common.NewTCallExpr(basicLit, "")
 }
 
 func (rp *rewritePackage) addInitFuncToPackage(packageName, outputDir, importPath string) error {
@@ -548,12 +605,17 @@ func (rp *rewritePackage) addInitFuncToPackage(packageName, outputDir, importPat
 
 	common.CreateOutputDirsIfNeeded(outputDir)
 
-	pieces := strings.Split(importPath, "/")
-	for index, str := range pieces {
-		pieces[index] = `"` + str + `"`
+	// the embedded files are read with io/fs whose paths always use slashes
+	joinedImportPath := strconv.Quote(importPath)
+	if rp.InitEmbedDirname == "" {
+		pieces := strings.Split(importPath, "/")
+		for index, str := range pieces {
+			pieces[index] = `"` + str + `"`
+		}
+
+		joinedImportPath = "filepath.Join(" + strings.Join(pieces, ", ") + ")"
 	}
 
-	joinedImportPath := "filepath.Join(" + strings.Join(pieces, ", ") + ")"
 	content := rp.getInitFuncCodeSnippetContent(packageName, joinedImportPath)
 
 	return ioutil.WriteFile(filepath.Join(outputDir, "i18n_init.go"), []byte(content), 0666)
@@ -561,6 +623,10 @@ func (rp *rewritePackage) addInitFuncToPackage(packageName, outputDir, importPat
 
 func (rp *rewritePackage) getInitFuncCodeSnippetContent(packageName, importPath string) string {
 	snippetContent := INIT_CODE_SNIPPET
+	if rp.InitEmbedDirname != "" {
+		snippetContent = INIT_EMBED_CODE_SNIPPET
+	}
+
 	if rp.InitCodeSnippetFilename != "" {
 		bytes, err := ioutil.ReadFile(rp.InitCodeSnippetFilename)
 		if err != nil {
@@ -572,6 +638,7 @@ func (rp *rewritePackage) getInitFuncCodeSnippetContent(packageName, importPath
 
 	content := strings.Replace(snippetContent, "__PACKAGE__NAME__", packageName, -1)
 	content = strings.Replace(content, "__FULL_IMPORT_PATH__", importPath, -1)
+	content = strings.Replace(content, "__EMBED_DIRNAME__", filepath.ToSlash(rp.InitEmbedDirname), -1)
 	return content
 }
 
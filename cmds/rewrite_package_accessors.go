@@ -0,0 +1,145 @@
+package cmds
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"golang.org/x/tools/go/ast/astutil"
+
+	"github.com/EverlongProject/i18n4go/common"
+)
+
+// rewriteAccessorCalls replaces the T() calls of the strings with accessors
+// by calls to their accessor, e.g., T("Deleting app {{.Name}}...",
+// map[string]interface{}{"Name": name}) by MsgDeletingApp(name), the values
+// that may not be strings are converted with fmt.Sprint like the templates do
+func (rp *rewritePackage) rewriteAccessorCalls(fileSet *token.FileSet, astFile *ast.File) {
+	sprint := false
+	ast.Inspect(astFile, func(node ast.Node) bool {
+		callExpr, ok := node.(*ast.CallExpr)
+		if !ok || !common.IsTCallExpr(callExpr, rp.options.QualifierFlag, rp.options.TFuncs()) || len(callExpr.Args) == 0 {
+			return true
+		}
+
+		basicLit, ok := callExpr.Args[0].(*ast.BasicLit)
+		if !ok || basicLit.Kind != token.STRING {
+			return true
+		}
+
+		id, _ := strconv.Unquote(basicLit.Value)
+		accessor, ok := rp.Accessors[id]
+		if !ok {
+			return true
+		}
+
+		args, ok := rp.accessorCallArgs(accessor, callExpr.Args[1:])
+		if !ok {
+			rp.Println("i18n4go: WARNING could not rewrite the T() call with the accessor:", accessor.Name)
+			return true
+		}
+
+		for i, arg := range args {
+			if accessor.Plural && i == 0 {
+				continue
+			}
+			if !rp.isStringExpr(arg) {
+				args[i] = &ast.CallExpr{Fun: &ast.SelectorExpr{X: &ast.Ident{Name: "fmt"}, Sel: &ast.Ident{Name: "Sprint"}}, Args: []ast.Expr{arg}}
+				sprint = true
+			}
+		}
+
+		// the accessors are generated in the package of the T func
+		if selectorExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
+			callExpr.Fun = &ast.SelectorExpr{X: selectorExpr.X, Sel: &ast.Ident{Name: accessor.Name}}
+		} else {
+			callExpr.Fun = &ast.Ident{Name: accessor.Name}
+		}
+		callExpr.Args = args
+		return true
+	})
+
+	if sprint {
+		astutil.AddImport(fileSet, astFile, "fmt")
+	}
+}
+
+// accessorCallArgs returns the accessor arguments of the T() arguments after
+// the ID, ok is false when these do not have a literal value for each arg
+func (rp *rewritePackage) accessorCallArgs(accessor common.Accessor, tArgs []ast.Expr) ([]ast.Expr, bool) {
+	var args []ast.Expr
+	if accessor.Plural {
+		if len(tArgs) == 0 {
+			return nil, false
+		}
+		args, tArgs = append(args, tArgs[0]), tArgs[1:]
+	}
+
+	switch len(tArgs) {
+	case 0:
+		return args, len(accessor.Args) == 0
+	case 1:
+	default:
+		return nil, false
+	}
+
+	compositeLit, ok := tArgs[0].(*ast.CompositeLit)
+	if !ok {
+		return nil, false
+	}
+
+	values := make(map[string]ast.Expr)
+	for _, elt := range compositeLit.Elts {
+		keyValueExpr, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return nil, false
+		}
+
+		key, ok := keyValueExpr.Key.(*ast.BasicLit)
+		if !ok || key.Kind != token.STRING {
+			return nil, false
+		}
+
+		arg, _ := strconv.Unquote(key.Value)
+		values[arg] = keyValueExpr.Value
+	}
+
+	for _, arg := range accessor.Args {
+		value, ok := values[arg]
+		if !ok {
+			return nil, false
+		}
+		args = append(args, value)
+	}
+
+	return args, true
+}
+
+// isStringExpr returns whether an expression is a string literal or a call
+// of T() or of an accessor
+func (rp *rewritePackage) isStringExpr(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		return expr.Kind == token.STRING
+	case *ast.CallExpr:
+		if common.IsTCallExpr(expr, rp.options.QualifierFlag, rp.options.TFuncs()) {
+			return true
+		}
+		var funName string
+		switch fun := expr.Fun.(type) {
+		case *ast.Ident:
+			funName = fun.Name
+		case *ast.SelectorExpr:
+			if ident, ok := fun.X.(*ast.Ident); ok && ident.Name == rp.options.QualifierFlag {
+				funName = fun.Sel.Name
+			}
+		}
+		for _, accessor := range rp.Accessors {
+			if accessor.Name == funName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
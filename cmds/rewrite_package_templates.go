@@ -0,0 +1,88 @@
+package cmds
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go/ast"
+	"go/token"
+
+	"github.com/EverlongProject/i18n4go/common"
+)
+
+// processTemplateFilename wraps the texts of a text/template or html/template
+// file in {{T "..."}} actions, the T func must be added to the FuncMap of the
+// template by the code parsing it
+func (rp *rewritePackage) processTemplateFilename(fileName string) error {
+	rp.TotalFiles += 1
+	rp.Println("i18n4go: rewriting strings for template file:", fileName)
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		rp.Println(err)
+		return err
+	}
+
+	rewritten, count, err := common.RewriteTemplate(string(content), rp.isTemplateStringRewritten)
+	if err != nil {
+		rp.Println("i18n4go: error parsing template file:", err.Error())
+		return err
+	}
+	rp.TotalStrings += count
+
+	fileInfo, err := os.Stat(fileName)
+	if err != nil {
+		return err
+	}
+
+	if rp.OutputDirname == "" {
+		rp.OutputDirname = filepath.Dir(fileName)
+	}
+
+	pathToFile := filepath.Join(rp.OutputDirname, rp.relativePathForFile(fileName))
+	common.CreateOutputDirsIfNeeded(filepath.Dir(pathToFile))
+
+	rp.Println("saving file to path", pathToFile)
+	return os.WriteFile(pathToFile, []byte(rewritten), fileInfo.Mode())
+}
+
+// templateLitTFunc wraps the texts of a template literal of a Go file, e.g.,
+// template.New("page").Parse("<p>Hello</p>"), instead of the whole literal
+func (rp *rewritePackage) templateLitTFunc(basicLit *ast.BasicLit) {
+	if rp.directives.IsIgnored(basicLit.Pos()) {
+		return
+	}
+
+	text, err := strconv.Unquote(basicLit.Value)
+	if err != nil {
+		return
+	}
+
+	rewritten, count, err := common.RewriteTemplate(text, rp.isTemplateStringRewritten)
+	if err != nil {
+		rp.Println("i18n4go: WARNING could not parse template:", err.Error())
+		return
+	}
+	if count == 0 {
+		return
+	}
+	rp.TotalStrings += count
+
+	if strings.HasPrefix(basicLit.Value, "`") && !strings.Contains(rewritten, "`") {
+		basicLit.Value = "`" + rewritten + "`"
+	} else {
+		basicLit.Value = strconv.Quote(rewritten)
+	}
+	basicLit.Kind = token.STRING
+}
+
+func (rp *rewritePackage) isTemplateStringRewritten(s string) bool {
+	if rp.ExtractedStrings == nil {
+		return true
+	}
+
+	_, ok := rp.ExtractedStrings[s]
+	return ok
+}
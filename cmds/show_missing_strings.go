@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -13,6 +14,7 @@ import (
 	"go/token"
 
 	"github.com/EverlongProject/i18n4go/common"
+	"github.com/EverlongProject/i18n4go/i18n"
 )
 
 type ShowMissingStrings struct {
@@ -22,6 +24,12 @@ type ShowMissingStrings struct {
 	TranslatedStrings   []string
 	I18nStringsFilename string
 	Directory           string
+
+	MissingUsageFilename string
+	MissingUsages        []i18n.MissingUsage
+
+	Findings  []common.Finding
+	positions map[string]token.Position
 }
 
 func NewShowMissingStrings(options common.Options) ShowMissingStrings {
@@ -30,6 +38,10 @@ func NewShowMissingStrings(options common.Options) ShowMissingStrings {
 		Directory:           options.DirnameFlag,
 		I18nStringsFilename: options.I18nStringsFilenameFlag,
 		TranslatedStrings:   []string{},
+
+		MissingUsageFilename: options.MissingUsageFilenameFlag,
+
+		positions: make(map[string]token.Position),
 	}
 }
 
@@ -54,7 +66,28 @@ func (sms *ShowMissingStrings) Printf(msg string, a ...interface{}) (int, error)
 }
 
 func (sms *ShowMissingStrings) Run() error {
-	return sms.showMissingStrings()
+	err := common.CheckFindingsFormat(sms.options.FormatFlag)
+	if err != nil {
+		return err
+	}
+
+	err = sms.showMissingStrings()
+	if !common.IsTextFormat(sms.options.FormatFlag) {
+		if writeErr := common.WriteFindings(os.Stdout, sms.options.FormatFlag, "show-missing-strings", sms.Findings); writeErr != nil {
+			return writeErr
+		}
+	}
+
+	return err
+}
+
+// report prints a missing or additional string in the text format, else
+// records its finding
+func (sms *ShowMissingStrings) report(text string, finding common.Finding) {
+	if common.IsTextFormat(sms.options.FormatFlag) {
+		fmt.Println(text)
+	}
+	sms.Findings = append(sms.Findings, finding)
 }
 
 func (sms *ShowMissingStrings) showMissingStrings() error {
@@ -67,6 +100,13 @@ func (sms *ShowMissingStrings) showMissingStrings() error {
 	}
 	sms.I18nStringInfos = stringInfos
 
+	if sms.MissingUsageFilename != "" {
+		sms.MissingUsages, err = i18n.LoadMissingUsages(sms.MissingUsageFilename)
+		if err != nil {
+			return err
+		}
+	}
+
 	//Run AST to get list of strings
 	err = sms.parseFiles()
 	if err != nil {
@@ -74,13 +114,15 @@ func (sms *ShowMissingStrings) showMissingStrings() error {
 	}
 
 	//Compare list of strings with <lang>.all.json
-	err = sms.showMissingTranslatedStrings()
-	if err != nil {
-		return err
-	}
+	missingErr := sms.showMissingTranslatedStrings()
 
 	//Compare list of translated strings with strings in codebase
-	return sms.showExtraStrings()
+	extraErr := sms.showExtraStrings()
+	if missingErr != nil {
+		return missingErr
+	}
+
+	return extraErr
 }
 
 func (sms *ShowMissingStrings) parseFiles() error {
@@ -122,26 +164,21 @@ func (sms *ShowMissingStrings) inspectFile(filename string) error {
 }
 
 func (sms *ShowMissingStrings) extractString(f *ast.File, fset *token.FileSet, filename string) error {
+	directives := common.NewDirectives(fset, f)
 	ast.Inspect(f, func(n ast.Node) bool {
-		switch x := n.(type) {
-		case *ast.CallExpr:
-			switch x.Fun.(type) {
-			case *ast.Ident:
-				funName := x.Fun.(*ast.Ident).Name
-
-				if funName == "T" || funName == "t" {
-					if stringArg, ok := x.Args[0].(*ast.BasicLit); ok {
-						translatedString, err := strconv.Unquote(stringArg.Value)
-						if err != nil {
-							panic(err.Error())
-						}
-
-						sms.Println("Adding to translated strings:", translatedString)
-						sms.TranslatedStrings = append(sms.TranslatedStrings, filename+": "+translatedString)
-					}
+		if x, ok := n.(*ast.CallExpr); ok && len(x.Args) > 0 && common.IsTCallExpr(x, sms.options.QualifierFlag, sms.options.TFuncs()) {
+			if stringArg, ok := x.Args[0].(*ast.BasicLit); ok && !directives.IsIgnored(stringArg.Pos()) {
+				translatedString, err := strconv.Unquote(stringArg.Value)
+				if err != nil {
+					panic(err.Error())
 				}
-			default:
-				//Skip!
+
+				sms.Println("Adding to translated strings:", translatedString)
+				codeString := filename + ": " + translatedString
+				if _, ok := sms.positions[codeString]; !ok {
+					sms.positions[codeString] = fset.Position(stringArg.Pos())
+				}
+				sms.TranslatedStrings = append(sms.TranslatedStrings, codeString)
 			}
 		}
 		return true
@@ -151,14 +188,74 @@ func (sms *ShowMissingStrings) extractString(f *ast.File, fset *token.FileSet, f
 }
 
 func (sms *ShowMissingStrings) showMissingTranslatedStrings() error {
+	// with the strings missing at runtime the most used are shown first
+	usageCounts := make(map[string]int)
+	for _, usage := range sms.MissingUsages {
+		usageCounts[usage.ID] += usage.Count
+	}
+	sort.SliceStable(sms.TranslatedStrings, func(i, j int) bool {
+		_, iString := splitFilePathAndString(sms.TranslatedStrings[i])
+		_, jString := splitFilePathAndString(sms.TranslatedStrings[j])
+		return usageCounts[iString] > usageCounts[jString]
+	})
+
+	locale := languageForFilename(sms.I18nStringsFilename)
 	missingStrings := false
+	reportedStrings := make(map[string]bool)
 	for _, codeString := range sms.TranslatedStrings {
 		if !sms.stringInStringInfos(codeString, sms.I18nStringInfos) {
-			fmt.Println("Missing:", codeString)
+			filename, translatedString := splitFilePathAndString(codeString)
+			reportedStrings[translatedString] = true
+
+			text := "Missing: " + codeString
+			message := fmt.Sprintf("%q is not in %s", translatedString, sms.I18nStringsFilename)
+			if count := usageCounts[translatedString]; count > 0 {
+				text = fmt.Sprintf("Missing: %s (used %d times at runtime)", codeString, count)
+				message = fmt.Sprintf("%q is not in %s, it was used %d times at runtime", translatedString, sms.I18nStringsFilename, count)
+			}
+
+			position := sms.positions[codeString]
+			sms.report(text, common.Finding{
+				RuleID:   common.RULE_MISSING_STRING,
+				Severity: common.SEVERITY_ERROR,
+				Message:  message,
+				Locale:   locale,
+				Key:      translatedString,
+				Filename: strings.TrimSuffix(filename, ": "),
+				Line:     position.Line,
+				Column:   position.Column,
+				Fix:      fmt.Sprintf("Add %q to %s", translatedString, sms.I18nStringsFilename),
+			})
 			missingStrings = true
 		}
 	}
 
+	i18nStringIDs := make(map[string]bool, len(sms.I18nStringInfos))
+	for _, stringInfo := range sms.I18nStringInfos {
+		i18nStringIDs[stringInfo.ID] = true
+	}
+
+	for _, usage := range sms.MissingUsages {
+		if i18nStringIDs[usage.ID] || reportedStrings[usage.ID] {
+			continue
+		}
+
+		finding := common.Finding{
+			RuleID:   common.RULE_MISSING_AT_RUNTIME,
+			Severity: common.SEVERITY_ERROR,
+			Message:  fmt.Sprintf("%q was missing in %s at runtime, it was used %d times", usage.ID, usage.Locale, usage.Count),
+			Locale:   usage.Locale,
+			Key:      usage.ID,
+			Fix:      fmt.Sprintf("Add %q to %s", usage.ID, sms.I18nStringsFilename),
+		}
+		if len(usage.Callers) > 0 {
+			finding.Filename, finding.Line = splitCaller(usage.Callers[0])
+		}
+
+		sms.report(fmt.Sprintf("Missing at runtime: %s: %s (%s, used %d times)", strings.Join(usage.Callers, ","), usage.ID, usage.Locale, usage.Count), finding)
+		missingStrings = true
+	}
+
 	if missingStrings {
 		return errors.New("Missing Strings!")
 	}
@@ -166,6 +263,22 @@ func (sms *ShowMissingStrings) showMissingTranslatedStrings() error {
 	return nil
 }
 
+// splitCaller returns the file and line of a caller of the missing usages,
+// e.g., app.go:12
+func splitCaller(caller string) (string, int) {
+	index := strings.LastIndex(caller, ":")
+	if index < 0 {
+		return caller, 0
+	}
+
+	line, err := strconv.Atoi(caller[index+1:])
+	if err != nil {
+		return caller, 0
+	}
+
+	return caller[:index], line
+}
+
 func splitFilePathAndString(str string) (string, string) {
 	splitFileStr := strings.SplitAfterN(str, ": ", 2)
 	return splitFileStr[0], splitFileStr[1]
@@ -188,7 +301,15 @@ func (sms *ShowMissingStrings) showExtraStrings() error {
 	additionalStrings := false
 	for _, stringInfo := range sms.I18nStringInfos {
 		if !stringInTranslatedStrings(stringInfo.ID, sms.TranslatedStrings) {
-			fmt.Println("Additional:", stringInfo.ID)
+			sms.report("Additional: "+stringInfo.ID, common.Finding{
+				RuleID:   common.RULE_ADDITIONAL_STRING,
+				Severity: common.SEVERITY_ERROR,
+				Message:  fmt.Sprintf("%q is not translated in the code", stringInfo.ID),
+				Locale:   languageForFilename(sms.I18nStringsFilename),
+				Key:      stringInfo.ID,
+				Filename: sms.I18nStringsFilename,
+				Fix:      fmt.Sprintf("Remove %q from %s", stringInfo.ID, sms.I18nStringsFilename),
+			})
 			additionalStrings = true
 		}
 	}
@@ -0,0 +1,115 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/EverlongProject/i18n4go/common"
+	"github.com/EverlongProject/i18n4go/tm"
+)
+
+type updateTm struct {
+	options common.Options
+
+	Filename          string
+	TmFilename        string
+	SourceLanguage    string
+	Languages         []string
+	LanguageFilenames []string
+}
+
+func NewUpdateTm(options common.Options) updateTm {
+	return updateTm{options: options,
+		Filename:          options.FilenameFlag,
+		TmFilename:        options.TmFilenameFlag,
+		SourceLanguage:    options.SourceLanguageFlag,
+		Languages:         common.ParseStringList(options.LanguagesFlag, ","),
+		LanguageFilenames: common.ParseStringList(options.LanguageFilesFlag, ","),
+	}
+}
+
+func (ut *updateTm) Options() common.Options {
+	return ut.options
+}
+
+func (ut *updateTm) Println(a ...interface{}) (int, error) {
+	if ut.options.VerboseFlag {
+		return fmt.Println(a...)
+	}
+
+	return 0, nil
+}
+
+func (ut *updateTm) Printf(msg string, a ...interface{}) (int, error) {
+	if ut.options.VerboseFlag {
+		return fmt.Printf(msg, a...)
+	}
+
+	return 0, nil
+}
+
+// Run adds the reviewed translations of the translation files, i.e., not
+// modified, to the translation memory
+func (ut *updateTm) Run() error {
+	memory, err := tm.Load(ut.TmFilename)
+	if err != nil {
+		ut.Println(err)
+		return fmt.Errorf("i18n4go: could not load translation memory: %s", ut.TmFilename)
+	}
+
+	sourceI18nStringInfos, err := common.LoadI18nStringInfos(ut.Filename)
+	if err != nil {
+		ut.Println(err)
+		return fmt.Errorf("i18n4go: could not load i18n strings from file: %s", ut.Filename)
+	}
+
+	fileName, filePath, err := common.CheckFile(ut.Filename)
+	if err != nil {
+		return err
+	}
+
+	languages, targetFilenames := ut.determineTargetFilenames(fileName, filePath)
+	for i, targetFilename := range targetFilenames {
+		if _, err := os.Stat(targetFilename); os.IsNotExist(err) {
+			fmt.Println("i18n4go: WARNING translation file does not exist:", targetFilename)
+			continue
+		}
+
+		i18nStringInfos, err := common.LoadI18nStringInfos(targetFilename)
+		if err != nil {
+			ut.Println(err)
+			return fmt.Errorf("i18n4go: could not load i18n strings from file: %s", targetFilename)
+		}
+
+		count := memory.AddTranslations(ut.SourceLanguage, sourceI18nStringInfos, languages[i], i18nStringInfos)
+		ut.Printf("i18n4go: added %d translations of %s to the translation memory\n", count, targetFilename)
+	}
+
+	ut.Printf("i18n4go: the translation memory %s has %d translations\n", ut.TmFilename, memory.Len())
+	if ut.options.DryRunFlag {
+		return nil
+	}
+
+	return memory.Save(ut.TmFilename)
+}
+
+// determineTargetFilenames returns the languages and translation files to
+// add, either from --language-files or from --languages like verify-strings
+func (ut *updateTm) determineTargetFilenames(fileName, filePath string) ([]string, []string) {
+	if len(ut.LanguageFilenames) != 0 {
+		languages := make([]string, len(ut.LanguageFilenames))
+		for i, languageFilename := range ut.LanguageFilenames {
+			languages[i] = strings.SplitN(filepath.Base(languageFilename), ".", 2)[0]
+		}
+		return languages, ut.LanguageFilenames
+	}
+
+	targetFilenames := make([]string, len(ut.Languages))
+	for i, language := range ut.Languages {
+		targetFilenames[i] = filepath.Join(filePath, strings.Replace(fileName, ut.SourceLanguage, language, -1))
+	}
+
+	return ut.Languages, targetFilenames
+}
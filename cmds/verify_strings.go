@@ -1,18 +1,11 @@
 package cmds
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 	"strings"
 
-	"encoding/json"
-	"io/ioutil"
-
-	"reflect"
-
 	"github.com/EverlongProject/i18n4go/common"
 )
 
@@ -25,6 +18,12 @@ type verifyStrings struct {
 	SourceLanguage    string
 	LanguageFilenames []string
 	Languages         []string
+
+	// ExtractedDirname has the *.extracted.json files of extract-strings
+	// --meta, locating the findings in the Go files
+	ExtractedDirname     string
+	ExtractedStringInfos map[string][]common.StringInfo
+	Findings             []common.Finding
 }
 
 func NewVerifyStrings(options common.Options) verifyStrings {
@@ -37,6 +36,7 @@ func NewVerifyStrings(options common.Options) verifyStrings {
 		LanguageFilenames: languageFilenames,
 		Languages:         languages,
 		SourceLanguage:    options.SourceLanguageFlag,
+		ExtractedDirname:  options.DirnameFlag,
 	}
 }
 
@@ -61,12 +61,25 @@ func (vs *verifyStrings) Printf(msg string, a ...interface{}) (int, error) {
 }
 
 func (vs *verifyStrings) Run() error {
+	err := common.CheckFindingsFormat(vs.options.FormatFlag)
+	if err != nil {
+		return err
+	}
+
 	fileName, filePath, err := common.CheckFile(vs.InputFilename)
 	if err != nil {
 		vs.Println("i18n4go: Error checking input filename: ", vs.InputFilename)
 		return err
 	}
 
+	if vs.ExtractedDirname != "" {
+		vs.ExtractedStringInfos, err = common.LoadExtractedStringInfos(vs.ExtractedDirname)
+		if err != nil {
+			vs.Println(err)
+			return fmt.Errorf("i18n4go: could not load the *.extracted.json files in: %s", vs.ExtractedDirname)
+		}
+	}
+
 	targetFilenames := vs.determineTargetFilenames(fileName, filePath)
 	vs.Println("targetFilenames:", targetFilenames)
 	for _, targetFilename := range targetFilenames {
@@ -76,9 +89,25 @@ func (vs *verifyStrings) Run() error {
 		}
 	}
 
+	if !common.IsTextFormat(vs.options.FormatFlag) {
+		if writeErr := common.WriteFindings(os.Stdout, vs.options.FormatFlag, "verify-strings", vs.Findings); writeErr != nil {
+			return writeErr
+		}
+	}
+
 	return err
 }
 
+// addFinding records a finding of a target file, located in the Go files
+// when its string is in the *.extracted.json files
+func (vs *verifyStrings) addFinding(finding common.Finding) {
+	if stringInfos := vs.ExtractedStringInfos[finding.Key]; len(stringInfos) > 0 {
+		finding.Filename, finding.Line, finding.Column = stringInfos[0].Filename, stringInfos[0].Line, stringInfos[0].Column
+	}
+
+	vs.Findings = append(vs.Findings, finding)
+}
+
 func (vs *verifyStrings) determineTargetFilenames(inputFilename string, inputFilePath string) []string {
 	if len(vs.LanguageFilenames) != 0 {
 		return vs.LanguageFilenames
@@ -94,113 +123,10 @@ func (vs *verifyStrings) determineTargetFilenames(inputFilename string, inputFil
 	return targetFilenames
 }
 
-type I18nStringInfo struct {
-	ID          string      `json:"id"`
-	Translation interface{} `json:"translation"`
-	Modified    bool        `json:"modified"`
-}
-
-func (info I18nStringInfo) Translations() (translations []string) {
-	switch v := info.Translation.(type) {
-	case string:
-		translations = append(translations, v)
-	case map[string]interface{}:
-		for _, val := range v {
-			switch s := val.(type) {
-			case string:
-				translations = append(translations, s)
-			}
-		}
-	default:
-		panic(fmt.Sprintf("Unexpected type %v", reflect.TypeOf(v)))
-	}
-	return
-}
-
-func LoadI18nStringInfos(fileName string) ([]I18nStringInfo, error) {
-	_, err := os.Stat(fileName)
-	if os.IsNotExist(err) {
-		return nil, err
-	}
-
-	content, err := ioutil.ReadFile(fileName)
-	if err != nil {
-		return nil, err
-	}
-	var i18nStringInfos []I18nStringInfo
-	if content[0] == '[' {
-		err = json.Unmarshal(content, &i18nStringInfos)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		// flat file
-		var mp map[string]interface{}
-		err = json.Unmarshal(content, &mp)
-		if err != nil {
-			return nil, err
-		}
-		for k, v := range mp {
-			i18nStringInfos = append(i18nStringInfos, I18nStringInfo{
-				ID:          k,
-				Translation: v,
-			})
-		}
-		sort.Slice(i18nStringInfos, func(i, j int) bool { return i18nStringInfos[i].ID < i18nStringInfos[j].ID })
-	}
-
-	return i18nStringInfos, nil
-}
-
-func CreateI18nStringInfoMap(i18nStringInfos []I18nStringInfo) (map[string]I18nStringInfo, error) {
-	inputMap := make(map[string]I18nStringInfo, len(i18nStringInfos))
-
-	for _, i18nStringInfo := range i18nStringInfos {
-
-		if _, ok := inputMap[i18nStringInfo.ID]; !ok {
-			inputMap[i18nStringInfo.ID] = i18nStringInfo
-		} else {
-			return nil, errors.New("Duplicated key found: " + i18nStringInfo.ID)
-		}
-
-	}
-
-	return inputMap, nil
-}
-
-func SaveI18nStringInfos(printer common.PrinterInterface, options common.Options, i18nStringInfos []I18nStringInfo, fileName string) error {
-	var jsonData []byte
-	var err error
-	if options.OutputFormatFlatFlag {
-		strs := map[string]interface{}{}
-		for _, i := range i18nStringInfos {
-			strs[i.ID] = i.Translation
-		}
-		jsonData, err = json.MarshalIndent(strs, "", "   ")
-	} else {
-		jsonData, err = json.MarshalIndent(i18nStringInfos, "", "   ")
-	}
-	if err != nil {
-		printer.Println(err)
-		return err
-	}
-	jsonData = common.UnescapeHTML(jsonData)
-
-	if !options.DryRunFlag && len(i18nStringInfos) != 0 {
-		err := ioutil.WriteFile(fileName, jsonData, 0644)
-		if err != nil {
-			printer.Println(err)
-			return err
-		}
-	}
-
-	return nil
-}
-
 func (vs *verifyStrings) verify(inputFilename string, targetFilename string) error {
 	common.CheckFile(targetFilename)
 
-	inputI18nStringInfos, err := LoadI18nStringInfos(inputFilename)
+	inputI18nStringInfos, err := common.LoadI18nStringInfos(inputFilename)
 	if err != nil {
 		vs.Println("i18n4go: Error loading the i18n strings from input filename:", inputFilename)
 		return err
@@ -210,32 +136,64 @@ func (vs *verifyStrings) verify(inputFilename string, targetFilename string) err
 		return fmt.Errorf("i18n4go: Error input file: %s is empty", inputFilename)
 	}
 
-	inputMap, err := CreateI18nStringInfoMap(inputI18nStringInfos)
+	inputMap, err := common.CreateI18nStringInfoMap(inputI18nStringInfos)
 	if err != nil {
 		return fmt.Errorf("File has duplicated key: %s\n%s", inputFilename, err)
 	}
 	return vs.Verify(inputMap, targetFilename)
 }
 
-func (vs *verifyStrings) Verify(inputMap map[string]I18nStringInfo, targetFilename string) error {
+func (vs *verifyStrings) Verify(inputMap map[string]common.I18nStringInfo, targetFilename string) error {
 
-	targetI18nStringInfos, err := LoadI18nStringInfos(targetFilename)
+	targetI18nStringInfos, err := common.LoadI18nStringInfos(targetFilename)
 	if err != nil {
 		vs.Println("i18n4go: Error loading the i18n strings from target filename:", targetFilename)
 		return err
 	}
 
-	var targetExtraStringInfos, targetInvalidStringInfos []I18nStringInfo
+	targetLanguage := languageForFilename(targetFilename)
+
+	var targetExtraStringInfos, targetInvalidStringInfos []common.I18nStringInfo
 	for _, stringInfo := range targetI18nStringInfos {
-		if _, ok := inputMap[stringInfo.ID]; ok {
+		if inputStringInfo, ok := inputMap[stringInfo.ID]; ok {
 			if common.IsTemplatedString(stringInfo.ID) && vs.isTemplatedStringTranslationInvalid(stringInfo) {
 				vs.Println("i18n4go: WARNING target file has invalid templated translations with key ID: ", stringInfo.ID)
 				targetInvalidStringInfos = append(targetInvalidStringInfos, stringInfo)
+				vs.addFinding(common.Finding{
+					RuleID:   common.RULE_INVALID_TEMPLATE,
+					Severity: common.SEVERITY_ERROR,
+					Message:  fmt.Sprintf("The %s translation of %q does not have the args of the string", targetLanguage, stringInfo.ID),
+					Locale:   targetLanguage,
+					Key:      stringInfo.ID,
+					Filename: targetFilename,
+					Fix:      fmt.Sprintf("Use the args %s in the translation", strings.Join(common.GetTemplatedStringArgs(stringInfo.ID), ",")),
+				})
+			} else if vs.isPluralTranslationInvalid(inputStringInfo, stringInfo, targetLanguage, targetFilename) {
+				vs.Println("i18n4go: WARNING target file has invalid plural translations with key ID: ", stringInfo.ID)
+				targetInvalidStringInfos = append(targetInvalidStringInfos, stringInfo)
+				vs.addFinding(common.Finding{
+					RuleID:   common.RULE_INVALID_PLURAL,
+					Severity: common.SEVERITY_ERROR,
+					Message:  fmt.Sprintf("The %s translation of %q is missing plural categories", targetLanguage, stringInfo.ID),
+					Locale:   targetLanguage,
+					Key:      stringInfo.ID,
+					Filename: targetFilename,
+					Fix:      fmt.Sprintf("Translate the %s plural categories: %s", targetLanguage, strings.Join(common.PluralRuleForLanguage(targetLanguage).Categories, ",")),
+				})
 			}
 			delete(inputMap, stringInfo.ID)
 		} else {
 			vs.Println("i18n4go: WARNING target file has extra key with ID: ", stringInfo.ID)
 			targetExtraStringInfos = append(targetExtraStringInfos, stringInfo)
+			vs.addFinding(common.Finding{
+				RuleID:   common.RULE_EXTRA_TRANSLATION,
+				Severity: common.SEVERITY_ERROR,
+				Message:  fmt.Sprintf("The %s translation of %q has no string in the source translation file", targetLanguage, stringInfo.ID),
+				Locale:   targetLanguage,
+				Key:      stringInfo.ID,
+				Filename: targetFilename,
+				Fix:      fmt.Sprintf("Remove %q from %s", stringInfo.ID, targetFilename),
+			})
 		}
 	}
 
@@ -267,6 +225,18 @@ func (vs *verifyStrings) Verify(inputMap map[string]I18nStringInfo, targetFilena
 	if len(inputMap) > 0 {
 		vs.Println("i18n4go: ERROR input file does not match target file:", targetFilename)
 
+		for id := range inputMap {
+			vs.addFinding(common.Finding{
+				RuleID:   common.RULE_MISSING_TRANSLATION,
+				Severity: common.SEVERITY_ERROR,
+				Message:  fmt.Sprintf("%q is not translated in %s", id, targetLanguage),
+				Locale:   targetLanguage,
+				Key:      id,
+				Filename: targetFilename,
+				Fix:      fmt.Sprintf("Add a translation of %q to %s", id, targetFilename),
+			})
+		}
+
 		diffFilename, err := vs.generateMissingKeysDiffFile(valuesForI18nStringInfoMap(inputMap), targetFilename)
 		if err != nil {
 			vs.Println("i18n4go: ERROR could not create the diff file:", err)
@@ -279,11 +249,11 @@ func (vs *verifyStrings) Verify(inputMap map[string]I18nStringInfo, targetFilena
 	return verficationError
 }
 
-func (vs *verifyStrings) isTemplatedStringTranslationInvalid(stringInfo I18nStringInfo) bool {
+func (vs *verifyStrings) isTemplatedStringTranslationInvalid(stringInfo common.I18nStringInfo) bool {
 	if !common.IsTemplatedString(stringInfo.ID) {
 		return false
 	}
-	if stringInfo.Translation == nil {
+	if !stringInfo.IsPlural() && stringInfo.Translation == "" {
 		return true
 	}
 	translations := stringInfo.Translations()
@@ -332,7 +302,51 @@ func (vs *verifyStrings) isTemplatedStringTranslationInvalid(stringInfo I18nStri
 	return false
 }
 
-func keysForI18nStringInfos(in18nStringInfos []I18nStringInfo) []string {
+// isPluralTranslationInvalid returns whether the translation of a plural
+// message is not plural or misses plural categories of the target language
+func (vs *verifyStrings) isPluralTranslationInvalid(inputStringInfo common.I18nStringInfo, stringInfo common.I18nStringInfo, language, targetFilename string) bool {
+	if !inputStringInfo.IsPlural() && !stringInfo.IsPlural() {
+		return false
+	}
+
+	if !stringInfo.IsPlural() {
+		vs.Println("i18n4go: plural string is invalid, translation is not plural:", stringInfo.ID)
+		return true
+	}
+
+	missingCategories := stringInfo.MissingPluralCategories(language)
+	if len(missingCategories) > 0 {
+		vs.Printf("i18n4go: plural string is invalid, missing plural categories for %s: %s\n", language, strings.Join(missingCategories, ","))
+		return true
+	}
+
+	extraCategories := stringInfo.ExtraPluralCategories(language)
+	if len(extraCategories) > 0 {
+		vs.Printf("i18n4go: WARNING plural string %q has plural categories not used in %s: %s\n", stringInfo.ID, language, strings.Join(extraCategories, ","))
+		vs.addFinding(common.Finding{
+			RuleID:   common.RULE_EXTRA_PLURAL_CATEGORIES,
+			Severity: common.SEVERITY_WARNING,
+			Message:  fmt.Sprintf("The %s translation of %q has plural categories not used in %s: %s", language, stringInfo.ID, language, strings.Join(extraCategories, ",")),
+			Locale:   language,
+			Key:      stringInfo.ID,
+			Filename: targetFilename,
+			Fix:      fmt.Sprintf("Remove the plural categories %s", strings.Join(extraCategories, ",")),
+		})
+	}
+
+	return false
+}
+
+// languageForFilename returns the language of a translation file name such
+// as quota.go.fr.json or fr.all.json
+func languageForFilename(fileName string) string {
+	name := strings.TrimSuffix(filepath.Base(fileName), ".json")
+	name = strings.TrimSuffix(name, ".all")
+
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+func keysForI18nStringInfos(in18nStringInfos []common.I18nStringInfo) []string {
 	var keys []string
 	for _, stringInfo := range in18nStringInfos {
 		keys = append(keys, stringInfo.ID)
@@ -340,7 +354,7 @@ func keysForI18nStringInfos(in18nStringInfos []I18nStringInfo) []string {
 	return keys
 }
 
-func keysForI18nStringInfoMap(inputMap map[string]I18nStringInfo) []string {
+func keysForI18nStringInfoMap(inputMap map[string]common.I18nStringInfo) []string {
 	var keys []string
 	for k, _ := range inputMap {
 		keys = append(keys, k)
@@ -348,15 +362,15 @@ func keysForI18nStringInfoMap(inputMap map[string]I18nStringInfo) []string {
 	return keys
 }
 
-func valuesForI18nStringInfoMap(inputMap map[string]I18nStringInfo) []I18nStringInfo {
-	var values []I18nStringInfo
+func valuesForI18nStringInfoMap(inputMap map[string]common.I18nStringInfo) []common.I18nStringInfo {
+	var values []common.I18nStringInfo
 	for _, v := range inputMap {
 		values = append(values, v)
 	}
 	return values
 }
 
-func (vs *verifyStrings) generateMissingKeysDiffFile(missingStringInfos []I18nStringInfo, fileName string) (string, error) {
+func (vs *verifyStrings) generateMissingKeysDiffFile(missingStringInfos []common.I18nStringInfo, fileName string) (string, error) {
 	name, pathName, err := common.CheckFile(fileName)
 	if err != nil {
 		return "", err
@@ -370,10 +384,10 @@ func (vs *verifyStrings) generateMissingKeysDiffFile(missingStringInfos []I18nSt
 		diffFilename = filepath.Join(pathName, diffFilename)
 	}
 
-	return diffFilename, SaveI18nStringInfos(vs, vs.Options(), missingStringInfos, diffFilename)
+	return diffFilename, common.SaveI18nStringInfos(vs, vs.Options(), missingStringInfos, diffFilename)
 }
 
-func (vs *verifyStrings) generateExtraKeysDiffFile(extraStringInfos []I18nStringInfo, fileName string) (string, error) {
+func (vs *verifyStrings) generateExtraKeysDiffFile(extraStringInfos []common.I18nStringInfo, fileName string) (string, error) {
 	name, pathName, err := common.CheckFile(fileName)
 	if err != nil {
 		return "", err
@@ -387,10 +401,10 @@ func (vs *verifyStrings) generateExtraKeysDiffFile(extraStringInfos []I18nString
 		diffFilename = filepath.Join(pathName, diffFilename)
 	}
 
-	return diffFilename, SaveI18nStringInfos(vs, vs.Options(), extraStringInfos, diffFilename)
+	return diffFilename, common.SaveI18nStringInfos(vs, vs.Options(), extraStringInfos, diffFilename)
 }
 
-func (vs *verifyStrings) generateInvalidTranslationDiffFile(invalidStringInfos []I18nStringInfo, fileName string) (string, error) {
+func (vs *verifyStrings) generateInvalidTranslationDiffFile(invalidStringInfos []common.I18nStringInfo, fileName string) (string, error) {
 	name, pathName, err := common.CheckFile(fileName)
 	if err != nil {
 		return "", err
@@ -404,5 +418,5 @@ func (vs *verifyStrings) generateInvalidTranslationDiffFile(invalidStringInfos [
 		diffFilename = filepath.Join(pathName, diffFilename)
 	}
 
-	return diffFilename, SaveI18nStringInfos(vs, vs.Options(), invalidStringInfos, diffFilename)
+	return diffFilename, common.SaveI18nStringInfos(vs, vs.Options(), invalidStringInfos, diffFilename)
 }
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	ACCESSORS_FILENAME = "i18n_accessors.go"
+	ACCESSOR_PREFIX    = "Msg"
+	MAX_ACCESSOR_WORDS = 6
+
+	// COUNT_ARG is the template arg go-i18n sets to the count of plural strings
+	COUNT_ARG = "Count"
+)
+
+// Accessor is the Go func generated for a string, e.g., MsgDeletingApp(name
+// string) for "Deleting app {{.Name}}...", Params are the names of the
+// string parameters of its Args, plural strings also have a count parameter
+type Accessor struct {
+	ID     string
+	Name   string
+	Plural bool
+	Args   []string
+	Params []string
+}
+
+// NewAccessors returns the accessors of the strings keyed by ID, the names
+// are the first words of the IDs, so adding strings never renames accessors,
+// and it fails when the names of two IDs collide
+func NewAccessors(i18nStringInfos []I18nStringInfo) (map[string]Accessor, error) {
+	sorted := make([]I18nStringInfo, len(i18nStringInfos))
+	copy(sorted, i18nStringInfos)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	accessors := make(map[string]Accessor, len(sorted))
+	ids := make(map[string]string, len(sorted))
+	for _, i18nStringInfo := range sorted {
+		if _, ok := accessors[i18nStringInfo.ID]; ok {
+			continue
+		}
+
+		name := accessorName(i18nStringInfo.ID)
+		if id, ok := ids[name]; ok {
+			return nil, fmt.Errorf("i18n4go: the accessors of %q and %q are both named %s, reword one of the strings", id, i18nStringInfo.ID, name)
+		}
+		ids[name] = i18nStringInfo.ID
+
+		accessor := Accessor{ID: i18nStringInfo.ID, Name: name, Plural: i18nStringInfo.IsPlural()}
+		params := map[string]bool{"count": accessor.Plural}
+		for _, arg := range GetTemplatedStringArgs(i18nStringInfo.ID) {
+			if (accessor.Plural && arg == COUNT_ARG) || containsString(accessor.Args, arg) {
+				continue
+			}
+
+			param := accessorParam(arg, len(accessor.Args))
+			for params[param] {
+				param += "_"
+			}
+			params[param] = true
+
+			accessor.Args = append(accessor.Args, arg)
+			accessor.Params = append(accessor.Params, param)
+		}
+
+		accessors[i18nStringInfo.ID] = accessor
+	}
+
+	return accessors, nil
+}
+
+// accessorName returns the Go name of the accessor of a string, the prefix
+// and its first words without the placeholders, e.g., MsgDeletingApp
+func accessorName(id string) string {
+	words := strings.FieldsFunc(placeholderRegexp.ReplaceAllString(id, " "), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > MAX_ACCESSOR_WORDS {
+		words = words[:MAX_ACCESSOR_WORDS]
+	}
+
+	name := ACCESSOR_PREFIX
+	for _, word := range words {
+		runes := []rune(word)
+		name += string(unicode.ToUpper(runes[0])) + string(runes[1:])
+	}
+
+	return name
+}
+
+// accessorParam returns the Go parameter name of a template arg, e.g., name
+// for Name, args that are not identifiers, e.g., {{.Name | printf}}, are argN
+func accessorParam(arg string, index int) string {
+	if !token.IsIdentifier(arg) {
+		return "arg" + strconv.Itoa(index)
+	}
+
+	runes := []rune(arg)
+	param := string(unicode.ToLower(runes[0])) + string(runes[1:])
+	if token.IsKeyword(param) || param == "string" || param == "int" {
+		param += "Arg"
+	}
+
+	return param
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,58 @@
+package common
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewAccessors(t *testing.T) {
+	accessors, err := NewAccessors([]I18nStringInfo{
+		{ID: "Deleting app {{.Name}}...", Translation: "Deleting app {{.Name}}..."},
+		NewPluralI18nStringInfo("Deleted {{.Count}} apps in {{.Org}}", map[string]string{"one": "Deleted {{.Count}} app in {{.Org}}", "other": "Deleted {{.Count}} apps in {{.Org}}"}, false),
+		{ID: "{{.Name | printf}} is {{.Type}}, not {{.Func}}", Translation: "{{.Name | printf}} is {{.Type}}, not {{.Func}}"},
+		{ID: "world", Translation: "world"},
+		{ID: "One two three four five six seven", Translation: "One two three four five six seven"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]Accessor{
+		"Deleting app {{.Name}}...":                      {ID: "Deleting app {{.Name}}...", Name: "MsgDeletingApp", Args: []string{"Name"}, Params: []string{"name"}},
+		"Deleted {{.Count}} apps in {{.Org}}":            {ID: "Deleted {{.Count}} apps in {{.Org}}", Name: "MsgDeletedAppsIn", Plural: true, Args: []string{"Org"}, Params: []string{"org"}},
+		"{{.Name | printf}} is {{.Type}}, not {{.Func}}": {ID: "{{.Name | printf}} is {{.Type}}, not {{.Func}}", Name: "MsgIsNot", Args: []string{"Name | printf", "Type", "Func"}, Params: []string{"arg0", "typeArg", "funcArg"}},
+		"world":                             {ID: "world", Name: "MsgWorld"},
+		"One two three four five six seven": {ID: "One two three four five six seven", Name: "MsgOneTwoThreeFourFiveSix"},
+	}
+
+	if !reflect.DeepEqual(accessors, expected) {
+		t.Errorf("expected accessors %v, got %v", expected, accessors)
+	}
+}
+
+func TestNewAccessorsAddingStrings(t *testing.T) {
+	i18nStringInfos := []I18nStringInfo{
+		{ID: "world", Translation: "world"},
+		{ID: "Deleting app {{.Name}}...", Translation: "Deleting app {{.Name}}..."},
+	}
+	accessors, err := NewAccessors(i18nStringInfos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	moreAccessors, err := NewAccessors(append([]I18nStringInfo{{ID: "A world", Translation: "A world"}}, i18nStringInfos...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, accessor := range accessors {
+		if moreAccessors[id].Name != accessor.Name {
+			t.Errorf("expected %q to keep the accessor %s, got %s", id, accessor.Name, moreAccessors[id].Name)
+		}
+	}
+
+	_, err = NewAccessors(append(i18nStringInfos, I18nStringInfo{ID: "World!", Translation: "World!"}))
+	if err == nil || !strings.Contains(err.Error(), `"World!" and "world" are both named MsgWorld`) {
+		t.Errorf("expected an error naming both strings, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package common
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+const (
+	I18N_COMMENT_PREFIX = "i18n:"
+	I18N_CONTEXT_PREFIX = "i18n:context="
+
+	// CONTEXT_SEPARATOR separates the context from the string in the keys
+	// of the strings with a context, like the gettext msgctxt
+	CONTEXT_SEPARATOR = "\x04"
+)
+
+// StringKey returns the key of a string in its context, the string itself
+// without context
+func StringKey(value, context string) string {
+	if context == "" {
+		return value
+	}
+
+	return context + CONTEXT_SEPARATOR + value
+}
+
+// Key returns the key of the extracted string, see StringKey
+func (stringInfo StringInfo) Key() string {
+	return StringKey(stringInfo.Value, stringInfo.Context)
+}
+
+// Annotations finds the translator comments, // i18n: <note>, and the
+// context, // i18n:context=<ctx>, of the strings of a Go file
+type Annotations struct {
+	fset          *token.FileSet
+	commentGroups []*ast.CommentGroup
+	trailing      map[*ast.CommentGroup]bool
+}
+
+// NewAnnotations returns the annotations of a file, the comments after some
+// code on their line only annotate the strings of that line
+func NewAnnotations(fset *token.FileSet, astFile *ast.File) *Annotations {
+	codeLines := firstCodePositions(fset, astFile)
+
+	trailing := make(map[*ast.CommentGroup]bool)
+	for _, commentGroup := range astFile.Comments {
+		if first, ok := codeLines[fset.Position(commentGroup.Pos()).Line]; ok && first < commentGroup.Pos() {
+			trailing[commentGroup] = true
+		}
+	}
+
+	return &Annotations{fset: fset, commentGroups: astFile.Comments, trailing: trailing}
+}
+
+// Find returns the translator comments and the context of the string at pos
+// from the comments on its line or right above it
+func (annotations *Annotations) Find(pos token.Pos) ([]string, string) {
+	var notes []string
+	var context string
+
+	line := annotations.fset.Position(pos).Line
+	for _, commentGroup := range annotations.commentGroups {
+		startLine := annotations.fset.Position(commentGroup.Pos()).Line
+		endLine := annotations.fset.Position(commentGroup.End()).Line
+		if startLine > line {
+			break
+		}
+		if endLine != line && (endLine != line-1 || annotations.trailing[commentGroup]) {
+			continue
+		}
+
+		for _, comment := range commentGroup.List {
+			text := commentText(comment.Text)
+			if strings.HasPrefix(text, I18N_CONTEXT_PREFIX) {
+				context = strings.TrimSpace(strings.TrimPrefix(text, I18N_CONTEXT_PREFIX))
+			} else if strings.HasPrefix(text, I18N_COMMENT_PREFIX) {
+				if note := strings.TrimSpace(strings.TrimPrefix(text, I18N_COMMENT_PREFIX)); note != "" {
+					notes = append(notes, note)
+				}
+			}
+		}
+	}
+
+	return notes, context
+}
+
+// MergeNotes returns the notes of a followed by those of b not in a
+func MergeNotes(a, b []string) []string {
+	merged := append([]string(nil), a...)
+	for _, note := range b {
+		found := false
+		for _, existing := range merged {
+			if existing == note {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, note)
+		}
+	}
+
+	return merged
+}
+
+// firstCodePositions returns the first position of code on each line
+func firstCodePositions(fset *token.FileSet, astFile *ast.File) map[int]token.Pos {
+	codeLines := make(map[int]token.Pos)
+	addCode := func(pos token.Pos) {
+		line := fset.Position(pos).Line
+		if first, ok := codeLines[line]; !ok || pos < first {
+			codeLines[line] = pos
+		}
+	}
+	ast.Inspect(astFile, func(n ast.Node) bool {
+		switch n.(type) {
+		case nil, *ast.File, *ast.CommentGroup, *ast.Comment:
+			return true
+		}
+		addCode(n.Pos())
+		addCode(n.End() - 1)
+		return true
+	})
+
+	return codeLines
+}
+
+func commentText(text string) string {
+	if strings.HasPrefix(text, "//") {
+		text = strings.TrimPrefix(text, "//")
+	} else {
+		text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+	}
+
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestFindAnnotations(t *testing.T) {
+	src := `package app
+
+// i18n: not above a string
+
+func f() {
+	// i18n: the state of an app
+	// i18n:context=app state
+	say("Stopped")
+	say("Space") // i18n:context=keyboard
+	say("Other")
+	say(
+		// i18n: above a multi-line argument
+		"Argument")
+}
+`
+	fset := token.NewFileSet()
+	astFile, err := parser.ParseFile(fset, "app.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type annotation struct {
+		notes   []string
+		context string
+	}
+	fileAnnotations := NewAnnotations(fset, astFile)
+	annotations := make(map[string]annotation)
+	ast.Inspect(astFile, func(n ast.Node) bool {
+		if basicLit, ok := n.(*ast.BasicLit); ok {
+			value, _ := strconv.Unquote(basicLit.Value)
+			notes, context := fileAnnotations.Find(basicLit.Pos())
+			annotations[value] = annotation{notes, context}
+		}
+		return true
+	})
+
+	expected := map[string]annotation{
+		"Stopped":  {[]string{"the state of an app"}, "app state"},
+		"Space":    {nil, "keyboard"},
+		"Other":    {nil, ""},
+		"Argument": {[]string{"above a multi-line argument"}, ""},
+	}
+	if !reflect.DeepEqual(annotations, expected) {
+		t.Errorf("Find = %v, expected %v", annotations, expected)
+	}
+}
+
+func TestStringKey(t *testing.T) {
+	if StringKey("Stopped", "") != "Stopped" {
+		t.Errorf("the key of a string without context is not the string")
+	}
+
+	if StringKey("Stopped", "button") == StringKey("Stopped", "app state") {
+		t.Errorf("the keys of a string in two contexts are the same")
+	}
+}
@@ -21,3 +21,43 @@ func ImportsForASTFile(astFile *ast.File) (*ast.GenDecl, error) {
 
 	return nil, errors.New(fmt.Sprintf("Could not find imports for root node:\n\t%#v\n", astFile))
 }
+
+// NewTCallExpr returns the T() call wrapping a string literal, e.g.,
+// T("Hello"), or i18n.T("Hello") with the i18n qualifier
+func NewTCallExpr(basicLit *ast.BasicLit, qualifier string) *ast.CallExpr {
+	var tFunc ast.Expr = &ast.Ident{Name: "T"}
+	if qualifier != "" {
+		tFunc = &ast.SelectorExpr{X: &ast.Ident{Name: qualifier}, Sel: &ast.Ident{Name: "T"}}
+	}
+
+	return &ast.CallExpr{Fun: tFunc, Args: []ast.Expr{basicLit}}
+}
+
+// IsTCallExpr returns whether a call is a call of one of the translate
+// funcs, DEFAULT_T_FUNCS when none, unqualified or with the qualifier
+func IsTCallExpr(callExpr *ast.CallExpr, qualifier string, tFuncs []string) bool {
+	var funName string
+	switch fun := callExpr.Fun.(type) {
+	case *ast.Ident:
+		funName = fun.Name
+	case *ast.SelectorExpr:
+		ident, ok := fun.X.(*ast.Ident)
+		if !ok || qualifier == "" || ident.Name != qualifier {
+			return false
+		}
+		funName = fun.Sel.Name
+	default:
+		return false
+	}
+
+	if len(tFuncs) == 0 {
+		tFuncs = DEFAULT_T_FUNCS
+	}
+	for _, tFunc := range tFuncs {
+		if funName == tFunc {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,153 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+const (
+	// CACHE_VERSION is part of the configuration hash, so changing how the
+	// strings are extracted invalidates the caches
+	CACHE_VERSION        = "1"
+	CACHE_INDEX_FILENAME = "index.json"
+)
+
+// CacheEntry is the extraction of a source file, the strings and the output
+// files written for them, for a hash of its content
+type CacheEntry struct {
+	Hash    string       `json:"hash"`
+	Strings []StringInfo `json:"strings"`
+	Outputs []string     `json:"outputs,omitempty"`
+}
+
+// Cache is the on-disk cache of the strings extracted from the source files,
+// keyed by their absolute path, for a hash of the extraction configuration,
+// it is safe for concurrent use
+type Cache struct {
+	ConfigHash string                `json:"configHash"`
+	Entries    map[string]CacheEntry `json:"entries"`
+
+	dirName string
+	mutex   sync.Mutex
+}
+
+// LoadCache loads the cache of a dir, the cache is empty when the dir has none
+// and its entries are invalid when it was saved for another configuration
+func LoadCache(dirName, configHash string) (*Cache, error) {
+	cache := &Cache{ConfigHash: configHash, Entries: make(map[string]CacheEntry), dirName: dirName}
+
+	content, err := os.ReadFile(filepath.Join(dirName, CACHE_INDEX_FILENAME))
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var savedCache Cache
+	if err := json.Unmarshal(content, &savedCache); err != nil {
+		// a corrupted cache is rebuilt
+		return cache, nil
+	}
+
+	for fileName, entry := range savedCache.Entries {
+		if savedCache.ConfigHash != configHash {
+			// keep the outputs to prune them, not the strings
+			entry = CacheEntry{Outputs: entry.Outputs}
+		}
+		cache.Entries[fileName] = entry
+	}
+
+	return cache, nil
+}
+
+// Save writes the index of the cache in its dir
+func (cache *Cache) Save() error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if err := os.MkdirAll(cache.dirName, 0755); err != nil {
+		return err
+	}
+
+	jsonData, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(cache.dirName, CACHE_INDEX_FILENAME), jsonData, 0644)
+}
+
+// Get returns the entry of a file when its content has the hash, a nil
+// cache has no entries
+func (cache *Cache) Get(fileName, hash string) (CacheEntry, bool) {
+	if cache == nil {
+		return CacheEntry{}, false
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry, ok := cache.Entries[fileName]
+	if !ok || entry.Hash == "" || entry.Hash != hash {
+		return CacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+// Put sets the entry of a file and returns the outputs of its previous entry
+// that are not outputs anymore
+func (cache *Cache) Put(fileName string, entry CacheEntry) []string {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	var staleOutputs []string
+	for _, output := range cache.Entries[fileName].Outputs {
+		if !containsString(entry.Outputs, output) {
+			staleOutputs = append(staleOutputs, output)
+		}
+	}
+
+	cache.Entries[fileName] = entry
+
+	return staleOutputs
+}
+
+// Prune removes the entries of the deleted files and returns their outputs
+func (cache *Cache) Prune() []string {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	var staleOutputs []string
+	for fileName, entry := range cache.Entries {
+		if _, err := os.Stat(fileName); os.IsNotExist(err) {
+			staleOutputs = append(staleOutputs, entry.Outputs...)
+			delete(cache.Entries, fileName)
+		}
+	}
+	sort.Strings(staleOutputs)
+
+	return staleOutputs
+}
+
+// HashContent returns the hex SHA-256 of a content
+func HashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// HashConfig returns the hash of a configuration marshalled in JSON
+func HashConfig(config interface{}) (string, error) {
+	jsonData, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+
+	return HashContent(append([]byte(CACHE_VERSION), jsonData...)), nil
+}
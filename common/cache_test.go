@@ -0,0 +1,83 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	dirName := t.TempDir()
+	sourceFilename := filepath.Join(dirName, "app.go")
+	if err := os.WriteFile(sourceFilename, []byte("package app"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	deletedFilename := filepath.Join(dirName, "deleted.go")
+
+	cache, err := LoadCache(filepath.Join(dirName, ".i18n4go-cache"), "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := HashContent([]byte("package app"))
+	cache.Put(sourceFilename, CacheEntry{Hash: hash, Strings: []StringInfo{{Value: "World", Offset: 20}, {Value: "Hello", Offset: 10}}, Outputs: []string{"app.go.en.json", "app.go.en.po"}})
+	cache.Put(deletedFilename, CacheEntry{Hash: hash, Outputs: []string{"deleted.go.en.json"}})
+	if err := cache.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err = LoadCache(filepath.Join(dirName, ".i18n4go-cache"), "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, ok := cache.Get(sourceFilename, hash)
+	if !ok {
+		t.Fatalf("the entry of %s is not cached", sourceFilename)
+	}
+	if len(entry.Strings) != 2 {
+		t.Errorf("the strings of the entry are not cached: %v", entry.Strings)
+	}
+	if _, ok := cache.Get(sourceFilename, HashContent([]byte("package changed"))); ok {
+		t.Errorf("the entry of a changed file is cached")
+	}
+
+	staleOutputs := cache.Put(sourceFilename, CacheEntry{Hash: hash, Outputs: []string{"app.go.en.json"}})
+	if !reflect.DeepEqual(staleOutputs, []string{"app.go.en.po"}) {
+		t.Errorf("Put = %v, expected the output not written again", staleOutputs)
+	}
+
+	staleOutputs = cache.Prune()
+	if !reflect.DeepEqual(staleOutputs, []string{"deleted.go.en.json"}) {
+		t.Errorf("Prune = %v, expected the outputs of the deleted file", staleOutputs)
+	}
+	if _, ok := cache.Entries[deletedFilename]; ok {
+		t.Errorf("the entry of the deleted file was not pruned")
+	}
+}
+
+func TestCacheOfAnotherConfig(t *testing.T) {
+	dirName := t.TempDir()
+	hash := HashContent([]byte("package app"))
+
+	cache, err := LoadCache(dirName, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.Put("app.go", CacheEntry{Hash: hash, Outputs: []string{"app.go.en.json"}})
+	if err := cache.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err = LoadCache(dirName, "other config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get("app.go", hash); ok {
+		t.Errorf("the entry of another configuration is cached")
+	}
+	if !reflect.DeepEqual(cache.Entries["app.go"].Outputs, []string{"app.go.en.json"}) {
+		t.Errorf("the outputs of the entry of another configuration are not kept to be pruned")
+	}
+}
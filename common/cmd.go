@@ -3,6 +3,10 @@ package common
 type Options struct {
 	CommandFlag string
 
+	ConfigFilenameFlag string
+	// Config is the project configuration file, if any
+	Config Config
+
 	HelpFlag     bool
 	LongHelpFlag bool
 
@@ -11,9 +15,19 @@ type Options struct {
 	PoFlag      bool
 	MetaFlag    bool
 
-	SourceLanguageFlag        string
+	SourceLanguageFlag string
+	// SourceLanguageSet is whether --source-language or the configuration
+	// file set the source language, see SourceLocale
+	SourceLanguageSet bool
+
 	LanguagesFlag             string
 	GoogleTranslateApiKeyFlag string
+	TranslatorFlag            string
+	TranslatorConfigFlag      string
+	TranslateBatchSizeFlag    int
+	TranslateConcurrencyFlag  int
+	TranslateMaxRetriesFlag   int
+	TranslateRateLimitFlag    float64
 
 	OutputDirFlag          string
 	OutputMatchImportFlag  bool
@@ -28,6 +42,12 @@ type Options struct {
 
 	RecurseFlag bool
 
+	SinkFuncsFlag string
+
+	CacheDirFlag           string
+	SinceFlag              string
+	ExtractConcurrencyFlag int
+
 	IgnoreRegexpFlag string
 
 	LanguageFilesFlag string
@@ -38,14 +58,31 @@ type Options struct {
 	RootPathFlag string
 
 	InitCodeSnippetFilenameFlag string
+	InitEmbedDirnameFlag        string
+	AccessorsFilenameFlag       string
+	TemplatesFlag               bool
+
+	MissingUsageFilenameFlag string
+
+	FormatFlag string
 
 	QualifierFlag string
-}
+	TFuncsFlag    string
+
+	NonInteractiveFlag  bool
+	RenameThresholdFlag float64
+	RenameFilenameFlag  string
+	ReportFilenameFlag  string
+
+	XliffVersionFlag string
+	XliffFilesFlag   string
 
-type I18nStringInfo struct {
-	ID          string `json:"id"`
-	Translation string `json:"translation"`
-	Modified    bool   `json:"modified"`
+	PseudoExpansionFlag   int
+	PseudoRightToLeftFlag bool
+
+	TmFilenameFlag       string
+	TmxFilenameFlag      string
+	TmFuzzyThresholdFlag float64
 }
 
 type StringInfo struct {
@@ -57,14 +94,18 @@ type StringInfo struct {
 
 	// optional, empty means "all locales"
 	Locales []string `json:"locales,omitempty"`
+
+	// optional, from the // i18n: <note> and // i18n:context=<ctx> comments
+	Comments []string `json:"comments,omitempty"`
+	Context  string   `json:"context,omitempty"`
 }
 
 type ExcludedStrings struct {
-	ExcludedStrings     []string `json:"excludedStrings"`
-	ExcludedLines       []string `json:"excludedLines"`
-	ExcludedRegexps     []string `json:"excludedRegexps"`
-	ExcludedFileRegexps []string `json:"excludedFileRegexps"`
-	EnforcedFuncs       []string `json:"enforcedFuncs"`
+	ExcludedStrings     []string `json:"excludedStrings" yaml:"excludedStrings"`
+	ExcludedLines       []string `json:"excludedLines" yaml:"excludedLines"`
+	ExcludedRegexps     []string `json:"excludedRegexps" yaml:"excludedRegexps"`
+	ExcludedFileRegexps []string `json:"excludedFileRegexps" yaml:"excludedFileRegexps"`
+	EnforcedFuncs       []string `json:"enforcedFuncs" yaml:"enforcedFuncs"`
 }
 
 type PrinterInterface interface {
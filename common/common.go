@@ -20,6 +20,7 @@ import (
 const (
 	TEMPLATED_STRING_REGEXP    = `\{\{\.[[:alnum:][:punct:][:print:]]+?\}\}`
 	INTERPOLATED_STRING_REGEXP = `%(?:[#v]|[%EGUTXbcdefgopqstvx])`
+	PLACEHOLDER_REGEXP         = TEMPLATED_STRING_REGEXP + "|" + INTERPOLATED_STRING_REGEXP
 )
 
 var templatedStringRegexp, interpolatedStringRegexp *regexp.Regexp
@@ -124,11 +125,17 @@ func SaveStrings(printer PrinterInterface, options Options, stringInfos map[stri
 		}
 	}
 
-	i18nStringInfos := make([]I18nStringInfo, len(stringInfos))
-	i := 0
-	for _, stringInfo := range stringInfos {
-		i18nStringInfos[i] = I18nStringInfo{ID: stringInfo.Value, Translation: stringInfo.Value}
-		i++
+	// the translation files have no context, a string in several contexts is
+	// saved once and its contexts are only kept in the .po files
+	i18nStringInfos := make([]I18nStringInfo, 0, len(stringInfos))
+	ids := make(map[string]bool)
+	for _, stringInfo := range SortedStringInfos(stringInfos) {
+		if ids[stringInfo.Value] {
+			printer.Println("i18n4go: WARNING string in several contexts saved once, the contexts are only kept in the .po file:", stringInfo.Value)
+			continue
+		}
+		ids[stringInfo.Value] = true
+		i18nStringInfos = append(i18nStringInfos, I18nStringInfo{ID: stringInfo.Value, Translation: stringInfo.Value})
 	}
 
 	jsonData, err := json.MarshalIndent(i18nStringInfos, "", "   ")
@@ -158,6 +165,24 @@ func SaveStrings(printer PrinterInterface, options Options, stringInfos map[stri
 	return nil
 }
 
+// SortedStringInfos returns the extracted strings in the order of the source
+// file, the same string in several contexts by key, so that the outputs do
+// not change between runs
+func SortedStringInfos(stringInfos map[string]StringInfo) []StringInfo {
+	sortedStringInfos := make([]StringInfo, 0, len(stringInfos))
+	for _, stringInfo := range stringInfos {
+		sortedStringInfos = append(sortedStringInfos, stringInfo)
+	}
+	sort.Slice(sortedStringInfos, func(i, j int) bool {
+		if sortedStringInfos[i].Offset != sortedStringInfos[j].Offset {
+			return sortedStringInfos[i].Offset < sortedStringInfos[j].Offset
+		}
+		return sortedStringInfos[i].Key() < sortedStringInfos[j].Key()
+	})
+
+	return sortedStringInfos
+}
+
 func SaveStringsInPo(printer PrinterInterface, options Options, stringInfos map[string]StringInfo, outputDirname string, fileName string) error {
 	if len(stringInfos) != 0 {
 		printer.Println("Creating and saving i18n strings to .po file:", fileName)
@@ -170,42 +195,54 @@ func SaveStringsInPo(printer PrinterInterface, options Options, stringInfos map[
 			return err
 		}
 
-		file, err := os.Create(filepath.Join(outputDirname, fileName[strings.LastIndex(fileName, string(os.PathSeparator))+1:len(fileName)]))
-		defer file.Close()
+		sortedStringInfos := SortedStringInfos(stringInfos)
+
+		sourceFilename := strings.Split(fileName, ".en.po")[0]
+		po := NewPoFile("en")
+		for _, stringInfo := range sortedStringInfos {
+			entry := &PoEntry{
+				ExtractedComments: stringInfo.Comments,
+				References:        []string{sourceFilename + ":" + strconv.Itoa(stringInfo.Line)},
+				Context:           stringInfo.Context,
+				ID:                stringInfo.Value,
+				Translation:       stringInfo.Value,
+			}
+			if IsInterpolatedString(stringInfo.Value) {
+				entry.AddFlag(PO_FLAG_GO_FORMAT)
+			}
+			po.Entries = append(po.Entries, entry)
+		}
+
+		err = po.Save(filepath.Join(outputDirname, fileName[strings.LastIndex(fileName, string(os.PathSeparator))+1:len(fileName)]))
 		if err != nil {
 			printer.Println(err)
 			return err
 		}
-
-		for _, stringInfo := range stringInfos {
-			file.Write([]byte("# filename: " + strings.Split(fileName, ".en.po")[0] +
-				", offset: " + strconv.Itoa(stringInfo.Offset) +
-				", line: " + strconv.Itoa(stringInfo.Line) +
-				", column: " + strconv.Itoa(stringInfo.Column) + "\n"))
-			file.Write([]byte("msgid " + strconv.Quote(stringInfo.Value) + "\n"))
-			file.Write([]byte("msgstr " + strconv.Quote(stringInfo.Value) + "\n"))
-			file.Write([]byte("\n"))
-		}
 	}
 	return nil
 }
 
-func SaveI18nStringsInPo(printer PrinterInterface, options Options, i18nStrings []I18nStringInfo, fileName string) error {
+func SaveI18nStringsInPo(printer PrinterInterface, options Options, i18nStrings []I18nStringInfo, language string, fileName string) error {
 	printer.Println("i18n4go: creating and saving i18n strings to .po file:", fileName)
 
 	if !options.DryRunFlag && len(i18nStrings) != 0 {
-		file, err := os.Create(fileName)
-		defer file.Close()
+		po := NewPoFile(language)
+		for _, stringInfo := range i18nStrings {
+			entry := &PoEntry{ID: stringInfo.ID, Translation: stringInfo.Translation}
+			if stringInfo.Modified {
+				entry.AddFlag(PO_FLAG_FUZZY)
+			}
+			if IsInterpolatedString(stringInfo.ID) {
+				entry.AddFlag(PO_FLAG_GO_FORMAT)
+			}
+			po.Entries = append(po.Entries, entry)
+		}
+
+		err := po.Save(fileName)
 		if err != nil {
 			printer.Println(err)
 			return err
 		}
-
-		for _, stringInfo := range i18nStrings {
-			file.Write([]byte("msgid " + strconv.Quote(stringInfo.ID) + "\n"))
-			file.Write([]byte("msgstr " + strconv.Quote(stringInfo.Translation) + "\n"))
-			file.Write([]byte("\n"))
-		}
 	}
 	return nil
 }
@@ -214,9 +251,13 @@ func SaveI18nStringInfos(printer PrinterInterface, options Options, i18nStringIn
 	var jsonData []byte
 	var err error
 	if options.OutputFormatFlatFlag {
-		strs := map[string]string{}
+		strs := map[string]json.RawMessage{}
 		for _, i := range i18nStringInfos {
-			strs[i.ID] = i.Translation
+			strs[i.ID], err = i.translationJSON()
+			if err != nil {
+				printer.Println(err)
+				return err
+			}
 		}
 		jsonData, err = json.MarshalIndent(strs, "", "   ")
 	} else {
@@ -257,16 +298,17 @@ func LoadI18nStringInfos(fileName string) ([]I18nStringInfo, error) {
 		}
 	} else {
 		// flat file
-		var mp map[string]string
+		var mp map[string]json.RawMessage
 		err = json.Unmarshal(content, &mp)
 		if err != nil {
 			return nil, err
 		}
 		for k, v := range mp {
-			i18nStringInfos = append(i18nStringInfos, I18nStringInfo{
-				ID:          k,
-				Translation: v,
-			})
+			i18nStringInfo, err := newI18nStringInfo(k, v)
+			if err != nil {
+				return nil, err
+			}
+			i18nStringInfos = append(i18nStringInfos, i18nStringInfo)
 		}
 		sort.Slice(i18nStringInfos, func(i, j int) bool { return i18nStringInfos[i].ID < i18nStringInfos[j].ID })
 	}
@@ -274,6 +316,45 @@ func LoadI18nStringInfos(fileName string) ([]I18nStringInfo, error) {
 	return i18nStringInfos, nil
 }
 
+// LoadExtractedStringInfos returns the positions of the strings, by value,
+// in the *.extracted.json files created by extract-strings --meta in a
+// directory and its sub directories, sorted by file name and line
+func LoadExtractedStringInfos(dirName string) (map[string][]StringInfo, error) {
+	stringInfosByValue := make(map[string][]StringInfo)
+	err := filepath.WalkDir(dirName, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".extracted.json") {
+			return err
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var stringInfos []StringInfo
+		err = json.Unmarshal(content, &stringInfos)
+		if err != nil {
+			return fmt.Errorf("i18n4go: could not parse %s: %s", path, err.Error())
+		}
+
+		for _, stringInfo := range stringInfos {
+			stringInfosByValue[stringInfo.Value] = append(stringInfosByValue[stringInfo.Value], stringInfo)
+		}
+		return nil
+	})
+
+	for _, stringInfos := range stringInfosByValue {
+		sort.Slice(stringInfos, func(i, j int) bool {
+			if stringInfos[i].Filename != stringInfos[j].Filename {
+				return stringInfos[i].Filename < stringInfos[j].Filename
+			}
+			return stringInfos[i].Line < stringInfos[j].Line
+		})
+	}
+
+	return stringInfosByValue, err
+}
+
 func CreateI18nStringInfoMap(i18nStringInfos []I18nStringInfo) (map[string]I18nStringInfo, error) {
 	inputMap := make(map[string]I18nStringInfo, len(i18nStringInfos))
 
@@ -318,6 +399,15 @@ func GetTemplatedStringArgs(aString string) []string {
 	return stringMatches
 }
 
+// GetPlaceholders returns the templated and interpolated placeholders of a
+// string sorted, e.g., [%d {{.Name}}] for "{{.Name}} has %d apps"
+func GetPlaceholders(aString string) []string {
+	placeholders := placeholderRegexp.FindAllString(aString, -1)
+	sort.Strings(placeholders)
+
+	return placeholders
+}
+
 func IsTemplatedString(aString string) bool {
 	re, err := getTemplatedStringRegexp()
 	if err != nil {
@@ -0,0 +1,209 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// CONFIG_FILENAMES are the names of the project configuration file, looked
+// up from the working directory to the root
+var CONFIG_FILENAMES = []string{".i18n4go.yaml", ".i18n4go.yml", ".i18n4go.json"}
+
+// DEFAULT_SOURCE_LANGUAGE is the default of --source-language
+const DEFAULT_SOURCE_LANGUAGE = "en"
+
+// DEFAULT_SOURCE_LOCALE is the source locale of checkup and fixup when
+// neither --source-language nor the configuration file set one
+const DEFAULT_SOURCE_LOCALE = "en_US"
+
+// DEFAULT_T_FUNCS are the names of the translate funcs found in the code
+var DEFAULT_T_FUNCS = []string{"T", "t"}
+
+const (
+	OUTPUT_LAYOUT_FLAT          = "flat"
+	OUTPUT_LAYOUT_MATCH_PACKAGE = "match-package"
+	OUTPUT_LAYOUT_MATCH_IMPORT  = "match-import"
+)
+
+// Config is the project configuration file, its settings are the defaults of
+// the flags of all the commands, its paths are relative to its directory
+type Config struct {
+	SourceLanguage string   `yaml:"sourceLanguage" json:"sourceLanguage"`
+	Languages      []string `yaml:"languages" json:"languages"`
+
+	// SourcesDir has the Go files, ResourcesDir the translation files
+	SourcesDir   string `yaml:"sourcesDir" json:"sourcesDir"`
+	ResourcesDir string `yaml:"resourcesDir" json:"resourcesDir"`
+
+	// OutputLayout is one of flat, match-package or match-import
+	OutputDir    string `yaml:"outputDir" json:"outputDir"`
+	OutputLayout string `yaml:"outputLayout" json:"outputLayout"`
+
+	ExcludedFilename  string          `yaml:"excludedFilename" json:"excludedFilename"`
+	Excluded          ExcludedStrings `yaml:"excluded" json:"excluded"`
+	SubstringFilename string          `yaml:"substringFilename" json:"substringFilename"`
+	IgnoreRegexp      string          `yaml:"ignoreRegexp" json:"ignoreRegexp"`
+	SinkFuncs         []string        `yaml:"sinkFuncs" json:"sinkFuncs"`
+	CacheDir          string          `yaml:"cacheDir" json:"cacheDir"`
+
+	TFuncs    []string `yaml:"tFuncs" json:"tFuncs"`
+	Qualifier string   `yaml:"qualifier" json:"qualifier"`
+}
+
+// FindConfigFile returns the configuration file in the directory or its
+// closest parent, the file name is empty when there is none
+func FindConfigFile(dirName string) (string, error) {
+	dirName, err := filepath.Abs(dirName)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		for _, configFilename := range CONFIG_FILENAMES {
+			fileName := filepath.Join(dirName, configFilename)
+			if _, err := os.Stat(fileName); err == nil {
+				return fileName, nil
+			}
+		}
+
+		parentDirName := filepath.Dir(dirName)
+		if parentDirName == dirName {
+			return "", nil
+		}
+		dirName = parentDirName
+	}
+}
+
+// LoadConfig loads a YAML or JSON configuration file, its paths are made
+// relative to the working directory
+func LoadConfig(fileName string) (Config, error) {
+	var config Config
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return config, err
+	}
+
+	if strings.HasSuffix(fileName, ".json") {
+		err = json.Unmarshal(content, &config)
+	} else {
+		err = yaml.UnmarshalStrict(content, &config)
+	}
+	if err != nil {
+		return config, fmt.Errorf("i18n4go: could not parse the configuration file %s: %s", fileName, err.Error())
+	}
+
+	switch config.OutputLayout {
+	case "", OUTPUT_LAYOUT_FLAT, OUTPUT_LAYOUT_MATCH_PACKAGE, OUTPUT_LAYOUT_MATCH_IMPORT:
+	default:
+		return config, fmt.Errorf("i18n4go: unknown outputLayout %q in the configuration file %s", config.OutputLayout, fileName)
+	}
+
+	dirName := filepath.Dir(fileName)
+	for _, path := range []*string{&config.SourcesDir, &config.ResourcesDir, &config.OutputDir, &config.ExcludedFilename, &config.SubstringFilename, &config.CacheDir} {
+		*path = configPath(dirName, *path)
+	}
+
+	return config, nil
+}
+
+// configPath returns a path of the configuration file relative to the
+// working directory, or absolute
+func configPath(dirName, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	path, err := filepath.Abs(filepath.Join(dirName, path))
+	if err != nil {
+		return path
+	}
+
+	if wd, err := os.Getwd(); err == nil {
+		if relPath, err := filepath.Rel(wd, path); err == nil {
+			return relPath
+		}
+	}
+
+	return path
+}
+
+// Apply sets the options of the flags that are not set, the flags are named
+// like with flag.Visit, e.g., source-language
+func (config Config) Apply(options *Options, setFlags map[string]bool) {
+	options.Config = config
+
+	applyString := func(flag string, option *string, value string) {
+		if !setFlags[flag] && value != "" {
+			*option = value
+		}
+	}
+
+	applyString("source-language", &options.SourceLanguageFlag, config.SourceLanguage)
+	options.SourceLanguageSet = setFlags["source-language"] || config.SourceLanguage != ""
+	applyString("languages", &options.LanguagesFlag, strings.Join(config.Languages, ","))
+	applyString("o", &options.OutputDirFlag, config.OutputDir)
+	applyString("e", &options.ExcludedFilenameFlag, config.ExcludedFilename)
+	applyString("s", &options.SubstringFilenameFlag, config.SubstringFilename)
+	applyString("ignore-regexp", &options.IgnoreRegexpFlag, config.IgnoreRegexp)
+	applyString("sink-funcs", &options.SinkFuncsFlag, strings.Join(config.SinkFuncs, ","))
+	applyString("cache-dir", &options.CacheDirFlag, config.CacheDir)
+	applyString("t-funcs", &options.TFuncsFlag, strings.Join(config.TFuncs, ","))
+	applyString("q", &options.QualifierFlag, config.Qualifier)
+
+	if config.OutputLayout != "" && !setFlags["output-flat"] && !setFlags["output-match-package"] && !setFlags["output-match-import"] {
+		options.OutputFlatFlag = config.OutputLayout == OUTPUT_LAYOUT_FLAT
+		options.OutputMatchPackageFlag = config.OutputLayout == OUTPUT_LAYOUT_MATCH_PACKAGE
+		options.OutputMatchImportFlag = config.OutputLayout == OUTPUT_LAYOUT_MATCH_IMPORT
+	}
+}
+
+// SourceLocale returns the locale of the source translation files of checkup
+// and fixup, e.g., en_US for en_US.all.json, the default source language en
+// becomes their default locale en_US unless it was set explicitly
+func (options Options) SourceLocale() string {
+	if options.SourceLanguageSet && options.SourceLanguageFlag != "" {
+		return options.SourceLanguageFlag
+	}
+
+	return DEFAULT_SOURCE_LOCALE
+}
+
+// SourcesDir returns the directory of the Go files of checkup and fixup
+func (options Options) SourcesDir() string {
+	if options.DirnameFlag != "" {
+		return options.DirnameFlag
+	}
+	if options.Config.SourcesDir != "" {
+		return options.Config.SourcesDir
+	}
+
+	return "."
+}
+
+// ResourcesDir returns the directory of the translation files of checkup
+// and fixup
+func (options Options) ResourcesDir() string {
+	if options.I18nStringsDirnameFlag != "" {
+		return options.I18nStringsDirnameFlag
+	}
+	if options.Config.ResourcesDir != "" {
+		return options.Config.ResourcesDir
+	}
+
+	return "."
+}
+
+// TFuncs returns the names of the translate funcs
+func (options Options) TFuncs() []string {
+	if options.TFuncsFlag != "" {
+		return ParseStringList(options.TFuncsFlag, ",")
+	}
+
+	return DEFAULT_T_FUNCS
+}
@@ -0,0 +1,131 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindConfigFile(t *testing.T) {
+	dirName := t.TempDir()
+	subDirName := filepath.Join(dirName, "cmd", "app")
+	if err := os.MkdirAll(subDirName, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	fileName, err := FindConfigFile(subDirName)
+	if err != nil || fileName != "" {
+		t.Errorf("FindConfigFile without a configuration file = %q, %v", fileName, err)
+	}
+
+	configFilename := filepath.Join(dirName, ".i18n4go.json")
+	if err := os.WriteFile(configFilename, []byte(`{"sourceLanguage": "en_GB"}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	fileName, err = FindConfigFile(subDirName)
+	if err != nil || fileName != configFilename {
+		t.Errorf("FindConfigFile = %q, %v, expected %q", fileName, err, configFilename)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dirName := t.TempDir()
+	configFilename := filepath.Join(dirName, ".i18n4go.yaml")
+	content := `
+sourceLanguage: en_GB
+languages: [fr_FR, de_DE]
+resourcesDir: i18n/resources
+outputLayout: match-package
+excludedFilename: /etc/i18n4go/excluded.json
+excluded:
+  excludedStrings: ["OK"]
+tFuncs: [T, Tr]
+`
+	if err := os.WriteFile(configFilename, []byte(content), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(configFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Config{
+		SourceLanguage:   "en_GB",
+		Languages:        []string{"fr_FR", "de_DE"},
+		ResourcesDir:     configPath(dirName, "i18n/resources"),
+		OutputLayout:     OUTPUT_LAYOUT_MATCH_PACKAGE,
+		ExcludedFilename: "/etc/i18n4go/excluded.json",
+		Excluded:         ExcludedStrings{ExcludedStrings: []string{"OK"}},
+		TFuncs:           []string{"T", "Tr"},
+	}
+	if !reflect.DeepEqual(config, expected) {
+		t.Errorf("LoadConfig = %+v, expected %+v", config, expected)
+	}
+
+	resourcesDir, _ := filepath.Abs(config.ResourcesDir)
+	if resourcesDir != filepath.Join(dirName, "i18n", "resources") {
+		t.Errorf("the resources dir %s is not relative to the configuration file", config.ResourcesDir)
+	}
+
+	for _, content := range []string{"outputLayout: nested\n", "sourceLanguages: en_GB\n"} {
+		if err := os.WriteFile(configFilename, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := LoadConfig(configFilename); err == nil {
+			t.Errorf("LoadConfig of %q did not fail", content)
+		}
+	}
+}
+
+func TestConfigApply(t *testing.T) {
+	config := Config{
+		SourceLanguage: "en_GB",
+		Languages:      []string{"fr_FR", "de_DE"},
+		OutputLayout:   OUTPUT_LAYOUT_MATCH_IMPORT,
+		Qualifier:      "i18n",
+	}
+
+	options := Options{SourceLanguageFlag: "en", QualifierFlag: "", OutputFlatFlag: true}
+	config.Apply(&options, map[string]bool{"q": true})
+
+	if options.SourceLanguageFlag != "en_GB" || options.LanguagesFlag != "fr_FR,de_DE" {
+		t.Errorf("the languages of the configuration were not applied: %+v", options)
+	}
+	if options.OutputFlatFlag || !options.OutputMatchImportFlag {
+		t.Errorf("the output layout of the configuration was not applied: %+v", options)
+	}
+	if options.QualifierFlag != "" {
+		t.Errorf("the -q flag was overridden by the configuration: %q", options.QualifierFlag)
+	}
+	if options.SourceLocale() != "en_GB" || options.SourcesDir() != "." {
+		t.Errorf("SourceLocale() = %q, SourcesDir() = %q", options.SourceLocale(), options.SourcesDir())
+	}
+}
+
+func TestOptionsDirs(t *testing.T) {
+	options := Options{Config: Config{SourcesDir: "src", ResourcesDir: "i18n"}}
+	if options.SourceLocale() != DEFAULT_SOURCE_LOCALE || options.SourcesDir() != "src" || options.ResourcesDir() != "i18n" {
+		t.Errorf("SourceLocale() = %q, SourcesDir() = %q, ResourcesDir() = %q", options.SourceLocale(), options.SourcesDir(), options.ResourcesDir())
+	}
+
+	options.SourceLanguageFlag = DEFAULT_SOURCE_LANGUAGE
+	if options.SourceLocale() != DEFAULT_SOURCE_LOCALE {
+		t.Errorf("SourceLocale() = %q, expected %s for the default source language", options.SourceLocale(), DEFAULT_SOURCE_LOCALE)
+	}
+
+	options.SourceLanguageSet = true
+	if options.SourceLocale() != DEFAULT_SOURCE_LANGUAGE {
+		t.Errorf("SourceLocale() = %q, expected %s for an explicit source language", options.SourceLocale(), DEFAULT_SOURCE_LANGUAGE)
+	}
+
+	options.SourceLanguageFlag = "de_DE"
+	options.DirnameFlag = "cmd"
+	options.I18nStringsDirnameFlag = "resources"
+	if options.SourceLocale() != "de_DE" || options.SourcesDir() != "cmd" || options.ResourcesDir() != "resources" {
+		t.Errorf("the flags did not override the configuration: SourceLocale() = %q, SourcesDir() = %q, ResourcesDir() = %q", options.SourceLocale(), options.SourcesDir(), options.ResourcesDir())
+	}
+}
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"go/ast"
+	"go/token"
+	"math"
+	"strings"
+)
+
+const (
+	IGNORE_DIRECTIVE       = "i18n4go:ignore"
+	IGNORE_START_DIRECTIVE = "i18n4go:ignore-start"
+	IGNORE_END_DIRECTIVE   = "i18n4go:ignore-end"
+	IGNORE_FILE_DIRECTIVE  = "i18n4go:ignore-file"
+)
+
+// Directives are the //i18n4go:ignore directives of a Go file:
+//
+//	//i18n4go:ignore        ignores the strings of the next line, or of its own line after some code
+//	//i18n4go:ignore-start  ignores the strings up to the next //i18n4go:ignore-end
+//	//i18n4go:ignore-file   ignores all the strings of the file
+type Directives struct {
+	fset          *token.FileSet
+	ignoreFile    bool
+	ignoredLines  map[int]bool
+	ignoredBlocks [][2]int
+}
+
+// NewDirectives returns the directives of a file parsed with its comments
+func NewDirectives(fset *token.FileSet, astFile *ast.File) *Directives {
+	directives := &Directives{fset: fset, ignoredLines: make(map[int]bool)}
+	if len(astFile.Comments) == 0 {
+		return directives
+	}
+
+	codeLines := firstCodePositions(fset, astFile)
+	blockStart := 0
+	for _, commentGroup := range astFile.Comments {
+		for _, comment := range commentGroup.List {
+			fields := strings.Fields(commentText(comment.Text))
+			if len(fields) == 0 {
+				continue
+			}
+
+			line := fset.Position(comment.Pos()).Line
+			switch fields[0] {
+			case IGNORE_DIRECTIVE:
+				if first, ok := codeLines[line]; ok && first < comment.Pos() {
+					directives.ignoredLines[line] = true
+				} else {
+					directives.ignoredLines[line+1] = true
+				}
+			case IGNORE_START_DIRECTIVE:
+				if blockStart == 0 {
+					blockStart = line
+				}
+			case IGNORE_END_DIRECTIVE:
+				if blockStart != 0 {
+					directives.ignoredBlocks = append(directives.ignoredBlocks, [2]int{blockStart, line})
+					blockStart = 0
+				}
+			case IGNORE_FILE_DIRECTIVE:
+				directives.ignoreFile = true
+			}
+		}
+	}
+
+	// an unterminated block ignores the rest of the file
+	if blockStart != 0 {
+		directives.ignoredBlocks = append(directives.ignoredBlocks, [2]int{blockStart, math.MaxInt})
+	}
+
+	return directives
+}
+
+// IsFileIgnored returns true when the file has an //i18n4go:ignore-file directive
+func (directives *Directives) IsFileIgnored() bool {
+	return directives != nil && directives.ignoreFile
+}
+
+// IsIgnored returns true when the string at pos must not be extracted nor rewritten
+func (directives *Directives) IsIgnored(pos token.Pos) bool {
+	if directives == nil || !pos.IsValid() {
+		return false
+	}
+	if directives.ignoreFile {
+		return true
+	}
+
+	line := directives.fset.Position(pos).Line
+	if directives.ignoredLines[line] {
+		return true
+	}
+
+	for _, block := range directives.ignoredBlocks {
+		if block[0] <= line && line <= block[1] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestDirectives(t *testing.T) {
+	src := `package app
+
+func f() {
+	//i18n4go:ignore
+	say("Next line")
+	say("Extracted")
+	say("Same line") //i18n4go:ignore not user facing
+	say("After same line")
+	//i18n4go:ignore-start
+	say("Block")
+	say(
+		"Multi-line block")
+	//i18n4go:ignore-end
+	say("After block")
+}
+`
+	fset := token.NewFileSet()
+	astFile, err := parser.ParseFile(fset, "app.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	directives := NewDirectives(fset, astFile)
+	if directives.IsFileIgnored() {
+		t.Errorf("the file is ignored without an %s directive", IGNORE_FILE_DIRECTIVE)
+	}
+
+	ignored := make(map[string]bool)
+	ast.Inspect(astFile, func(n ast.Node) bool {
+		if basicLit, ok := n.(*ast.BasicLit); ok {
+			value, _ := strconv.Unquote(basicLit.Value)
+			ignored[value] = directives.IsIgnored(basicLit.Pos())
+		}
+		return true
+	})
+
+	expected := map[string]bool{
+		"Next line":        true,
+		"Extracted":        false,
+		"Same line":        true,
+		"After same line":  false,
+		"Block":            true,
+		"Multi-line block": true,
+		"After block":      false,
+	}
+	if !reflect.DeepEqual(ignored, expected) {
+		t.Errorf("IsIgnored = %v, expected %v", ignored, expected)
+	}
+}
+
+func TestIgnoreFileDirective(t *testing.T) {
+	src := `//i18n4go:ignore-file
+
+package app
+
+var s = "Ignored"
+`
+	fset := token.NewFileSet()
+	astFile, err := parser.ParseFile(fset, "app.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	directives := NewDirectives(fset, astFile)
+	if !directives.IsFileIgnored() {
+		t.Errorf("the file is not ignored with an %s directive", IGNORE_FILE_DIRECTIVE)
+	}
+
+	if (*Directives)(nil).IsIgnored(astFile.Pos()) {
+		t.Errorf("no directives ignore a string")
+	}
+}
@@ -0,0 +1,313 @@
+package common
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	FORMAT_TEXT  = "text"
+	FORMAT_JSON  = "json"
+	FORMAT_SARIF = "sarif"
+	FORMAT_JUNIT = "junit"
+
+	SEVERITY_ERROR   = "error"
+	SEVERITY_WARNING = "warning"
+
+	SARIF_VERSION = "2.1.0"
+	SARIF_SCHEMA  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	I18N4GO_TOOL_NAME       = "i18n4go"
+	I18N4GO_INFORMATION_URI = "https://github.com/EverlongProject/i18n4go"
+)
+
+// the rule IDs of the findings
+const (
+	RULE_MISSING_STRING          = "missing-string"
+	RULE_ADDITIONAL_STRING       = "additional-string"
+	RULE_MISSING_AT_RUNTIME      = "missing-at-runtime"
+	RULE_MISSING_TRANSLATION     = "missing-translation"
+	RULE_EXTRA_TRANSLATION       = "extra-translation"
+	RULE_INVALID_TEMPLATE        = "invalid-template"
+	RULE_INVALID_PLURAL          = "invalid-plural"
+	RULE_EXTRA_PLURAL_CATEGORIES = "extra-plural-categories"
+)
+
+// RULE_DESCRIPTIONS describe the rules in the SARIF files
+var RULE_DESCRIPTIONS = map[string]string{
+	RULE_MISSING_STRING:          "A string translated in the code is not in the translation file",
+	RULE_ADDITIONAL_STRING:       "A string of the translation file is not translated in the code",
+	RULE_MISSING_AT_RUNTIME:      "A string was missing from the translations at runtime",
+	RULE_MISSING_TRANSLATION:     "A string of the source translation file is not translated",
+	RULE_EXTRA_TRANSLATION:       "A translation has no string in the source translation file",
+	RULE_INVALID_TEMPLATE:        "A translation does not have the {{.Args}} of its string",
+	RULE_INVALID_PLURAL:          "A plural translation misses plural categories of its language",
+	RULE_EXTRA_PLURAL_CATEGORIES: "A plural translation has plural categories not used in its language",
+}
+
+// Finding is a problem found by verify-strings, checkup or
+// show-missing-strings, Filename and Line are the Go file of the string when
+// known, else the translation file
+type Finding struct {
+	RuleID   string `json:"ruleId"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Locale   string `json:"locale,omitempty"`
+	Key      string `json:"key"`
+	Filename string `json:"filename,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Fix      string `json:"suggestedFix,omitempty"`
+}
+
+// Location returns the file:line of the finding, or its file name without
+// a line
+func (finding Finding) Location() string {
+	if finding.Line == 0 {
+		return finding.Filename
+	}
+
+	return finding.Filename + ":" + strconv.Itoa(finding.Line)
+}
+
+// CheckFindingsFormat returns an error for an unknown -format
+func CheckFindingsFormat(format string) error {
+	switch format {
+	case "", FORMAT_TEXT, FORMAT_JSON, FORMAT_SARIF, FORMAT_JUNIT:
+		return nil
+	}
+
+	return fmt.Errorf("i18n4go: unknown format: %s, use %s, %s, %s or %s", format, FORMAT_TEXT, FORMAT_JSON, FORMAT_SARIF, FORMAT_JUNIT)
+}
+
+// IsTextFormat returns whether the findings are printed as text, as they are
+// found, instead of being written at the end
+func IsTextFormat(format string) bool {
+	return format == "" || format == FORMAT_TEXT
+}
+
+// WriteFindings writes the findings of a command in the JSON, SARIF or JUnit
+// format, the text format is printed by the commands
+func WriteFindings(writer io.Writer, format, command string, findings []Finding) error {
+	SortFindings(findings)
+
+	switch format {
+	case FORMAT_JSON:
+		if findings == nil {
+			findings = []Finding{}
+		}
+		return writeIndentedJSON(writer, findings)
+	case FORMAT_SARIF:
+		return writeIndentedJSON(writer, newSarifLog(findings))
+	case FORMAT_JUNIT:
+		return writeJUnit(writer, command, findings)
+	}
+
+	return nil
+}
+
+// SortFindings sorts the findings by file, line, locale, rule and key
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		switch {
+		case a.Filename != b.Filename:
+			return a.Filename < b.Filename
+		case a.Line != b.Line:
+			return a.Line < b.Line
+		case a.Locale != b.Locale:
+			return a.Locale < b.Locale
+		case a.RuleID != b.RuleID:
+			return a.RuleID < b.RuleID
+		}
+		return a.Key < b.Key
+	})
+}
+
+func writeIndentedJSON(writer io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "   ")
+	return encoder.Encode(v)
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func newSarifLog(findings []Finding) sarifLog {
+	results := []sarifResult{}
+	ruleIDs := make(map[string]bool)
+	for _, finding := range findings {
+		ruleIDs[finding.RuleID] = true
+
+		result := sarifResult{
+			RuleID:  finding.RuleID,
+			Level:   finding.Severity,
+			Message: sarifMessage{Text: finding.Message},
+			Properties: map[string]interface{}{
+				"key": finding.Key,
+			},
+		}
+		if finding.Locale != "" {
+			result.Properties["locale"] = finding.Locale
+		}
+		if finding.Fix != "" {
+			result.Properties["suggestedFix"] = finding.Fix
+		}
+
+		if finding.Filename != "" {
+			location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(finding.Filename)},
+			}}
+			if finding.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: finding.Line, StartColumn: finding.Column}
+			}
+			result.Locations = []sarifLocation{location}
+		}
+
+		results = append(results, result)
+	}
+
+	rules := []sarifRule{}
+	for ruleID := range ruleIDs {
+		rules = append(rules, sarifRule{ID: ruleID, ShortDescription: sarifMessage{Text: RULE_DESCRIPTIONS[ruleID]}})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+
+	return sarifLog{
+		Schema:  SARIF_SCHEMA,
+		Version: SARIF_VERSION,
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: I18N4GO_TOOL_NAME, InformationURI: I18N4GO_INFORMATION_URI, Rules: rules}},
+			Results: results,
+		}},
+	}
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitFailure `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit writes a test case per finding, failed for the errors and
+// skipped for the warnings, or a passing test case without findings
+func writeJUnit(writer io.Writer, command string, findings []Finding) error {
+	testSuite := junitTestSuite{Name: I18N4GO_TOOL_NAME + " " + command}
+	for _, finding := range findings {
+		var name []string
+		for _, s := range []string{finding.Locale, finding.Key} {
+			if s != "" {
+				name = append(name, s)
+			}
+		}
+
+		text := finding.Location()
+		if finding.Fix != "" {
+			text = strings.TrimPrefix(text+"\n"+finding.Fix, "\n")
+		}
+
+		testCase := junitTestCase{ClassName: command + "." + finding.RuleID, Name: strings.Join(name, ": ")}
+		failure := &junitFailure{Message: finding.Message, Type: finding.RuleID, Text: text}
+		if finding.Severity == SEVERITY_WARNING {
+			testCase.Skipped = failure
+			testSuite.Skipped++
+		} else {
+			testCase.Failure = failure
+			testSuite.Failures++
+		}
+		testSuite.TestCases = append(testSuite.TestCases, testCase)
+	}
+
+	if len(testSuite.TestCases) == 0 {
+		testSuite.TestCases = []junitTestCase{{ClassName: command, Name: command}}
+	}
+	testSuite.Tests = len(testSuite.TestCases)
+
+	content, err := xml.MarshalIndent(junitTestSuites{TestSuites: []junitTestSuite{testSuite}}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(writer, "%s%s\n", xml.Header, content)
+	return err
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCheckFindingsFormat(t *testing.T) {
+	for _, format := range []string{"", FORMAT_TEXT, FORMAT_JSON, FORMAT_SARIF, FORMAT_JUNIT} {
+		if err := CheckFindingsFormat(format); err != nil {
+			t.Errorf("CheckFindingsFormat(%q) = %v", format, err)
+		}
+	}
+
+	if err := CheckFindingsFormat("xml"); err == nil {
+		t.Error("CheckFindingsFormat(\"xml\") did not fail")
+	}
+}
+
+func TestWriteFindings(t *testing.T) {
+	findings := []Finding{
+		{RuleID: RULE_EXTRA_PLURAL_CATEGORIES, Severity: SEVERITY_WARNING, Message: "extra categories", Locale: "ja", Key: "{{.Count}} apps", Filename: "ja.all.json"},
+		{RuleID: RULE_MISSING_STRING, Severity: SEVERITY_ERROR, Message: "missing", Locale: "en", Key: "Hello", Filename: "app.go", Line: 12, Column: 3, Fix: "Add \"Hello\" to en.all.json"},
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteFindings(&buffer, FORMAT_JSON, "checkup", nil); err != nil || strings.TrimSpace(buffer.String()) != "[]" {
+		t.Errorf("WriteFindings without findings = %q, %v", buffer.String(), err)
+	}
+
+	buffer.Reset()
+	if err := WriteFindings(&buffer, FORMAT_SARIF, "checkup", findings); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{`"version": "2.1.0"`, `"uri": "app.go"`, `"startLine": 12`, `"level": "warning"`, `"suggestedFix": "Add \"Hello\" to en.all.json"`} {
+		if !strings.Contains(buffer.String(), s) {
+			t.Errorf("the SARIF log does not contain %s:\n%s", s, buffer.String())
+		}
+	}
+
+	buffer.Reset()
+	if err := WriteFindings(&buffer, FORMAT_JUNIT, "checkup", findings); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{`tests="2" failures="1" skipped="1"`, `<testcase classname="checkup.missing-string" name="en: Hello">`, `app.go:12`} {
+		if !strings.Contains(buffer.String(), s) {
+			t.Errorf("the JUnit report does not contain %s:\n%s", s, buffer.String())
+		}
+	}
+
+	buffer.Reset()
+	if err := WriteFindings(&buffer, FORMAT_JUNIT, "checkup", nil); err != nil || !strings.Contains(buffer.String(), `<testcase classname="checkup" name="checkup">`) {
+		t.Errorf("the JUnit report without findings does not have a passing test case:\n%s", buffer.String())
+	}
+}
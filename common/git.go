@@ -0,0 +1,66 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GitChangedFiles returns the real absolute paths of the files changed since
+// a git ref in the work tree of a dir, with the uncommitted and untracked files
+func GitChangedFiles(dirName, ref string) (map[string]bool, error) {
+	topLevel, err := git(dirName, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	topLevel = strings.TrimSpace(topLevel)
+
+	changed, err := git(topLevel, "diff", "--name-only", "--no-renames", ref, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := git(topLevel, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	changedFiles := make(map[string]bool)
+	for _, fileName := range strings.Split(changed+untracked, "\n") {
+		if fileName != "" {
+			changedFiles[filepath.Join(topLevel, fileName)] = true
+		}
+	}
+
+	return changedFiles, nil
+}
+
+// RealPath returns the absolute path of a file with its symlinks evaluated,
+// or the absolute path when they cannot be
+func RealPath(fileName string) string {
+	absFileName, err := filepath.Abs(fileName)
+	if err != nil {
+		return fileName
+	}
+
+	if realFileName, err := filepath.EvalSymlinks(absFileName); err == nil {
+		return realFileName
+	}
+
+	return absFileName
+}
+
+func git(dirName string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dirName}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("i18n4go: git %s failed: %s %s", strings.Join(args, " "), err.Error(), strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,239 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// PLURAL_CATEGORIES are the CLDR plural categories in their usual order
+var PLURAL_CATEGORIES = []string{"zero", "one", "two", "few", "many", "other"}
+
+// I18nStringInfo is a message of a translation file, its translation is
+// either a string or, for a plural message, a translation per CLDR plural
+// category, e.g.,
+//
+//	{"id": "{{.Count}} apps", "translation": {"one": "{{.Count}} app", "other": "{{.Count}} apps"}}
+//
+// Translation is then the "other" translation so that the commands that do
+// not handle plurals still see a string
+type I18nStringInfo struct {
+	ID          string
+	Translation string
+	Plurals     map[string]string
+	Modified    bool
+}
+
+type i18nStringInfoJSON struct {
+	ID          string          `json:"id"`
+	Translation json.RawMessage `json:"translation"`
+	Modified    bool            `json:"modified"`
+}
+
+func NewPluralI18nStringInfo(id string, plurals map[string]string, modified bool) I18nStringInfo {
+	return I18nStringInfo{ID: id, Translation: plurals["other"], Plurals: plurals, Modified: modified}
+}
+
+func (info I18nStringInfo) IsPlural() bool {
+	return info.Plurals != nil
+}
+
+// Translations returns the translation, or the plural translations in the
+// order of PLURAL_CATEGORIES
+func (info I18nStringInfo) Translations() []string {
+	if !info.IsPlural() {
+		return []string{info.Translation}
+	}
+
+	var translations []string
+	for _, category := range info.PluralCategories() {
+		translations = append(translations, info.Plurals[category])
+	}
+
+	return translations
+}
+
+// PluralCategories returns the categories of a plural message in the order
+// of PLURAL_CATEGORIES, unknown categories last
+func (info I18nStringInfo) PluralCategories() []string {
+	categories := make([]string, 0, len(info.Plurals))
+	for category := range info.Plurals {
+		categories = append(categories, category)
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		return pluralCategoryIndex(categories[i]) < pluralCategoryIndex(categories[j]) ||
+			(pluralCategoryIndex(categories[i]) == pluralCategoryIndex(categories[j]) && categories[i] < categories[j])
+	})
+
+	return categories
+}
+
+// MapTranslations returns a copy whose translations, all the plural ones for
+// a plural message, are replaced with f(translation)
+func (info I18nStringInfo) MapTranslations(f func(translation string) string) I18nStringInfo {
+	if !info.IsPlural() {
+		info.Translation = f(info.Translation)
+		return info
+	}
+
+	plurals := make(map[string]string, len(info.Plurals))
+	for category, translation := range info.Plurals {
+		plurals[category] = f(translation)
+	}
+
+	return NewPluralI18nStringInfo(info.ID, plurals, info.Modified)
+}
+
+// Equal returns whether both messages are the same, I18nStringInfo is not
+// comparable with == because of the plurals
+func (info I18nStringInfo) Equal(other I18nStringInfo) bool {
+	if info.ID != other.ID || info.Translation != other.Translation || info.Modified != other.Modified || info.IsPlural() != other.IsPlural() {
+		return false
+	}
+
+	if len(info.Plurals) != len(other.Plurals) {
+		return false
+	}
+
+	for category, translation := range info.Plurals {
+		if otherTranslation, ok := other.Plurals[category]; !ok || otherTranslation != translation {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (info I18nStringInfo) IsEmpty() bool {
+	return info.Equal(I18nStringInfo{})
+}
+
+func (info I18nStringInfo) MarshalJSON() ([]byte, error) {
+	translation, err := info.translationJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(i18nStringInfoJSON{ID: info.ID, Translation: translation, Modified: info.Modified})
+}
+
+func (info *I18nStringInfo) UnmarshalJSON(data []byte) error {
+	var infoJSON i18nStringInfoJSON
+	err := json.Unmarshal(data, &infoJSON)
+	if err != nil {
+		return err
+	}
+
+	*info, err = newI18nStringInfo(infoJSON.ID, infoJSON.Translation)
+	info.Modified = infoJSON.Modified
+
+	return err
+}
+
+// translationJSON returns the translation as a JSON string, or as a JSON
+// object with the plural categories in the order of PLURAL_CATEGORIES
+func (info I18nStringInfo) translationJSON() (json.RawMessage, error) {
+	if !info.IsPlural() {
+		return json.Marshal(info.Translation)
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("{")
+	for i, category := range info.PluralCategories() {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+
+		key, err := json.Marshal(category)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(info.Plurals[category])
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(key)
+		buffer.WriteString(":")
+		buffer.Write(value)
+	}
+	buffer.WriteString("}")
+
+	return buffer.Bytes(), nil
+}
+
+// newI18nStringInfo creates the message of a JSON translation, a string or
+// an object of plural translations
+func newI18nStringInfo(id string, translation json.RawMessage) (I18nStringInfo, error) {
+	translation = bytes.TrimSpace(translation)
+	if len(translation) == 0 || bytes.Equal(translation, []byte("null")) {
+		return I18nStringInfo{ID: id}, nil
+	}
+
+	if translation[0] != '{' {
+		var aString string
+		err := json.Unmarshal(translation, &aString)
+		if err != nil {
+			return I18nStringInfo{}, fmt.Errorf("i18n4go: the translation of %q is not a string or plural translations: %s", id, err.Error())
+		}
+		return I18nStringInfo{ID: id, Translation: aString}, nil
+	}
+
+	var plurals map[string]string
+	err := json.Unmarshal(translation, &plurals)
+	if err != nil {
+		return I18nStringInfo{}, fmt.Errorf("i18n4go: the plural translations of %q are not strings: %s", id, err.Error())
+	}
+
+	for category := range plurals {
+		if pluralCategoryIndex(category) == len(PLURAL_CATEGORIES) {
+			return I18nStringInfo{}, fmt.Errorf("i18n4go: %q has an unknown plural category: %s", id, category)
+		}
+	}
+
+	return NewPluralI18nStringInfo(id, plurals, false), nil
+}
+
+func pluralCategoryIndex(category string) int {
+	for i, pluralCategory := range PLURAL_CATEGORIES {
+		if pluralCategory == category {
+			return i
+		}
+	}
+
+	return len(PLURAL_CATEGORIES)
+}
+
+// MissingPluralCategories returns the plural categories of a language, see
+// PluralRuleForLanguage, that a plural message has no translation for
+func (info I18nStringInfo) MissingPluralCategories(language string) []string {
+	var missingCategories []string
+	for _, category := range PluralRuleForLanguage(language).Categories {
+		if _, ok := info.Plurals[category]; !ok {
+			missingCategories = append(missingCategories, category)
+		}
+	}
+
+	return missingCategories
+}
+
+// ExtraPluralCategories returns the plural categories of a plural message
+// that a language does not use
+func (info I18nStringInfo) ExtraPluralCategories(language string) []string {
+	categories := make(map[string]bool)
+	for _, category := range PluralRuleForLanguage(language).Categories {
+		categories[category] = true
+	}
+
+	var extraCategories []string
+	for _, category := range info.PluralCategories() {
+		if !categories[category] {
+			extraCategories = append(extraCategories, category)
+		}
+	}
+
+	return extraCategories
+}
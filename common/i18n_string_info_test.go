@@ -0,0 +1,103 @@
+package common
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestI18nStringInfoJSON(t *testing.T) {
+	content := []byte(`[
+   {
+      "id": "{{.Count}} apps",
+      "translation": {
+         "other": "{{.Count}} applications",
+         "one": "{{.Count}} application"
+      },
+      "modified": true
+   },
+   {
+      "id": "Hello",
+      "translation": "Bonjour",
+      "modified": false
+   }
+]`)
+
+	var i18nStringInfos []I18nStringInfo
+	err := json.Unmarshal(content, &i18nStringInfos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plural := i18nStringInfos[0]
+	if !plural.IsPlural() || plural.Translation != "{{.Count}} applications" || !plural.Modified {
+		t.Fatalf("unexpected plural string: %#v", plural)
+	}
+	if !reflect.DeepEqual(plural.Translations(), []string{"{{.Count}} application", "{{.Count}} applications"}) {
+		t.Fatalf("unexpected plural translations: %v", plural.Translations())
+	}
+	if i18nStringInfos[1].IsPlural() || i18nStringInfos[1].Translation != "Bonjour" {
+		t.Fatalf("unexpected string: %#v", i18nStringInfos[1])
+	}
+
+	data, err := json.Marshal(plural)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"id":"{{.Count}} apps","translation":{"one":"{{.Count}} application","other":"{{.Count}} applications"},"modified":true}`
+	if string(UnescapeHTML(data)) != expected {
+		t.Fatalf("expected %s, got %s", expected, data)
+	}
+}
+
+func TestI18nStringInfoUnknownPluralCategory(t *testing.T) {
+	var i18nStringInfo I18nStringInfo
+	err := json.Unmarshal([]byte(`{"id": "apps", "translation": {"single": "app"}}`), &i18nStringInfo)
+	if err == nil {
+		t.Fatal("expected an error for an unknown plural category")
+	}
+}
+
+func TestI18nStringInfoFlatFileRoundTrip(t *testing.T) {
+	i18nStringInfos := []I18nStringInfo{
+		{ID: "Hello", Translation: "Привет"},
+		NewPluralI18nStringInfo("{{.Count}} files", map[string]string{"one": "{{.Count}} файл", "few": "{{.Count}} файла", "many": "{{.Count}} файлов", "other": "{{.Count}} файла"}, false),
+	}
+
+	fileName := filepath.Join(t.TempDir(), "ru.all.json")
+	err := SaveI18nStringInfos(&testPrinter{}, Options{OutputFormatFlatFlag: true}, i18nStringInfos, fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loadedI18nStringInfos, err := LoadI18nStringInfos(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(loadedI18nStringInfos) != len(i18nStringInfos) {
+		t.Fatalf("expected %d strings, got %d", len(i18nStringInfos), len(loadedI18nStringInfos))
+	}
+	for i := range i18nStringInfos {
+		if !loadedI18nStringInfos[i].Equal(i18nStringInfos[i]) {
+			t.Errorf("expected %#v, got %#v", i18nStringInfos[i], loadedI18nStringInfos[i])
+		}
+	}
+
+	if categories := loadedI18nStringInfos[1].MissingPluralCategories("ru"); categories != nil {
+		t.Errorf("expected no missing categories for ru, got %v", categories)
+	}
+	if categories := loadedI18nStringInfos[1].MissingPluralCategories("pl"); categories != nil {
+		t.Errorf("expected no missing categories for pl, got %v", categories)
+	}
+	if categories := loadedI18nStringInfos[1].ExtraPluralCategories("fr"); !reflect.DeepEqual(categories, []string{"few", "many"}) {
+		t.Errorf("expected few and many to be extra for fr, got %v", categories)
+	}
+}
+
+type testPrinter struct{}
+
+func (printer *testPrinter) Println(a ...interface{}) (int, error) { return 0, nil }
+
+func (printer *testPrinter) Printf(msg string, a ...interface{}) (int, error) { return 0, nil }
@@ -0,0 +1,187 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"go/build"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// FindModule walks up from dir to the closest go.mod and returns the
+// directory containing it and the module path it declares
+func FindModule(dir string) (string, string, error) {
+	moduleDir, err := findUp(dir, "go.mod")
+	if err != nil {
+		return "", "", err
+	}
+
+	content, err := os.ReadFile(filepath.Join(moduleDir, "go.mod"))
+	if err != nil {
+		return "", "", err
+	}
+
+	modulePath := modfile.ModulePath(content)
+	if modulePath == "" {
+		return "", "", fmt.Errorf("i18n4go: no module path in: %s", filepath.Join(moduleDir, "go.mod"))
+	}
+
+	return moduleDir, modulePath, nil
+}
+
+// FindWorkspace walks up from dir to the closest go.work and returns the
+// directory containing it and the absolute directories of its modules
+func FindWorkspace(dir string) (string, []string, error) {
+	workspaceDir, err := findUp(dir, "go.work")
+	if err != nil {
+		return "", nil, err
+	}
+
+	workFilename := filepath.Join(workspaceDir, "go.work")
+	content, err := os.ReadFile(workFilename)
+	if err != nil {
+		return "", nil, err
+	}
+
+	workFile, err := modfile.ParseWork(workFilename, content, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var moduleDirs []string
+	for _, use := range workFile.Use {
+		moduleDir := filepath.FromSlash(use.Path)
+		if !filepath.IsAbs(moduleDir) {
+			moduleDir = filepath.Join(workspaceDir, moduleDir)
+		}
+		moduleDirs = append(moduleDirs, moduleDir)
+	}
+
+	return workspaceDir, moduleDirs, nil
+}
+
+// ImportPathForDir returns the import path of the package in dir, using the
+// closest go.mod (module path plus relative dir) or GOPATH when there is none
+func ImportPathForDir(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	moduleDir, modulePath, err := FindModule(absDir)
+	if err == nil {
+		relDir, err := filepath.Rel(moduleDir, absDir)
+		if err != nil {
+			return "", err
+		}
+
+		return path.Join(modulePath, filepath.ToSlash(relDir)), nil
+	}
+
+	pkg, err := build.Default.ImportDir(absDir, build.FindOnly)
+	if err != nil {
+		return "", err
+	}
+
+	if pkg.ImportPath == "" || pkg.ImportPath == "." {
+		return "", fmt.Errorf("i18n4go: could not determine import path for dir: %s", dir)
+	}
+
+	return pkg.ImportPath, nil
+}
+
+// RelativeImportPath returns the import path of the package in dir relative
+// to the package in rootDir; rootDir can also be the root of a go.work
+// workspace, in which case the path relative to the workspace is used
+func RelativeImportPath(rootDir, dir string) (string, error) {
+	absRootDir, err := filepath.Abs(rootDir)
+	if err != nil {
+		return "", err
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	importPath, err := ImportPathForDir(absDir)
+	if err != nil {
+		return "", err
+	}
+
+	rootImportPath, err := ImportPathForDir(absRootDir)
+	if err == nil {
+		if importPath == rootImportPath {
+			return "", nil
+		}
+
+		if strings.HasPrefix(importPath, rootImportPath+"/") {
+			return strings.TrimPrefix(importPath, rootImportPath+"/"), nil
+		}
+	}
+
+	if isSubDir(absRootDir, absDir) && (err == nil || inWorkspace(absRootDir, absDir)) {
+		// e.g., nested modules with unrelated module paths or a go.work root
+		relDir, err := filepath.Rel(absRootDir, absDir)
+		if err != nil {
+			return "", err
+		}
+
+		return filepath.ToSlash(relDir), nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return importPath, nil
+}
+
+func inWorkspace(workspaceDir, dir string) bool {
+	foundWorkspaceDir, moduleDirs, err := FindWorkspace(workspaceDir)
+	if err != nil || foundWorkspaceDir != workspaceDir {
+		return false
+	}
+
+	moduleDir, _, err := FindModule(dir)
+	if err != nil {
+		return false
+	}
+
+	for _, workspaceModuleDir := range moduleDirs {
+		if filepath.Clean(workspaceModuleDir) == moduleDir {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSubDir(parentDir, dir string) bool {
+	relDir, err := filepath.Rel(parentDir, dir)
+	return err == nil && relDir != ".." && !strings.HasPrefix(relDir, ".."+string(os.PathSeparator))
+}
+
+func findUp(dir string, fileName string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		fileInfo, err := os.Stat(filepath.Join(absDir, fileName))
+		if err == nil && fileInfo.Mode().IsRegular() {
+			return absDir, nil
+		}
+
+		parentDir := filepath.Dir(absDir)
+		if parentDir == absDir {
+			return "", errors.New("i18n4go: could not find " + fileName + " in: " + dir + " or any of its parent directories")
+		}
+		absDir = parentDir
+	}
+}
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, fileName, content string) {
+	err := os.MkdirAll(filepath.Dir(fileName), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(fileName, []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportPathForDir(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "go.mod"), "module example.com/app\n\ngo 1.21\n")
+	writeTestFile(t, filepath.Join(dir, "nested", "go.mod"), "module example.com/other\n\ngo 1.21\n")
+
+	for subDir, exp := range map[string]string{
+		"":                "example.com/app",
+		"cmd/app":         "example.com/app/cmd/app",
+		"nested":          "example.com/other",
+		"nested/pkg/util": "example.com/other/pkg/util",
+	} {
+		got, err := ImportPathForDir(filepath.Join(dir, subDir))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != exp {
+			t.Errorf("got import path %q for %q, expected %q", got, subDir, exp)
+		}
+	}
+}
+
+func TestRelativeImportPath(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "go.work"), "go 1.21\n\nuse (\n\t./app\n\t./lib\n)\n")
+	writeTestFile(t, filepath.Join(dir, "app", "go.mod"), "module example.com/app\n\ngo 1.21\n")
+	writeTestFile(t, filepath.Join(dir, "lib", "go.mod"), "module github.com/org/lib\n\ngo 1.21\n")
+	writeTestFile(t, filepath.Join(dir, "app", "nested", "go.mod"), "module example.com/nested\n\ngo 1.21\n")
+
+	for _, test := range []struct{ root, dir, exp string }{
+		{"app", "app", ""},
+		{"app", "app/cmd/app", "cmd/app"},
+		{"app", "app/nested/pkg", "nested/pkg"},
+		{"app", "lib/util", "github.com/org/lib/util"},
+		{".", "app/cmd", "app/cmd"},
+		{".", "lib/util", "lib/util"},
+	} {
+		got, err := RelativeImportPath(filepath.Join(dir, test.root), filepath.Join(dir, test.dir))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.exp {
+			t.Errorf("got import path %q for %q relative to %q, expected %q", got, test.dir, test.root, test.exp)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package common
+
+import (
+	"strings"
+)
+
+// PluralRule is the gettext Plural-Forms of a language, the CLDR plural
+// category of each msgstr[n] index, e.g., msgstr[1] is "few" in Russian, and
+// the CLDR plural categories a translation needs, which always include
+// "other" even when gettext has no form for it, e.g., fractions in Russian
+type PluralRule struct {
+	PluralForms string
+	Forms       []string
+	Categories  []string
+}
+
+var (
+	PLURAL_RULE_ONE_OTHER = PluralRule{
+		PluralForms: "nplurals=2; plural=(n != 1);",
+		Forms:       []string{"one", "other"},
+		Categories:  []string{"one", "other"},
+	}
+	PLURAL_RULE_ONE_GT_OTHER = PluralRule{
+		PluralForms: "nplurals=2; plural=(n > 1);",
+		Forms:       []string{"one", "other"},
+		Categories:  []string{"one", "other"},
+	}
+	PLURAL_RULE_OTHER = PluralRule{
+		PluralForms: "nplurals=1; plural=0;",
+		Forms:       []string{"other"},
+		Categories:  []string{"other"},
+	}
+	PLURAL_RULE_RUSSIAN = PluralRule{
+		PluralForms: "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+		Forms:       []string{"one", "few", "many"},
+		Categories:  []string{"one", "few", "many", "other"},
+	}
+	PLURAL_RULE_SERBIAN = PluralRule{
+		PluralForms: "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+		Forms:       []string{"one", "few", "other"},
+		Categories:  []string{"one", "few", "other"},
+	}
+	PLURAL_RULE_POLISH = PluralRule{
+		PluralForms: "nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+		Forms:       []string{"one", "few", "many"},
+		Categories:  []string{"one", "few", "many", "other"},
+	}
+	PLURAL_RULE_CZECH = PluralRule{
+		PluralForms: "nplurals=3; plural=(n==1) ? 0 : (n>=2 && n<=4) ? 1 : 2;",
+		Forms:       []string{"one", "few", "other"},
+		Categories:  []string{"one", "few", "many", "other"},
+	}
+	PLURAL_RULE_ARABIC = PluralRule{
+		PluralForms: "nplurals=6; plural=(n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n%100>=3 && n%100<=10 ? 3 : n%100>=11 ? 4 : 5);",
+		Forms:       []string{"zero", "one", "two", "few", "many", "other"},
+		Categories:  []string{"zero", "one", "two", "few", "many", "other"},
+	}
+	PLURAL_RULE_HEBREW = PluralRule{
+		PluralForms: "nplurals=3; plural=(n==1 ? 0 : n==2 ? 1 : 2);",
+		Forms:       []string{"one", "two", "other"},
+		Categories:  []string{"one", "two", "many", "other"},
+	}
+	PLURAL_RULE_ROMANIAN = PluralRule{
+		PluralForms: "nplurals=3; plural=(n==1 ? 0 : (n==0 || (n%100>0 && n%100<20)) ? 1 : 2);",
+		Forms:       []string{"one", "few", "other"},
+		Categories:  []string{"one", "few", "other"},
+	}
+	PLURAL_RULE_LITHUANIAN = PluralRule{
+		PluralForms: "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && (n%100<10 || n%100>=20) ? 1 : 2);",
+		Forms:       []string{"one", "few", "other"},
+		Categories:  []string{"one", "few", "many", "other"},
+	}
+	PLURAL_RULE_LATVIAN = PluralRule{
+		PluralForms: "nplurals=3; plural=(n%10==0 || (n%100>=11 && n%100<=19) ? 0 : n%10==1 && n%100!=11 ? 1 : 2);",
+		Forms:       []string{"zero", "one", "other"},
+		Categories:  []string{"zero", "one", "other"},
+	}
+	PLURAL_RULE_SLOVENIAN = PluralRule{
+		PluralForms: "nplurals=4; plural=(n%100==1 ? 0 : n%100==2 ? 1 : n%100==3 || n%100==4 ? 2 : 3);",
+		Forms:       []string{"one", "two", "few", "other"},
+		Categories:  []string{"one", "two", "few", "other"},
+	}
+)
+
+// PLURAL_RULES maps languages, and some languages with territory, to their
+// plural rule, languages not listed use PLURAL_RULE_ONE_OTHER
+var PLURAL_RULES = map[string]PluralRule{
+	"fr":    PLURAL_RULE_ONE_GT_OTHER,
+	"pt_BR": PLURAL_RULE_ONE_GT_OTHER,
+	"tr":    PLURAL_RULE_ONE_GT_OTHER,
+	"fil":   PLURAL_RULE_ONE_GT_OTHER,
+
+	"ja": PLURAL_RULE_OTHER,
+	"zh": PLURAL_RULE_OTHER,
+	"ko": PLURAL_RULE_OTHER,
+	"vi": PLURAL_RULE_OTHER,
+	"th": PLURAL_RULE_OTHER,
+	"id": PLURAL_RULE_OTHER,
+	"ms": PLURAL_RULE_OTHER,
+
+	"ru": PLURAL_RULE_RUSSIAN,
+	"uk": PLURAL_RULE_RUSSIAN,
+	"be": PLURAL_RULE_RUSSIAN,
+	"sr": PLURAL_RULE_SERBIAN,
+	"hr": PLURAL_RULE_SERBIAN,
+	"bs": PLURAL_RULE_SERBIAN,
+	"pl": PLURAL_RULE_POLISH,
+	"cs": PLURAL_RULE_CZECH,
+	"sk": PLURAL_RULE_CZECH,
+	"ro": PLURAL_RULE_ROMANIAN,
+	"lt": PLURAL_RULE_LITHUANIAN,
+	"lv": PLURAL_RULE_LATVIAN,
+	"sl": PLURAL_RULE_SLOVENIAN,
+	"he": PLURAL_RULE_HEBREW,
+	"ar": PLURAL_RULE_ARABIC,
+}
+
+// PluralRuleForLanguage returns the plural rule of a language such as "fr",
+// "pt_BR" or "zh-Hans"
+func PluralRuleForLanguage(language string) PluralRule {
+	language = strings.Replace(language, "-", "_", -1)
+	if rule, ok := PLURAL_RULES[language]; ok {
+		return rule
+	}
+
+	baseLanguage := strings.ToLower(strings.SplitN(language, "_", 2)[0])
+	if rule, ok := PLURAL_RULES[baseLanguage]; ok {
+		return rule
+	}
+
+	return PLURAL_RULE_ONE_OTHER
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPluralRulesRequireOther(t *testing.T) {
+	for language, rule := range PLURAL_RULES {
+		nplurals, err := strconv.Atoi(strings.TrimSuffix(strings.SplitN(strings.TrimPrefix(rule.PluralForms, "nplurals="), " ", 2)[0], ";"))
+		if err != nil {
+			t.Fatalf("%s: invalid Plural-Forms %q", language, rule.PluralForms)
+		}
+		if len(rule.Forms) != nplurals {
+			t.Errorf("%s: expected %d forms, got %v", language, nplurals, rule.Forms)
+		}
+
+		if rule.Categories[len(rule.Categories)-1] != "other" {
+			t.Errorf("%s: expected the categories to include other, got %v", language, rule.Categories)
+		}
+	}
+}
+
+func TestPluralRuleForRussian(t *testing.T) {
+	rule := PluralRuleForLanguage("ru_RU")
+	if !reflect.DeepEqual(rule.Forms, []string{"one", "few", "many"}) {
+		t.Errorf("expected the gettext forms one,few,many, got %v", rule.Forms)
+	}
+
+	info := NewPluralI18nStringInfo("{{.Count}} files", map[string]string{"one": "{{.Count}} файл", "few": "{{.Count}} файла", "many": "{{.Count}} файлов"}, false)
+	if categories := info.MissingPluralCategories("ru"); !reflect.DeepEqual(categories, []string{"other"}) {
+		t.Errorf("expected other to be missing for ru, got %v", categories)
+	}
+}
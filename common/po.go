@@ -0,0 +1,414 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	PO_FLAG_FUZZY     = "fuzzy"
+	PO_FLAG_GO_FORMAT = "go-format"
+)
+
+// PoFile is a gettext PO file, the header entry (msgid "") is kept apart as
+// an ordered list of headers
+type PoFile struct {
+	Headers []PoHeader
+	Entries []*PoEntry
+}
+
+type PoHeader struct {
+	Name  string
+	Value string
+}
+
+// PoEntry is one message of a PO file, Translations holds the msgstr[n] of
+// plural messages and Translation the msgstr of the others
+type PoEntry struct {
+	TranslatorComments []string
+	ExtractedComments  []string
+	References         []string
+	Flags              []string
+
+	Context      string
+	ID           string
+	IDPlural     string
+	Translation  string
+	Translations []string
+}
+
+// NewPoFile returns an empty PO file with the usual headers, including the
+// Plural-Forms of the language
+func NewPoFile(language string) *PoFile {
+	return &PoFile{
+		Headers: []PoHeader{
+			{"Project-Id-Version", ""},
+			{"Language", language},
+			{"MIME-Version", "1.0"},
+			{"Content-Type", "text/plain; charset=UTF-8"},
+			{"Content-Transfer-Encoding", "8bit"},
+			{"Plural-Forms", PluralRuleForLanguage(language).PluralForms},
+			{"X-Generator", "i18n4go"},
+		},
+	}
+}
+
+func (po *PoFile) Header(name string) string {
+	for _, header := range po.Headers {
+		if strings.EqualFold(header.Name, name) {
+			return header.Value
+		}
+	}
+
+	return ""
+}
+
+func (po *PoFile) SetHeader(name, value string) {
+	for i, header := range po.Headers {
+		if strings.EqualFold(header.Name, name) {
+			po.Headers[i].Value = value
+			return
+		}
+	}
+
+	po.Headers = append(po.Headers, PoHeader{name, value})
+}
+
+// Language returns the Language header
+func (po *PoFile) Language() string {
+	return po.Header("Language")
+}
+
+// PluralCategories returns the CLDR plural category of each msgstr[n] of the
+// plural messages, using the Language header
+func (po *PoFile) PluralCategories() []string {
+	return PluralRuleForLanguage(po.Language()).Forms
+}
+
+func (entry *PoEntry) HasFlag(flag string) bool {
+	for _, entryFlag := range entry.Flags {
+		if entryFlag == flag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (entry *PoEntry) AddFlag(flag string) {
+	if !entry.HasFlag(flag) {
+		entry.Flags = append(entry.Flags, flag)
+	}
+}
+
+func (entry *PoEntry) IsPlural() bool {
+	return entry.IDPlural != ""
+}
+
+func (po *PoFile) WriteTo(w io.Writer) (int64, error) {
+	var buffer bytes.Buffer
+
+	var header strings.Builder
+	for _, poHeader := range po.Headers {
+		header.WriteString(poHeader.Name + ": " + poHeader.Value + "\n")
+	}
+	buffer.WriteString("msgid \"\"\n")
+	writePoString(&buffer, "msgstr", header.String())
+
+	for _, entry := range po.Entries {
+		buffer.WriteString("\n")
+		writePoComments(&buffer, "# ", entry.TranslatorComments)
+		writePoComments(&buffer, "#. ", entry.ExtractedComments)
+		writePoComments(&buffer, "#: ", entry.References)
+		if len(entry.Flags) > 0 {
+			buffer.WriteString("#, " + strings.Join(entry.Flags, ", ") + "\n")
+		}
+
+		if entry.Context != "" {
+			writePoString(&buffer, "msgctxt", entry.Context)
+		}
+		writePoString(&buffer, "msgid", entry.ID)
+
+		if entry.IsPlural() {
+			writePoString(&buffer, "msgid_plural", entry.IDPlural)
+			translations := entry.Translations
+			if len(translations) == 0 {
+				translations = []string{""}
+			}
+			for i, translation := range translations {
+				writePoString(&buffer, "msgstr["+strconv.Itoa(i)+"]", translation)
+			}
+		} else {
+			writePoString(&buffer, "msgstr", entry.Translation)
+		}
+	}
+
+	n, err := w.Write(buffer.Bytes())
+	return int64(n), err
+}
+
+func (po *PoFile) Save(fileName string) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = po.WriteTo(file)
+	return err
+}
+
+func writePoComments(buffer *bytes.Buffer, prefix string, comments []string) {
+	for _, comment := range comments {
+		for _, line := range strings.Split(comment, "\n") {
+			buffer.WriteString(strings.TrimRight(prefix+line, " ") + "\n")
+		}
+	}
+}
+
+// writePoString writes a keyword and its quoted string, strings with new
+// lines are written the gettext way, i.e., an empty first line followed by
+// one line per new line
+func writePoString(buffer *bytes.Buffer, keyword string, s string) {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) <= 1 {
+		buffer.WriteString(keyword + " " + QuotePoString(s) + "\n")
+		return
+	}
+
+	buffer.WriteString(keyword + " \"\"\n")
+	for _, line := range lines {
+		buffer.WriteString(QuotePoString(line) + "\n")
+	}
+}
+
+// QuotePoString quotes a string with the C escapes understood by gettext,
+// other characters (including non ASCII ones) are kept as is
+func QuotePoString(s string) string {
+	var builder strings.Builder
+	builder.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			builder.WriteString(`\\`)
+		case '"':
+			builder.WriteString(`\"`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\t':
+			builder.WriteString(`\t`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\a':
+			builder.WriteString(`\a`)
+		case '\b':
+			builder.WriteString(`\b`)
+		case '\f':
+			builder.WriteString(`\f`)
+		case '\v':
+			builder.WriteString(`\v`)
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	builder.WriteByte('"')
+
+	return builder.String()
+}
+
+// UnquotePoString is the reverse of QuotePoString, it also accepts octal,
+// hex and the \u escapes of Go quoted strings
+func UnquotePoString(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("i18n4go: invalid PO string: %s", s)
+	}
+
+	s = s[1 : len(s)-1]
+	var builder strings.Builder
+	for len(s) > 0 {
+		if s[0] == '"' {
+			return "", fmt.Errorf("i18n4go: unescaped quote in PO string: %s", s)
+		}
+
+		if s[0] != '\\' {
+			builder.WriteByte(s[0])
+			s = s[1:]
+			continue
+		}
+
+		if len(s) > 1 && s[1] == '?' {
+			builder.WriteByte('?')
+			s = s[2:]
+			continue
+		}
+
+		value, multibyte, tail, err := strconv.UnquoteChar(s, '"')
+		if err != nil {
+			return "", fmt.Errorf("i18n4go: invalid escape in PO string: %s", s)
+		}
+
+		if multibyte {
+			builder.WriteRune(value)
+		} else {
+			builder.WriteByte(byte(value))
+		}
+		s = tail
+	}
+
+	return builder.String(), nil
+}
+
+func ReadPoFile(fileName string) (*PoFile, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	po, err := ParsePo(content)
+	if err != nil {
+		return nil, fmt.Errorf("i18n4go: could not parse %s: %s", fileName, err.Error())
+	}
+
+	return po, nil
+}
+
+// ParsePo parses the content of a PO file, obsolete entries (#~) are dropped
+func ParsePo(content []byte) (*PoFile, error) {
+	po := &PoFile{}
+
+	var entry *PoEntry
+	var current *string
+	var hasEntry, hasMsgstr bool
+	lineNumber := 0
+
+	flush := func() {
+		if hasEntry {
+			if entry.ID == "" && entry.Context == "" {
+				po.Headers = parsePoHeaders(entry.Translation)
+			} else {
+				po.Entries = append(po.Entries, entry)
+			}
+		}
+
+		entry, current, hasEntry, hasMsgstr = &PoEntry{}, nil, false, false
+	}
+	flush()
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "#~"):
+			continue
+		case strings.HasPrefix(line, "#"):
+			if hasEntry {
+				flush()
+			}
+			parsePoComment(entry, line)
+		case strings.HasPrefix(line, "\""):
+			if current == nil {
+				return nil, fmt.Errorf("line %d: string without keyword", lineNumber)
+			}
+
+			s, err := UnquotePoString(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", lineNumber, err.Error())
+			}
+			*current += s
+		default:
+			keyword, value, found := strings.Cut(line, " ")
+			if !found {
+				return nil, fmt.Errorf("line %d: invalid line: %s", lineNumber, line)
+			}
+
+			s, err := UnquotePoString(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", lineNumber, err.Error())
+			}
+
+			if (keyword == "msgctxt" || keyword == "msgid") && hasMsgstr {
+				// entries without a blank line between them
+				flush()
+			}
+
+			switch {
+			case keyword == "msgctxt":
+				current = &entry.Context
+			case keyword == "msgid":
+				current = &entry.ID
+			case keyword == "msgid_plural":
+				current = &entry.IDPlural
+			case keyword == "msgstr":
+				current = &entry.Translation
+				hasMsgstr = true
+			case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+				index, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("line %d: invalid plural index: %s", lineNumber, keyword)
+				}
+				for len(entry.Translations) <= index {
+					entry.Translations = append(entry.Translations, "")
+				}
+				current = &entry.Translations[index]
+				hasMsgstr = true
+			default:
+				return nil, fmt.Errorf("line %d: unknown keyword: %s", lineNumber, keyword)
+			}
+
+			*current = s
+			hasEntry = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	return po, nil
+}
+
+func parsePoComment(entry *PoEntry, line string) {
+	switch {
+	case strings.HasPrefix(line, "#."):
+		entry.ExtractedComments = append(entry.ExtractedComments, strings.TrimSpace(line[2:]))
+	case strings.HasPrefix(line, "#:"):
+		entry.References = append(entry.References, strings.Fields(line[2:])...)
+	case strings.HasPrefix(line, "#,"):
+		for _, flag := range strings.Split(line[2:], ",") {
+			if flag = strings.TrimSpace(flag); flag != "" {
+				entry.AddFlag(flag)
+			}
+		}
+	case strings.HasPrefix(line, "#|"):
+		// previous msgid, not kept
+	default:
+		entry.TranslatorComments = append(entry.TranslatorComments, strings.TrimSpace(line[1:]))
+	}
+}
+
+func parsePoHeaders(s string) []PoHeader {
+	var headers []PoHeader
+	for _, line := range strings.Split(s, "\n") {
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		headers = append(headers, PoHeader{strings.TrimSpace(name), strings.TrimSpace(value)})
+	}
+
+	return headers
+}
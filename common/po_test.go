@@ -0,0 +1,60 @@
+package common
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestPoRoundTrip(t *testing.T) {
+	po := NewPoFile("pl")
+	po.Entries = []*PoEntry{
+		{References: []string{"app.go:12"}, Flags: []string{PO_FLAG_GO_FORMAT}, ID: "Hello %s", Translation: "Cześć %s"},
+		{ExtractedComments: []string{"shown in the help"}, ID: "Usage:\n  app [\"options\"]\n\tmore", Translation: "Użycie:\n  app [\"opcje\"]\n\twięcej"},
+		{Flags: []string{PO_FLAG_FUZZY}, Context: "files", ID: "{{.Count}} file", IDPlural: "{{.Count}} files", Translations: []string{"{{.Count}} plik", "{{.Count}} pliki", "{{.Count}} plików"}},
+		{TranslatorComments: []string{"not translated yet"}, ID: "back\\slash", Translation: ""},
+	}
+
+	var buffer bytes.Buffer
+	_, err := po.WriteTo(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsedPo, err := ParsePo(buffer.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(parsedPo, po) {
+		t.Fatalf("got %#v after round trip of:\n%s", parsedPo, buffer.String())
+	}
+
+	if parsedPo.Header("Plural-Forms") != PLURAL_RULE_POLISH.PluralForms {
+		t.Fatalf("got Plural-Forms %q", parsedPo.Header("Plural-Forms"))
+	}
+}
+
+func TestParsePoMultiLine(t *testing.T) {
+	po, err := ParsePo([]byte(`msgid ""
+msgstr "Language: fr\n"
+
+msgid ""
+"first line\n"
+"second line"
+msgstr "une ligne"
+msgid "no blank line"
+msgstr ""
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if po.Language() != "fr" || len(po.Entries) != 2 {
+		t.Fatalf("got language %q and %d entries", po.Language(), len(po.Entries))
+	}
+
+	if po.Entries[0].ID != "first line\nsecond line" || po.Entries[1].ID != "no blank line" {
+		t.Fatalf("got IDs %q and %q", po.Entries[0].ID, po.Entries[1].ID)
+	}
+}
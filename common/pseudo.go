@@ -0,0 +1,76 @@
+package common
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	PSEUDO_LANGUAGE     = "en_XA"
+	PSEUDO_RTL_LANGUAGE = "ar_XB"
+
+	// the right-to-left override and pop directional formatting characters
+	RTL_OVERRIDE = "\u202e"
+	RTL_POP      = "\u202c"
+)
+
+var pseudoLetters = map[rune]rune{
+	'a': 'á', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'í', 'j': 'ĵ', 'k': 'ķ', 'l': 'ļ', 'm': 'ɱ',
+	'n': 'ñ', 'o': 'ó', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ', 's': 'š', 't': 'ţ', 'u': 'ú', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+	'A': 'Á', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Í', 'J': 'Ĵ', 'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ',
+	'N': 'Ñ', 'O': 'Ó', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ', 'U': 'Ú', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+}
+
+var placeholderRegexp = regexp.MustCompile(PLACEHOLDER_REGEXP)
+
+// PseudoOptions configures PseudoLocalize, Expansion is the percentage of
+// characters added to the text, e.g., 30 to 50 for the usual expansion of
+// translations, and RightToLeft wraps the string to be shown right to left
+type PseudoOptions struct {
+	Expansion   int
+	RightToLeft bool
+}
+
+// PseudoLocalize replaces the letters of a string with accented ones and
+// brackets it, e.g., "Hello {{.Name}}" becomes "[Ĥéļļó {{.Name}}]", the
+// templated and interpolated placeholders are kept
+func PseudoLocalize(aString string, options PseudoOptions) string {
+	var builder strings.Builder
+	if options.RightToLeft {
+		builder.WriteString(RTL_OVERRIDE)
+	}
+	builder.WriteString("[")
+
+	start, length := 0, 0
+	for _, match := range placeholderRegexp.FindAllStringIndex(aString, -1) {
+		builder.WriteString(accentLetters(aString[start:match[0]]))
+		builder.WriteString(aString[match[0]:match[1]])
+		length += utf8.RuneCountInString(aString[start:match[0]])
+		start = match[1]
+	}
+	builder.WriteString(accentLetters(aString[start:]))
+	length += utf8.RuneCountInString(aString[start:])
+
+	if padding := int(math.Ceil(float64(length*options.Expansion) / 100)); padding > 0 {
+		builder.WriteString(" ")
+		builder.WriteString(strings.Repeat("~", padding))
+	}
+
+	builder.WriteString("]")
+	if options.RightToLeft {
+		builder.WriteString(RTL_POP)
+	}
+
+	return builder.String()
+}
+
+func accentLetters(aString string) string {
+	return strings.Map(func(r rune) rune {
+		if pseudoLetter, ok := pseudoLetters[r]; ok {
+			return pseudoLetter
+		}
+		return r
+	}, aString)
+}
@@ -0,0 +1,22 @@
+package common
+
+import "testing"
+
+func TestPseudoLocalize(t *testing.T) {
+	for _, test := range []struct {
+		aString  string
+		options  PseudoOptions
+		expected string
+	}{
+		{"Hello {{.Name}}", PseudoOptions{}, "[Ĥéļļó {{.Name}}]"},
+		{"%d apps in %s", PseudoOptions{}, "[%d áþþš íñ %s]"},
+		{"100% done, {{.Arg}}", PseudoOptions{}, "[100% ðóñé, {{.Arg}}]"},
+		{"", PseudoOptions{Expansion: 40}, "[]"},
+		{"Hello {{.Name}}", PseudoOptions{Expansion: 30}, "[Ĥéļļó {{.Name}} ~~]"},
+		{"Quota %v", PseudoOptions{Expansion: 50, RightToLeft: true}, "\u202e[Ǫúóţá %v ~~~]\u202c"},
+	} {
+		if pseudo := PseudoLocalize(test.aString, test.options); pseudo != test.expected {
+			t.Errorf("PseudoLocalize(%q, %+v) = %q, expected %q", test.aString, test.options, pseudo, test.expected)
+		}
+	}
+}
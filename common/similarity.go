@@ -0,0 +1,80 @@
+package common
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Similarity returns a score between 0 and 1 of how close two strings are,
+// the average of their normalized edit distance and the overlap of their
+// words, e.g., "I like bananas." and "I like apples." score about 0.6
+func Similarity(s1, s2 string) float64 {
+	if s1 == s2 {
+		return 1
+	}
+
+	return (EditSimilarity(s1, s2) + TokenSimilarity(s1, s2)) / 2
+}
+
+// EditSimilarity returns 1 minus the Levenshtein distance of the two strings
+// divided by the length of the longest one, counting runes
+func EditSimilarity(s1, s2 string) float64 {
+	r1, r2 := []rune(s1), []rune(s2)
+	maxLen := max(len(r1), len(r2))
+	if maxLen == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein(r1, r2))/float64(maxLen)
+}
+
+// TokenSimilarity returns the Jaccard index of the lower cased words of the
+// two strings, ignoring punctuation
+func TokenSimilarity(s1, s2 string) float64 {
+	tokens1, tokens2 := tokenSet(s1), tokenSet(s2)
+	if len(tokens1) == 0 && len(tokens2) == 0 {
+		return 1
+	}
+
+	intersection := 0
+	for token := range tokens1 {
+		if tokens2[token] {
+			intersection++
+		}
+	}
+
+	return float64(intersection) / float64(len(tokens1)+len(tokens2)-intersection)
+}
+
+func tokenSet(s string) map[string]bool {
+	tokens := make(map[string]bool)
+	for _, token := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		tokens[token] = true
+	}
+
+	return tokens
+}
+
+func levenshtein(r1, r2 []rune) int {
+	previous := make([]int, len(r2)+1)
+	current := make([]int, len(r2)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(r1); i++ {
+		current[0] = i
+		for j := 1; j <= len(r2); j++ {
+			cost := 1
+			if r1[i-1] == r2[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(r2)]
+}
@@ -0,0 +1,21 @@
+package common
+
+import "testing"
+
+func TestSimilarity(t *testing.T) {
+	for _, test := range []struct {
+		s1, s2   string
+		min, max float64
+	}{
+		{"I like apples.", "I like apples.", 1, 1},
+		{"Hello world", "Hello, world!", 0.9, 1},
+		{"I like bananas.", "I like apples.", 0.5, 0.7},
+		{"Heal the world", "I like apples.", 0, 0.2},
+		{"", "", 1, 1},
+	} {
+		score := Similarity(test.s1, test.s2)
+		if score < test.min || score > test.max {
+			t.Errorf("got similarity %f for %q and %q, expected between %f and %f", score, test.s1, test.s2, test.min, test.max)
+		}
+	}
+}
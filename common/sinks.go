@@ -0,0 +1,109 @@
+package common
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// SinkFuncs is a set of "sink" functions, i.e., functions whose string
+// parameters end up in front of users, e.g., fmt.Printf, errors.New or ui.Say
+//
+// A sink can be named with its full name (fmt.Printf, github.com/org/ui.Say),
+// its package name (ui.Say), its receiver type name (UI.Say) or the selector
+// used at the call site (ui.Say where ui is a variable)
+type SinkFuncs map[string]bool
+
+func NewSinkFuncs(names []string) SinkFuncs {
+	sinkFuncs := make(SinkFuncs, len(names))
+	for _, name := range names {
+		sinkFuncs[name] = true
+	}
+
+	return sinkFuncs
+}
+
+func (sinkFuncs SinkFuncs) Matches(fn *types.Func, callExpr *ast.CallExpr) bool {
+	for _, name := range sinkFuncNames(fn, callExpr) {
+		if sinkFuncs[name] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StringParamIndexes returns the indexes of the call arguments that are
+// passed as string parameters of the function signature
+func StringParamIndexes(signature *types.Signature, callExpr *ast.CallExpr) []int {
+	var indexes []int
+	params := signature.Params()
+	for i := range callExpr.Args {
+		var paramType types.Type
+		switch {
+		case signature.Variadic() && i >= params.Len()-1:
+			paramType = params.At(params.Len() - 1).Type()
+			if callExpr.Ellipsis == 0 {
+				paramType = paramType.(*types.Slice).Elem()
+			}
+		case i < params.Len():
+			paramType = params.At(i).Type()
+		default:
+			continue
+		}
+
+		if basic, ok := paramType.Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+// InterfaceArgIndexes returns the indexes of the call arguments that are
+// passed as the variadic interface parameter of the function signature, e.g.,
+// the args of fmt.Println(args ...interface{})
+func InterfaceArgIndexes(signature *types.Signature, callExpr *ast.CallExpr) []int {
+	if !signature.Variadic() || callExpr.Ellipsis != 0 {
+		return nil
+	}
+
+	params := signature.Params()
+	if _, ok := params.At(params.Len() - 1).Type().(*types.Slice).Elem().Underlying().(*types.Interface); !ok {
+		return nil
+	}
+
+	var indexes []int
+	for i := params.Len() - 1; i < len(callExpr.Args); i++ {
+		indexes = append(indexes, i)
+	}
+
+	return indexes
+}
+
+func sinkFuncNames(fn *types.Func, callExpr *ast.CallExpr) []string {
+	var names []string
+	if fn != nil {
+		names = append(names, fn.FullName())
+
+		signature, _ := fn.Type().(*types.Signature)
+		if signature != nil && signature.Recv() != nil {
+			recvType := signature.Recv().Type()
+			if pointer, ok := recvType.(*types.Pointer); ok {
+				recvType = pointer.Elem()
+			}
+			if named, ok := recvType.(*types.Named); ok {
+				names = append(names, named.Obj().Name()+"."+fn.Name())
+			}
+		} else if fn.Pkg() != nil {
+			names = append(names, fn.Pkg().Name()+"."+fn.Name())
+		}
+	}
+
+	if selectorExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
+		if ident, ok := selectorExpr.X.(*ast.Ident); ok {
+			names = append(names, ident.Name+"."+selectorExpr.Sel.Name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,312 @@
+package common
+
+import (
+	"go/ast"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+	"unicode"
+)
+
+// TEMPLATE_EXTENSIONS are the extensions of the text/template and
+// html/template files whose strings are extracted and rewritten
+var TEMPLATE_EXTENSIONS = []string{".tmpl", ".gotmpl", ".tpl"}
+
+var TEMPLATE_PACKAGES = []string{"text/template", "html/template"}
+
+var htmlRawTextTagRegexp = regexp.MustCompile(`^<(/?)(script|style)[\s>/]`)
+var htmlEntityRegexp = regexp.MustCompile(`&#?\w+;`)
+
+// TemplateString is a string of a template, the text between its actions
+// and HTML tags, or the string of a {{T "..."}} action when Translated,
+// Offset is the byte offset of the string in the template
+type TemplateString struct {
+	Value      string
+	Offset     int
+	Translated bool
+}
+
+// IsTemplateFile returns whether a file is a template by its extension
+func IsTemplateFile(fileName string) bool {
+	for _, extension := range TEMPLATE_EXTENSIONS {
+		if strings.HasSuffix(fileName, extension) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FindTemplateStrings parses a template and returns its strings in order,
+// the texts with letters, not counting HTML entities, between its actions
+// and HTML tags, outside of <script> and <style>, and the string arguments
+// of the tFuncs actions, e.g., {{T "Hello"}} or {{"Hello" | T}}
+func FindTemplateStrings(text string, tFuncs []string) ([]TemplateString, error) {
+	if len(tFuncs) == 0 {
+		tFuncs = DEFAULT_T_FUNCS
+	}
+
+	tree := parse.New("template")
+	tree.Mode = parse.SkipFuncCheck
+	treeSet := map[string]*parse.Tree{}
+	if _, err := tree.Parse(text, "", "", treeSet); err != nil {
+		return nil, err
+	}
+
+	finder := templateStringsFinder{text: text, tFuncs: tFuncs}
+	names := make([]string, 0, len(treeSet))
+	for name := range treeSet {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		finder.inRawText, finder.inTag, finder.quote = false, false, 0
+		finder.walk(treeSet[name].Root)
+	}
+
+	sort.SliceStable(finder.strings, func(i, j int) bool {
+		return finder.strings[i].Offset < finder.strings[j].Offset
+	})
+	return finder.strings, nil
+}
+
+// RewriteTemplate wraps the texts of a template for which wrap returns true
+// in {{T "..."}} actions and returns the rewritten template with the number
+// of wrapped texts
+func RewriteTemplate(text string, wrap func(string) bool) (string, int, error) {
+	templateStrings, err := FindTemplateStrings(text, nil)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var builder strings.Builder
+	count, offset := 0, 0
+	for _, templateString := range templateStrings {
+		if templateString.Translated || !wrap(templateString.Value) {
+			continue
+		}
+
+		builder.WriteString(text[offset:templateString.Offset])
+		builder.WriteString("{{T " + strconv.Quote(templateString.Value) + "}}")
+		offset = templateString.Offset + len(templateString.Value)
+		count++
+	}
+	builder.WriteString(text[offset:])
+
+	return builder.String(), count, nil
+}
+
+// TemplatePosition returns the line and column of an offset in a template
+// starting at line and column, e.g., a template literal of a Go file
+func TemplatePosition(text string, offset, line, column int) (int, int) {
+	before := text[:offset]
+	if newlines := strings.Count(before, "\n"); newlines > 0 {
+		return line + newlines, offset - strings.LastIndex(before, "\n")
+	}
+
+	return line, column + offset
+}
+
+// TemplateParseLit returns the string literal parsed by a call of the
+// text/template or html/template packages, e.g.,
+// template.New("page").Parse(`<p>Hello</p>`), or nil for other calls
+func TemplateParseLit(callExpr *ast.CallExpr, astFile *ast.File) *ast.BasicLit {
+	fun, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || fun.Sel.Name != "Parse" || len(callExpr.Args) != 1 {
+		return nil
+	}
+
+	basicLit, ok := callExpr.Args[0].(*ast.BasicLit)
+	if !ok {
+		return nil
+	}
+
+	packageNames := templatePackageNames(astFile)
+	for expr := fun.X; ; {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return nil
+		}
+
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil
+		}
+
+		if ident, ok := selector.X.(*ast.Ident); ok && selector.Sel.Name == "New" && packageNames[ident.Name] {
+			return basicLit
+		}
+		expr = selector.X
+	}
+}
+
+func templatePackageNames(astFile *ast.File) map[string]bool {
+	packageNames := make(map[string]bool)
+	for _, importSpec := range astFile.Imports {
+		path, _ := strconv.Unquote(importSpec.Path.Value)
+		for _, templatePackage := range TEMPLATE_PACKAGES {
+			if path != templatePackage {
+				continue
+			}
+
+			if importSpec.Name != nil {
+				packageNames[importSpec.Name.Name] = true
+			} else {
+				packageNames["template"] = true
+			}
+		}
+	}
+
+	return packageNames
+}
+
+type templateStringsFinder struct {
+	text      string
+	tFuncs    []string
+	inRawText bool
+	inTag     bool
+	quote     byte
+	tag       string
+	strings   []TemplateString
+}
+
+func (finder *templateStringsFinder) walk(node parse.Node) {
+	switch x := node.(type) {
+	case *parse.ListNode:
+		if x == nil {
+			return
+		}
+		for _, n := range x.Nodes {
+			finder.walk(n)
+		}
+	case *parse.TextNode:
+		finder.addText(x)
+	case *parse.ActionNode:
+		finder.walkPipe(x.Pipe)
+	case *parse.IfNode:
+		finder.walkBranch(&x.BranchNode)
+	case *parse.RangeNode:
+		finder.walkBranch(&x.BranchNode)
+	case *parse.WithNode:
+		finder.walkBranch(&x.BranchNode)
+	case *parse.TemplateNode:
+		finder.walkPipe(x.Pipe)
+	}
+}
+
+func (finder *templateStringsFinder) walkBranch(branch *parse.BranchNode) {
+	finder.walkPipe(branch.Pipe)
+	finder.walk(branch.List)
+	finder.walk(branch.ElseList)
+}
+
+func (finder *templateStringsFinder) walkPipe(pipe *parse.PipeNode) {
+	if pipe == nil {
+		return
+	}
+
+	for i, cmd := range pipe.Cmds {
+		if finder.isTFunc(cmd.Args[0]) {
+			if len(cmd.Args) > 1 {
+				finder.addTranslated(cmd.Args[1])
+			} else if i > 0 && len(pipe.Cmds[i-1].Args) == 1 {
+				finder.addTranslated(pipe.Cmds[i-1].Args[0])
+			}
+		}
+
+		for _, arg := range cmd.Args {
+			if argPipe, ok := arg.(*parse.PipeNode); ok {
+				finder.walkPipe(argPipe)
+			}
+		}
+	}
+}
+
+func (finder *templateStringsFinder) isTFunc(node parse.Node) bool {
+	ident, ok := node.(*parse.IdentifierNode)
+	if !ok {
+		return false
+	}
+
+	for _, tFunc := range finder.tFuncs {
+		if ident.Ident == tFunc {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (finder *templateStringsFinder) addTranslated(node parse.Node) {
+	if stringNode, ok := node.(*parse.StringNode); ok {
+		finder.strings = append(finder.strings, TemplateString{Value: stringNode.Text, Offset: int(stringNode.Pos), Translated: true})
+	}
+}
+
+// addText adds the texts between the HTML tags of a text node, the text of
+// the node may be trimmed by {{- and -}} so it is searched from its position,
+// a tag may span several text nodes when its attributes have actions, e.g.,
+// <a href="{{.URL}}" title="Open it">, so the scanner state is kept between
+// the nodes
+func (finder *templateStringsFinder) addText(textNode *parse.TextNode) {
+	text := string(textNode.Text)
+	offset := int(textNode.Pos)
+	if index := strings.Index(finder.text[offset:], text); index >= 0 {
+		offset += index
+	}
+
+	start := 0
+	for i := 0; i < len(text); i++ {
+		switch {
+		case !finder.inTag:
+			if isHTMLTagStart(text, i) {
+				finder.addSegment(text[start:i], offset+start)
+				finder.inTag = true
+				finder.tag = ""
+				start = i
+			}
+		case finder.quote != 0:
+			if text[i] == finder.quote {
+				finder.quote = 0
+			}
+		case text[i] == '"' || text[i] == '\'':
+			finder.quote = text[i]
+		case text[i] == '>':
+			finder.tag += text[start : i+1]
+			if matches := htmlRawTextTagRegexp.FindStringSubmatch(finder.tag); matches != nil {
+				finder.inRawText = matches[1] == ""
+			}
+			finder.inTag = false
+			start = i + 1
+		}
+	}
+
+	if finder.inTag {
+		finder.tag += text[start:]
+		return
+	}
+	finder.addSegment(text[start:], offset+start)
+}
+
+// isHTMLTagStart returns whether a < starts a tag, a comment or a
+// declaration, not a < in a text, e.g., "1 < 2"
+func isHTMLTagStart(text string, i int) bool {
+	if text[i] != '<' || i+1 >= len(text) {
+		return false
+	}
+
+	next := rune(text[i+1])
+	return next == '/' || next == '!' || next == '?' || unicode.IsLetter(next)
+}
+
+func (finder *templateStringsFinder) addSegment(segment string, offset int) {
+	value := strings.TrimSpace(segment)
+	if finder.inRawText || strings.IndexFunc(htmlEntityRegexp.ReplaceAllString(value, ""), unicode.IsLetter) < 0 {
+		return
+	}
+
+	offset += strings.Index(segment, value)
+	finder.strings = append(finder.strings, TemplateString{Value: value, Offset: offset})
+}
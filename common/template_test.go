@@ -0,0 +1,96 @@
+package common
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+func TestFindTemplateStrings(t *testing.T) {
+	text := `<h1>{{T "Welcome"}}</h1>
+{{define "body"}}<p class="intro">
+  Hello {{.Name}}, you have {{"new messages" | T}}
+</p>{{end}}
+{{- if .Admin }}  Admin tools {{- else}}<b>123</b>{{end}}
+<script>var title = "Not a string";</script><em>Bye</em>&copy; 2024
+<a href="{{.URL}}" title="Open it">Click here</a> 1 < 2 apples`
+
+	templateStrings, err := FindTemplateStrings(text, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var values []string
+	for _, templateString := range templateStrings {
+		values = append(values, templateString.Value)
+		if !templateString.Translated && text[templateString.Offset:templateString.Offset+len(templateString.Value)] != templateString.Value {
+			t.Errorf("the offset %d of %q is wrong", templateString.Offset, templateString.Value)
+		}
+	}
+
+	expected := []string{"Welcome", "Hello", ", you have", "new messages", "Admin tools", "Bye", "Click here", "1 < 2 apples"}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("FindTemplateStrings = %q, expected %q", values, expected)
+	}
+
+	if _, err := FindTemplateStrings("{{if}}", nil); err == nil {
+		t.Error("FindTemplateStrings of an invalid template did not fail")
+	}
+}
+
+func TestRewriteTemplate(t *testing.T) {
+	text := "<p>Hello {{.Name}}</p>\n<p>{{T \"Bye\"}} Skipped</p>"
+
+	rewritten, count, err := RewriteTemplate(text, func(s string) bool { return s != "Skipped" })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "<p>{{T \"Hello\"}} {{.Name}}</p>\n<p>{{T \"Bye\"}} Skipped</p>"
+	if rewritten != expected || count != 1 {
+		t.Errorf("RewriteTemplate = %q, %d, expected %q, 1", rewritten, count, expected)
+	}
+}
+
+func TestTemplatePosition(t *testing.T) {
+	text := "<p>\n  Hello</p>"
+	if line, column := TemplatePosition(text, 6, 3, 10); line != 4 || column != 3 {
+		t.Errorf("TemplatePosition = %d:%d, expected 4:3", line, column)
+	}
+	if line, column := TemplatePosition(text, 1, 3, 10); line != 3 || column != 11 {
+		t.Errorf("TemplatePosition = %d:%d, expected 3:11", line, column)
+	}
+}
+
+func TestTemplateParseLit(t *testing.T) {
+	src := `package app
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+)
+
+var page = htmltemplate.Must(htmltemplate.New("page").Funcs(nil).Parse("<p>Hello</p>"))
+var other = fmt.Sprint("Not a template")
+`
+	astFile, err := parser.ParseFile(token.NewFileSet(), "app.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var lits []string
+	ast.Inspect(astFile, func(n ast.Node) bool {
+		if callExpr, ok := n.(*ast.CallExpr); ok {
+			if basicLit := TemplateParseLit(callExpr, astFile); basicLit != nil {
+				lits = append(lits, basicLit.Value)
+			}
+		}
+		return true
+	})
+
+	if !reflect.DeepEqual(lits, []string{`"<p>Hello</p>"`}) {
+		t.Errorf("TemplateParseLit found %q", lits)
+	}
+}
@@ -0,0 +1,413 @@
+package common
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	XLIFF_VERSION_12 = "1.2"
+	XLIFF_VERSION_20 = "2.0"
+
+	XLIFF_NAMESPACE_12 = "urn:oasis:names:tc:xliff:document:1.2"
+	XLIFF_NAMESPACE_20 = "urn:oasis:names:tc:xliff:document:2.0"
+
+	// states of the units, independent of the XLIFF version
+	XLIFF_STATE_NEW          = "new"
+	XLIFF_STATE_NEEDS_REVIEW = "needs-review"
+	XLIFF_STATE_TRANSLATED   = "translated"
+	XLIFF_STATE_FINAL        = "final"
+)
+
+// XliffFile is the content of an XLIFF 1.2 or 2.0 document with one file
+type XliffFile struct {
+	Version        string
+	Original       string
+	SourceLanguage string
+	TargetLanguage string
+	Units          []XliffUnit
+}
+
+// XliffUnit is a translation unit, its ID is the id of the translation and
+// Source and Target are plain strings where templated {{.Arg}} placeholders
+// are written as protected inline elements, Notes are the locations of the
+// string and Comments the notes for the translators
+type XliffUnit struct {
+	ID        string
+	Source    string
+	Target    string
+	HasTarget bool
+	State     string
+	Notes     []string
+	Comments  []string
+}
+
+// Modified returns whether the unit's target needs to be translated again
+func (unit XliffUnit) Modified() bool {
+	return !unit.HasTarget || (unit.State != XLIFF_STATE_TRANSLATED && unit.State != XLIFF_STATE_FINAL)
+}
+
+func NewXliffFile(version, original, sourceLanguage, targetLanguage string) (*XliffFile, error) {
+	if version != XLIFF_VERSION_12 && version != XLIFF_VERSION_20 {
+		return nil, fmt.Errorf("i18n4go: unsupported XLIFF version: %s, use %s or %s", version, XLIFF_VERSION_12, XLIFF_VERSION_20)
+	}
+
+	return &XliffFile{
+		Version:        version,
+		Original:       original,
+		SourceLanguage: sourceLanguage,
+		TargetLanguage: targetLanguage,
+	}, nil
+}
+
+func (xliff *XliffFile) Save(fileName string) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = xliff.WriteTo(file)
+	return err
+}
+
+func (xliff *XliffFile) WriteTo(w io.Writer) (int64, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(xml.Header)
+
+	if xliff.Version == XLIFF_VERSION_20 {
+		xliff.write20(&buffer)
+	} else {
+		xliff.write12(&buffer)
+	}
+
+	n, err := w.Write(buffer.Bytes())
+	return int64(n), err
+}
+
+func (xliff *XliffFile) write12(buffer *bytes.Buffer) {
+	buffer.WriteString(`<xliff version="1.2" xmlns="` + XLIFF_NAMESPACE_12 + `">` + "\n")
+	buffer.WriteString(`  <file original="` + escapeXml(xliff.Original) + `" source-language="` + escapeXml(xliff.SourceLanguage) +
+		`" target-language="` + escapeXml(xliff.TargetLanguage) + `" datatype="plaintext">` + "\n")
+	buffer.WriteString("    <body>\n")
+	for _, unit := range xliff.Units {
+		buffer.WriteString(`      <trans-unit id="` + escapeXml(unit.ID) + `" xml:space="preserve">` + "\n")
+		buffer.WriteString("        <source>" + xliffInline(unit.Source, XLIFF_VERSION_12) + "</source>\n")
+		if unit.HasTarget {
+			buffer.WriteString(`        <target state="` + xliffState12(unit.State) + `">` + xliffInline(unit.Target, XLIFF_VERSION_12) + "</target>\n")
+		}
+		for _, note := range unit.Notes {
+			buffer.WriteString(`        <note from="i18n4go">` + escapeXml(note) + "</note>\n")
+		}
+		for _, comment := range unit.Comments {
+			buffer.WriteString(`        <note from="developer">` + escapeXml(comment) + "</note>\n")
+		}
+		buffer.WriteString("      </trans-unit>\n")
+	}
+	buffer.WriteString("    </body>\n")
+	buffer.WriteString("  </file>\n")
+	buffer.WriteString("</xliff>\n")
+}
+
+func (xliff *XliffFile) write20(buffer *bytes.Buffer) {
+	buffer.WriteString(`<xliff version="2.0" xmlns="` + XLIFF_NAMESPACE_20 + `" srcLang="` + escapeXml(xliff.SourceLanguage) +
+		`" trgLang="` + escapeXml(xliff.TargetLanguage) + `">` + "\n")
+	buffer.WriteString(`  <file id="f1" original="` + escapeXml(xliff.Original) + `">` + "\n")
+	for _, unit := range xliff.Units {
+		// unit ids are NMTOKENs, so the translation id is kept in the name
+		buffer.WriteString(`    <unit id="` + XliffUnitID(unit.ID) + `" name="` + escapeXml(unit.ID) + `">` + "\n")
+		if len(unit.Notes) > 0 || len(unit.Comments) > 0 {
+			buffer.WriteString("      <notes>\n")
+			for _, note := range unit.Notes {
+				buffer.WriteString(`        <note category="location">` + escapeXml(note) + "</note>\n")
+			}
+			for _, comment := range unit.Comments {
+				buffer.WriteString(`        <note category="description">` + escapeXml(comment) + "</note>\n")
+			}
+			buffer.WriteString("      </notes>\n")
+		}
+
+		state := unit.State
+		if !unit.HasTarget {
+			state = XLIFF_STATE_NEW
+		}
+		buffer.WriteString(`      <segment state="` + xliffState20(state) + `">` + "\n")
+		buffer.WriteString(`        <source xml:space="preserve">` + xliffInline(unit.Source, XLIFF_VERSION_20) + "</source>\n")
+		if unit.HasTarget {
+			buffer.WriteString(`        <target xml:space="preserve">` + xliffInline(unit.Target, XLIFF_VERSION_20) + "</target>\n")
+		}
+		buffer.WriteString("      </segment>\n")
+		buffer.WriteString("    </unit>\n")
+	}
+	buffer.WriteString("  </file>\n")
+	buffer.WriteString("</xliff>\n")
+}
+
+// XliffUnitID returns a stable NMTOKEN id for a translation id
+func XliffUnitID(id string) string {
+	sum := sha1.Sum([]byte(id))
+	return "u" + hex.EncodeToString(sum[:])[:16]
+}
+
+func xliffState12(state string) string {
+	switch state {
+	case XLIFF_STATE_NEW:
+		return "new"
+	case XLIFF_STATE_NEEDS_REVIEW:
+		return "needs-review-translation"
+	case XLIFF_STATE_FINAL:
+		return "final"
+	}
+
+	return "translated"
+}
+
+func xliffState20(state string) string {
+	switch state {
+	case XLIFF_STATE_NEW, XLIFF_STATE_NEEDS_REVIEW:
+		return "initial"
+	case XLIFF_STATE_FINAL:
+		return "final"
+	}
+
+	return "translated"
+}
+
+func parseXliffState(state string) string {
+	switch state {
+	case "new", "initial", "":
+		return XLIFF_STATE_NEW
+	case "translated", "reviewed", "signed-off":
+		return XLIFF_STATE_TRANSLATED
+	case "final":
+		return XLIFF_STATE_FINAL
+	}
+
+	// needs-translation, needs-adaptation, needs-l10n, needs-review-*
+	return XLIFF_STATE_NEEDS_REVIEW
+}
+
+// xliffInline escapes a string and writes its templated placeholders as
+// protected inline elements, i.e., <ph> elements in both versions
+func xliffInline(s string, version string) string {
+	re, err := getTemplatedStringRegexp()
+	if err != nil {
+		return escapeXml(s)
+	}
+
+	var builder strings.Builder
+	last := 0
+	for i, match := range re.FindAllStringIndex(s, -1) {
+		builder.WriteString(escapeXml(s[last:match[0]]))
+
+		placeholder := escapeXml(s[match[0]:match[1]])
+		id := strconv.Itoa(i + 1)
+		if version == XLIFF_VERSION_20 {
+			builder.WriteString(`<ph id="` + id + `" canCopy="yes" canDelete="no" equiv="` + placeholder + `" disp="` + placeholder + `"/>`)
+		} else {
+			builder.WriteString(`<ph id="` + id + `" ctype="x-go-template">` + placeholder + `</ph>`)
+		}
+		last = match[1]
+	}
+	builder.WriteString(escapeXml(s[last:]))
+
+	return builder.String()
+}
+
+func escapeXml(s string) string {
+	var buffer bytes.Buffer
+	xml.EscapeText(&buffer, []byte(s))
+	return buffer.String()
+}
+
+type xliffInlineContent struct {
+	InnerXML string `xml:",innerxml"`
+}
+
+type xliffDocument struct {
+	Version string `xml:"version,attr"`
+	SrcLang string `xml:"srcLang,attr"`
+	TrgLang string `xml:"trgLang,attr"`
+	Files   []struct {
+		Original       string `xml:"original,attr"`
+		SourceLanguage string `xml:"source-language,attr"`
+		TargetLanguage string `xml:"target-language,attr"`
+		TransUnits     []struct {
+			ID     string             `xml:"id,attr"`
+			Source xliffInlineContent `xml:"source"`
+			Target *xliffTarget12     `xml:"target"`
+			Notes  []string           `xml:"note"`
+		} `xml:"body>trans-unit"`
+		Units []struct {
+			ID       string   `xml:"id,attr"`
+			Name     string   `xml:"name,attr"`
+			Notes    []string `xml:"notes>note"`
+			Segments []struct {
+				State  string              `xml:"state,attr"`
+				Source xliffInlineContent  `xml:"source"`
+				Target *xliffInlineContent `xml:"target"`
+			} `xml:"segment"`
+		} `xml:"unit"`
+	} `xml:"file"`
+}
+
+type xliffTarget12 struct {
+	State    string `xml:"state,attr"`
+	InnerXML string `xml:",innerxml"`
+}
+
+func ReadXliffFile(fileName string) (*XliffFile, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	xliff, err := ParseXliff(content)
+	if err != nil {
+		return nil, fmt.Errorf("i18n4go: could not parse %s: %s", fileName, err.Error())
+	}
+
+	return xliff, nil
+}
+
+// ParseXliff parses an XLIFF 1.2 or 2.0 document, the units of all its files
+// are returned together
+func ParseXliff(content []byte) (*XliffFile, error) {
+	var document xliffDocument
+	err := xml.Unmarshal(content, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	xliff, err := NewXliffFile(document.Version, "", document.SrcLang, document.TrgLang)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range document.Files {
+		if xliff.Original == "" {
+			xliff.Original = file.Original
+		}
+		if file.SourceLanguage != "" {
+			xliff.SourceLanguage = file.SourceLanguage
+		}
+		if file.TargetLanguage != "" {
+			xliff.TargetLanguage = file.TargetLanguage
+		}
+
+		for _, transUnit := range file.TransUnits {
+			unit := XliffUnit{ID: transUnit.ID, Notes: transUnit.Notes, State: XLIFF_STATE_NEW}
+			unit.Source, err = parseXliffInline(transUnit.Source.InnerXML)
+			if err != nil {
+				return nil, fmt.Errorf("trans-unit %q: %s", transUnit.ID, err.Error())
+			}
+
+			if transUnit.Target != nil {
+				unit.HasTarget = true
+				unit.State = parseXliffState(transUnit.Target.State)
+				unit.Target, err = parseXliffInline(transUnit.Target.InnerXML)
+				if err != nil {
+					return nil, fmt.Errorf("trans-unit %q: %s", transUnit.ID, err.Error())
+				}
+			}
+
+			xliff.Units = append(xliff.Units, unit)
+		}
+
+		for _, xliffUnit := range file.Units {
+			unit := XliffUnit{ID: xliffUnit.Name, Notes: xliffUnit.Notes, State: XLIFF_STATE_NEW}
+			if unit.ID == "" {
+				unit.ID = xliffUnit.ID
+			}
+
+			states := []string{}
+			for _, segment := range xliffUnit.Segments {
+				source, err := parseXliffInline(segment.Source.InnerXML)
+				if err != nil {
+					return nil, fmt.Errorf("unit %q: %s", unit.ID, err.Error())
+				}
+				unit.Source += source
+
+				if segment.Target != nil {
+					target, err := parseXliffInline(segment.Target.InnerXML)
+					if err != nil {
+						return nil, fmt.Errorf("unit %q: %s", unit.ID, err.Error())
+					}
+					unit.Target += target
+					unit.HasTarget = true
+				}
+				states = append(states, parseXliffState(segment.State))
+			}
+
+			// the least advanced state of the segments
+			for _, state := range []string{XLIFF_STATE_FINAL, XLIFF_STATE_TRANSLATED, XLIFF_STATE_NEEDS_REVIEW, XLIFF_STATE_NEW} {
+				for _, segmentState := range states {
+					if segmentState == state {
+						unit.State = state
+					}
+				}
+			}
+
+			xliff.Units = append(xliff.Units, unit)
+		}
+	}
+
+	return xliff, nil
+}
+
+// parseXliffInline returns the text of source or target content, inline
+// placeholders are replaced by their original text
+func parseXliffInline(innerXML string) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader(innerXML))
+
+	var builder strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch element := token.(type) {
+		case xml.CharData:
+			builder.Write(element)
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "ph", "x":
+				placeholder := ""
+				for _, attr := range element.Attr {
+					if attr.Name.Local == "equiv" || attr.Name.Local == "equiv-text" || (attr.Name.Local == "disp" && placeholder == "") {
+						placeholder = attr.Value
+					}
+				}
+
+				if placeholder == "" {
+					// XLIFF 1.2 <ph> elements contain the native code
+					var content string
+					err = decoder.DecodeElement(&content, &element)
+					if err != nil {
+						return "", err
+					}
+					placeholder = content
+				}
+
+				builder.WriteString(placeholder)
+			case "mrk", "g", "pc", "sm", "em", "bpt", "ept", "it":
+				// markers and paired codes, keep their text
+			default:
+				return "", fmt.Errorf("unsupported inline element: %s", element.Name.Local)
+			}
+		}
+	}
+
+	return builder.String(), nil
+}
@@ -33,8 +33,8 @@ var SUPPORTED_LOCALES = map[string]string{
 	"es": "es_ES",
 	"fr": "fr_FR",
 	"it": "it_IT",
-	"ja": "ja_JA",
-	//"ko": "ko_KO", - Will add support for Korean when EverlongProject/go-i18n supports Korean
+	"ja": "ja_JP",
+	//"ko": "ko_KR", - Will add support for Korean when EverlongProject/go-i18n supports Korean
 	"pt": "pt_BR",
 	//"ru": "ru_RU", - Will add support for Russian when EverlongProject/go-i18n supports Russian
 	"zh": "zh_Hans",
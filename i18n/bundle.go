@@ -0,0 +1,258 @@
+package i18n
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	go_i18n "github.com/EverlongProject/go-i18n/i18n"
+	"github.com/EverlongProject/go-i18n/i18n/bundle"
+	"golang.org/x/text/language"
+)
+
+// Bundle has the translations of all the locales of a package, unlike Init
+// it does not use the process-global translations so that servers can
+// translate each request in its own language, it is safe for concurrent use
+type Bundle struct {
+	fsys        fs.FS
+	packageName string
+
+	// reloadMutex serializes the reloads, lookups only load the state
+	reloadMutex sync.Mutex
+	assets      map[string]bundleAsset
+	broken      map[string]string
+	state       atomic.Pointer[bundleState]
+
+	missingHandler atomic.Value
+}
+
+// bundleAsset is the translation file of a locale, as last loaded
+type bundleAsset struct {
+	language string
+	content  []byte
+}
+
+// bundleState is what the lookups use, a reload replaces it as a whole
+type bundleState struct {
+	matcher     *localeMatcher
+	translators map[string]go_i18n.TranslateFunc
+	ids         map[string]map[string]bool
+}
+
+type contextKey struct{}
+
+// NewBundle loads the translations of all the locales of a package from a
+// file system laid out as for InitFS, DEFAULT_LOCALE is required, the other
+// locales whose files cannot be parsed are left out of the bundle and
+// reported by the next Reload
+func NewBundle(fsys fs.FS, packageName string) (*Bundle, error) {
+	b := &Bundle{fsys: fsys, packageName: packageName}
+
+	_, err := b.Reload()
+	if b.state.Load() == nil {
+		return nil, err
+	}
+	b.broken = nil
+
+	return b, nil
+}
+
+// Reload loads the translation files that changed since the last load and
+// returns their locales, files that cannot be parsed are reported in the
+// error and keep their last translations, the new translations replace the
+// current ones at once
+func (b *Bundle) Reload() ([]string, error) {
+	b.reloadMutex.Lock()
+	defer b.reloadMutex.Unlock()
+
+	available, err := availableLocales(b.fsys, b.packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	var changedLocales []string
+	var errs []error
+	assets := make(map[string]bundleAsset, len(available))
+	broken := make(map[string]string)
+	for locale, language := range available {
+		oldAsset, loaded := b.assets[locale]
+
+		assetKey, content, err := readAsset(b.fsys, b.packageName, locale, language)
+		if err == nil && loaded && oldAsset.language == language && bytes.Equal(oldAsset.content, content) {
+			assets[locale] = oldAsset
+			continue
+		}
+
+		if err == nil {
+			err = parseAsset(bundle.New().ParseTranslationFileBytes, assetKey, locale, content)
+		}
+
+		if err != nil {
+			// the same error is only reported once
+			if b.broken[locale] != err.Error() {
+				errs = append(errs, err)
+			}
+			broken[locale] = err.Error()
+
+			if loaded {
+				assets[locale] = oldAsset
+			}
+			continue
+		}
+
+		assets[locale] = bundleAsset{language: language, content: content}
+		changedLocales = append(changedLocales, locale)
+	}
+
+	for locale, oldAsset := range b.assets {
+		if _, ok := available[locale]; ok {
+			continue
+		}
+
+		if locale == DEFAULT_LOCALE {
+			errs = append(errs, fmt.Errorf("Could not find i18n asset for locale %s of package %s", DEFAULT_LOCALE, b.packageName))
+			assets[locale] = oldAsset
+			continue
+		}
+		changedLocales = append(changedLocales, locale)
+	}
+	b.broken = broken
+
+	if _, ok := assets[DEFAULT_LOCALE]; !ok {
+		return nil, errors.Join(append(errs, fmt.Errorf("Could not find i18n asset for locale %s of package %s", DEFAULT_LOCALE, b.packageName))...)
+	}
+
+	if len(changedLocales) == 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	state, err := newBundleState(assets)
+	if err != nil {
+		return nil, errors.Join(append(errs, err)...)
+	}
+
+	b.assets = assets
+	b.state.Store(state)
+	sort.Strings(changedLocales)
+
+	return changedLocales, errors.Join(errs...)
+}
+
+func newBundleState(assets map[string]bundleAsset) (*bundleState, error) {
+	translations := bundle.New()
+	available := make(map[string]string, len(assets))
+	for locale, asset := range assets {
+		err := translations.ParseTranslationFileBytes(locale+".all.json", asset.content)
+		if err != nil {
+			return nil, err
+		}
+		available[locale] = asset.language
+	}
+
+	translators := make(map[string]go_i18n.TranslateFunc, len(assets))
+	ids := make(map[string]map[string]bool, len(assets))
+	for locale := range assets {
+		T, err := translations.Tfunc(locale, DEFAULT_LOCALE)
+		if err != nil {
+			return nil, err
+		}
+		translators[locale] = go_i18n.TranslateFunc(T)
+
+		_, language, err := translations.TfuncAndLanguage(locale)
+		if err != nil {
+			return nil, err
+		}
+		ids[locale] = idSet(translations.LanguageTranslationIDs(language.Tag))
+	}
+
+	return &bundleState{matcher: newLocaleMatcher(available), translators: translators, ids: ids}, nil
+}
+
+func (state *bundleState) match(tags ...language.Tag) string {
+	locale, ok := state.matcher.match(tags...)
+	if !ok {
+		return DEFAULT_LOCALE
+	}
+
+	return locale
+}
+
+// Locales returns the locales of the bundle, e.g., fr_FR
+func (b *Bundle) Locales() []string {
+	translators := b.state.Load().translators
+
+	locales := make([]string, 0, len(translators))
+	for locale := range translators {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	return locales
+}
+
+// Match returns the locale that best matches the first of the tags that has
+// a match, else DEFAULT_LOCALE
+func (b *Bundle) Match(tags ...language.Tag) string {
+	return b.state.Load().match(tags...)
+}
+
+// Translator returns the translate func of the locale matching the tags,
+// see Match, strings it misses use the DEFAULT_LOCALE translations
+func (b *Bundle) Translator(tags ...language.Tag) go_i18n.TranslateFunc {
+	state := b.state.Load()
+
+	return b.translator(state, state.match(tags...))
+}
+
+// SetMissingHandler sets the handler of the strings missing in the locale
+// of the translators the bundle returns from then on, nil removes it
+func (b *Bundle) SetMissingHandler(handler MissingHandler) {
+	b.missingHandler.Store(handler)
+}
+
+func (b *Bundle) translator(state *bundleState, locale string) go_i18n.TranslateFunc {
+	handler, _ := b.missingHandler.Load().(MissingHandler)
+	if handler == nil {
+		return state.translators[locale]
+	}
+
+	return reportMissing(state.translators[locale], locale, state.ids[locale], state.ids[DEFAULT_LOCALE], handler)
+}
+
+// Middleware negotiates the language of each request from its
+// Accept-Language header and stores its translator in the request context,
+// see FromContext
+func (b *Bundle) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tags, _, _ := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+		state := b.state.Load()
+		locale := state.match(tags...)
+
+		w.Header().Add("Vary", "Accept-Language")
+		w.Header().Set("Content-Language", strings.Replace(locale, "_", "-", -1))
+
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), b.translator(state, locale))))
+	})
+}
+
+// NewContext returns a copy of the context that carries the translator
+func NewContext(ctx context.Context, T go_i18n.TranslateFunc) context.Context {
+	return context.WithValue(ctx, contextKey{}, T)
+}
+
+// FromContext returns the translator of the context, strings are not
+// translated when it has none
+func FromContext(ctx context.Context) go_i18n.TranslateFunc {
+	if T, ok := ctx.Value(contextKey{}).(go_i18n.TranslateFunc); ok {
+		return T
+	}
+
+	return go_i18n.IdentityTfunc()
+}
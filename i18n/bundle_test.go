@@ -0,0 +1,228 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+var bundleFS = fstest.MapFS{
+	"en/api/en_US.all.json": {Data: []byte(`[{"id": "Hello", "translation": "Hello"}, {"id": "Bye", "translation": "Bye"}]`)},
+	"fr/api/fr_FR.all.json": {Data: []byte(`[{"id": "Hello", "translation": "Bonjour"}]`)},
+	"de/api/de_DE.all.json": {Data: []byte(`[{"id": "Hello", "translation": "Hallo"}]`)},
+}
+
+func TestBundleTranslator(t *testing.T) {
+	bundle, err := NewBundle(bundleFS, "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if locales := bundle.Locales(); len(locales) != 3 || locales[0] != "de_DE" {
+		t.Fatalf("unexpected locales: %v", locales)
+	}
+
+	T := bundle.Translator(language.MustParse("ko"), language.MustParse("de-AT"))
+	if translation := T("Hello"); translation != "Hallo" {
+		t.Errorf("expected Hallo, got %s", translation)
+	}
+	if translation := T("Bye"); translation != "Bye" {
+		t.Errorf("expected the en_US translation Bye, got %s", translation)
+	}
+
+	if translation := bundle.Translator()("Hello"); translation != "Hello" {
+		t.Errorf("expected the en_US translation Hello, got %s", translation)
+	}
+}
+
+func TestNewBundleWithoutDefaultLocale(t *testing.T) {
+	_, err := NewBundle(fstest.MapFS{"fr/api/fr_FR.all.json": bundleFS["fr/api/fr_FR.all.json"]}, "api")
+	if err == nil {
+		t.Fatal("expected an error without en_US translations")
+	}
+}
+
+func TestNewBundleWithBrokenLocale(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en/api/en_US.all.json": bundleFS["en/api/en_US.all.json"],
+		"fr/api/fr_FR.all.json": bundleFS["fr/api/fr_FR.all.json"],
+		"de/api/de_DE.all.json": {Data: []byte(`[{"id": "Hello",`)},
+	}
+
+	bundle, err := NewBundle(fsys, "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if locales := bundle.Locales(); len(locales) != 2 || locales[0] != "en_US" || locales[1] != "fr_FR" {
+		t.Fatalf("expected the en_US and fr_FR locales, got %v", locales)
+	}
+	if translation := bundle.Translator(language.MustParse("de"))("Hello"); translation != "Hello" {
+		t.Errorf("expected the en_US translation Hello, got %s", translation)
+	}
+	if _, err := bundle.Reload(); err == nil {
+		t.Error("expected the next reload to report the broken de_DE file")
+	}
+
+	fsys["en/api/en_US.all.json"] = &fstest.MapFile{Data: []byte(`[{"id": "Hello",`)}
+	if _, err := NewBundle(fsys, "api"); err == nil {
+		t.Fatal("expected an error with broken en_US translations")
+	}
+}
+
+func TestBundleMiddleware(t *testing.T) {
+	bundle, err := NewBundle(bundleFS, "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(bundle.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(FromContext(r.Context())("Hello")))
+	})))
+	defer server.Close()
+
+	testCases := map[string]string{
+		"fr-CH, en;q=0.5":  "Bonjour",
+		"ko, de;q=0.8":     "Hallo",
+		"":                 "Hello",
+		"not a language!!": "Hello",
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for acceptLanguage, expected := range testCases {
+			wg.Add(1)
+			go func(acceptLanguage, expected string) {
+				defer wg.Done()
+
+				request, _ := http.NewRequest("GET", server.URL, nil)
+				request.Header.Set("Accept-Language", acceptLanguage)
+				response, err := http.DefaultClient.Do(request)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				defer response.Body.Close()
+
+				var body [64]byte
+				n, _ := response.Body.Read(body[:])
+				if string(body[:n]) != expected {
+					t.Errorf("Accept-Language %q: expected %s, got %s", acceptLanguage, expected, body[:n])
+				}
+			}(acceptLanguage, expected)
+		}
+	}
+	wg.Wait()
+}
+
+func TestFromContextWithoutTranslator(t *testing.T) {
+	if translation := FromContext(context.Background())("Hello"); translation != "Hello" {
+		t.Errorf("expected Hello, got %s", translation)
+	}
+}
+
+func TestBundleReload(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for fileName, file := range bundleFS {
+		fsys[fileName] = &fstest.MapFile{Data: file.Data}
+	}
+
+	bundle, err := NewBundle(fsys, "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	T := func(locale string) string {
+		return bundle.Translator(language.MustParse(locale))("Hello")
+	}
+
+	locales, err := bundle.Reload()
+	if err != nil || len(locales) != 0 {
+		t.Fatalf("expected nothing to reload, got %v, %v", locales, err)
+	}
+
+	fsys["fr/api/fr_FR.all.json"] = &fstest.MapFile{Data: []byte(`[{"id": "Hello", "translation": "Salut"}]`)}
+	fsys["de/api/de_DE.all.json"] = &fstest.MapFile{Data: []byte(`[{"id": "Hello",`)}
+	fsys["es/api/es_ES.all.json"] = &fstest.MapFile{Data: []byte(`[{"id": "Hello", "translation": "Hola"}]`)}
+
+	locales, err = bundle.Reload()
+	if err == nil {
+		t.Fatal("expected an error for the broken de_DE file")
+	}
+	if len(locales) != 2 || locales[0] != "es_ES" || locales[1] != "fr_FR" {
+		t.Fatalf("expected es_ES and fr_FR to be reloaded, got %v", locales)
+	}
+	if T("fr") != "Salut" || T("es") != "Hola" || T("de") != "Hallo" {
+		t.Fatalf("unexpected translations after reload: %s, %s, %s", T("fr"), T("es"), T("de"))
+	}
+
+	_, err = bundle.Reload()
+	if err != nil {
+		t.Fatalf("expected the broken de_DE file to be reported once, got %v", err)
+	}
+
+	delete(fsys, "en/api/en_US.all.json")
+	_, err = bundle.Reload()
+	if err == nil || bundle.Translator()("Bye") != "Bye" {
+		t.Fatalf("expected the en_US translations to be kept, got %v", err)
+	}
+}
+
+func TestBundleWatch(t *testing.T) {
+	dir := t.TempDir()
+	for fileName, file := range bundleFS {
+		writeFile(t, filepath.Join(dir, fileName), file.Data)
+	}
+
+	bundle, err := NewBundle(os.DirFS(dir), "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := make(chan []string, 1)
+	stop := bundle.Watch(WatchOptions{Interval: 10 * time.Millisecond, OnReload: func(locales []string) {
+		reloaded <- locales
+	}})
+	defer stop()
+
+	writeFile(t, filepath.Join(dir, "fr", "api", "fr_FR.all.json"), []byte(`[{"id": "Hello", "translation": "Salut"}]`))
+
+	select {
+	case locales := <-reloaded:
+		if len(locales) != 1 || locales[0] != "fr_FR" {
+			t.Fatalf("expected fr_FR to be reloaded, got %v", locales)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("fr_FR was not reloaded")
+	}
+
+	if translation := bundle.Translator(language.French)("Hello"); translation != "Salut" {
+		t.Errorf("expected Salut, got %s", translation)
+	}
+}
+
+// writeFile writes the file at once, as editors do, so the watcher never
+// reads it half written
+func writeFile(t *testing.T, fileName string, content []byte) {
+	err := os.MkdirAll(filepath.Dir(fileName), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(fileName+".tmp", content, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Rename(fileName+".tmp", fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -3,12 +3,10 @@ package i18n
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
-	"strings"
-
-	"github.com/pivotal-cf-experimental/jibber_jabber"
 
 	go_i18n "github.com/EverlongProject/go-i18n/i18n"
 )
@@ -18,18 +16,6 @@ const (
 	DEFAULT_LANGUAGE = "en"
 )
 
-var SUPPORTED_LOCALES = map[string]string{
-	"de": "de_DE",
-	"en": "en_US",
-	"es": "es_ES",
-	"fr": "fr_FR",
-	"it": "it_IT",
-	"ja": "ja_JA",
-	"ko": "ko_KO",
-	"pt": "pt_BR",
-	"ru": "ru_RU",
-	"zh": "zh_CN",
-}
 var RESOUCES_PATH = filepath.Join("cf", "i18n", "resources")
 
 func GetResourcesPath() string {
@@ -37,101 +23,103 @@ func GetResourcesPath() string {
 }
 
 func Init(packageName string, i18nDirname string) go_i18n.TranslateFunc {
-	userLocale, err := initWithUserLocale(packageName, i18nDirname)
-	if err != nil {
-		userLocale = mustLoadDefaultLocale(packageName, i18nDirname)
+	if i18nDirname == "" {
+		i18nDirname = "."
 	}
 
-	T, err := go_i18n.Tfunc(userLocale, DEFAULT_LOCALE)
+	T, err := InitWithOptions(Options{FS: os.DirFS(i18nDirname), PackageName: packageName})
 	if err != nil {
-		panic(err)
+		panic("Could not load en_US language files. God save the queen. " + err.Error())
 	}
 
 	return T
 }
 
-func initWithUserLocale(packageName, i18nDirname string) (string, error) {
-	userLocale, err := jibber_jabber.DetectIETF()
+// InitFS is Init for translations in a file system such as an embed.FS, the
+// files are <language>/<packageName>/<locale>.all.json from its root, it
+// returns an error when neither the user locale nor en_US can be loaded
+func InitFS(fsys fs.FS, packageName string) (go_i18n.TranslateFunc, error) {
+	return InitWithOptions(Options{FS: fsys, PackageName: packageName})
+}
+
+// InitWithOptions loads the translations of the locale chosen with the
+// options, see Options, and of DEFAULT_LOCALE for the strings it misses
+func InitWithOptions(options Options) (go_i18n.TranslateFunc, error) {
+	available, err := availableLocales(options.FS, options.PackageName)
 	if err != nil {
-		userLocale = DEFAULT_LOCALE
+		return nil, err
 	}
 
-	language, err := jibber_jabber.DetectLanguage()
-	if err != nil {
-		language = DEFAULT_LANGUAGE
+	userLocale, ok := matchLocale(options.locales(), available)
+	if !ok {
+		userLocale = DEFAULT_LOCALE
 	}
 
-	userLocale = strings.Replace(userLocale, "-", "_", 1)
-	err = loadFromAsset(packageName, i18nDirname, userLocale, language)
-	if err != nil {
-		locale := SUPPORTED_LOCALES[language]
-		if locale == "" {
-			userLocale = DEFAULT_LOCALE
-		} else {
-			userLocale = locale
+	for _, locale := range []string{userLocale, DEFAULT_LOCALE} {
+		language, ok := available[locale]
+		if !ok {
+			if locale == userLocale {
+				return nil, fmt.Errorf("Could not find i18n asset for locale %s of package %s", locale, options.PackageName)
+			}
+			continue
+		}
+
+		err = loadFromFS(options.FS, options.PackageName, locale, language)
+		if err != nil {
+			return nil, err
 		}
-		err = loadFromAsset(packageName, i18nDirname, userLocale, language)
 	}
 
-	return userLocale, err
-}
+	T, err := go_i18n.Tfunc(userLocale, DEFAULT_LOCALE)
+	if err != nil || options.MissingHandler == nil {
+		return T, err
+	}
 
-func mustLoadDefaultLocale(packageName, i18nDirname string) string {
-	userLocale := DEFAULT_LOCALE
+	return reportMissing(T, userLocale, translationIDs(userLocale), translationIDs(DEFAULT_LOCALE), options.MissingHandler), nil
+}
 
-	err := loadFromAsset(packageName, i18nDirname, DEFAULT_LOCALE, DEFAULT_LANGUAGE)
-	if err != nil {
-		panic("Could not load en_US language files. God save the queen. " + err.Error())
+// translationIDs returns the IDs of the process-global translations of a
+// locale
+func translationIDs(locale string) map[string]bool {
+	_, language, err := go_i18n.TfuncAndLanguage(locale)
+	if err != nil || language == nil {
+		return map[string]bool{}
 	}
 
-	return userLocale
+	return idSet(go_i18n.LanguageTranslationIDs(language.Tag))
 }
 
-func loadFromAsset(packageName, assetPath, locale, language string) error {
-	assetName := locale + ".all.json"
-	assetKey := filepath.Join(assetPath, language, packageName, assetName)
-
-	byteArray, err := os.ReadFile(assetKey)
+func loadFromFS(fsys fs.FS, packageName, locale, language string) error {
+	assetKey, byteArray, err := readAsset(fsys, packageName, locale, language)
 	if err != nil {
 		return err
 	}
 
-	if len(byteArray) == 0 {
-		return errors.New(fmt.Sprintf("Could not load i18n asset: %v", assetKey))
-	}
+	return parseAsset(go_i18n.ParseTranslationFileBytes, assetKey, locale, byteArray)
+}
 
-	tmpDir, err := ioutil.TempDir("", "i18n4go_res")
-	if err != nil {
-		return err
-	}
-	defer func() {
-		os.RemoveAll(tmpDir)
-	}()
+func readAsset(fsys fs.FS, packageName, locale, language string) (string, []byte, error) {
+	assetKey := path.Join(language, filepath.ToSlash(packageName), locale+".all.json")
 
-	fileName, err := saveLanguageFileToDisk(tmpDir, assetName, byteArray)
+	byteArray, err := fs.ReadFile(fsys, assetKey)
 	if err != nil {
-		return err
+		return assetKey, nil, err
 	}
 
-	go_i18n.MustLoadTranslationFile(fileName)
-
-	os.RemoveAll(fileName)
+	if len(byteArray) == 0 {
+		return assetKey, nil, errors.New(fmt.Sprintf("Could not load i18n asset: %v", assetKey))
+	}
 
-	return nil
+	return assetKey, byteArray, nil
 }
 
-func saveLanguageFileToDisk(tmpDir, assetName string, byteArray []byte) (fileName string, err error) {
-	fileName = filepath.Join(tmpDir, assetName)
-	file, err := os.Create(fileName)
+// parseAsset parses the translations of a language file, go-i18n takes the
+// language of the translations from the file name, e.g., fr_FR.all.json
+func parseAsset(parse func(fileName string, buf []byte) error, assetKey, locale string, byteArray []byte) error {
+	err := parse(locale+".all.json", byteArray)
 	if err != nil {
-		return
+		return fmt.Errorf("Could not parse i18n asset: %v: %s", assetKey, err.Error())
 	}
-	defer file.Close()
 
-	_, err = file.Write(byteArray)
-	if err != nil {
-		return
-	}
-
-	return
+	return nil
 }
@@ -0,0 +1,112 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestInitFS(t *testing.T) {
+	t.Setenv("LC_ALL", "fr_FR.UTF-8")
+
+	fsys := fstest.MapFS{
+		"en/app/en_US.all.json": {Data: []byte(`[{"id": "Hello", "translation": "Hello"}]`)},
+		"fr/app/fr_FR.all.json": {Data: []byte(`[{"id": "Hello", "translation": "Bonjour"}]`)},
+	}
+
+	T, err := InitFS(fsys, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if translation := T("Hello"); translation != "Bonjour" {
+		t.Fatalf("expected Bonjour, got %s", translation)
+	}
+}
+
+func TestInitFSWithoutDefaultLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+
+	_, err := InitFS(fstest.MapFS{}, "missing")
+	if err == nil {
+		t.Fatal("expected an error without en_US translations")
+	}
+}
+
+func TestInitFSWithMalformedFile(t *testing.T) {
+	t.Setenv("LC_ALL", "en_US.UTF-8")
+
+	fsys := fstest.MapFS{
+		"en/malformed/en_US.all.json": {Data: []byte(`[{"id": "Hello",`)},
+	}
+
+	_, err := InitFS(fsys, "malformed")
+	if err == nil {
+		t.Fatal("expected an error for a malformed translation file")
+	}
+}
+
+type testDetector struct {
+	locale string
+}
+
+func (detector testDetector) DetectIETF() (string, error) {
+	return detector.locale, nil
+}
+
+func (detector testDetector) DetectLanguage() (string, error) {
+	return detector.locale[:2], nil
+}
+
+func TestInitWithOptions(t *testing.T) {
+	for _, envVar := range append([]string{"MYAPP_LOCALE"}, LOCALE_ENV_VARS...) {
+		t.Setenv(envVar, "")
+	}
+
+	fsys := fstest.MapFS{
+		"en/app/en_US.all.json":   {Data: []byte(`[{"id": "Hello", "translation": "Hello"}, {"id": "Bye", "translation": "Bye"}]`)},
+		"fr/app/fr_FR.all.json":   {Data: []byte(`[{"id": "Hello", "translation": "Bonjour"}]`)},
+		"ja/app/ja_JP.all.json":   {Data: []byte(`[{"id": "Hello", "translation": "こんにちは"}]`)},
+		"zh/app/zh_Hans.all.json": {Data: []byte(`[{"id": "Hello", "translation": "你好"}]`)},
+		"de/app/de_DE.all.json":   {Data: []byte(`[{"id": "Hello", "translation": "Hallo"}]`)},
+	}
+
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		options  Options
+		expected string
+	}{
+		{"explicit locale", map[string]string{"LANG": "fr_FR.UTF-8"}, Options{Locale: "de-DE"}, "Hallo"},
+		{"custom env var", map[string]string{"MYAPP_LOCALE": "ja", "LANG": "fr_FR.UTF-8"}, Options{EnvVar: "MYAPP_LOCALE"}, "こんにちは"},
+		{"LC_ALL before LANG", map[string]string{"LC_ALL": "ja_JP.UTF-8", "LANG": "fr_FR.UTF-8"}, Options{}, "こんにちは"},
+		{"LC_MESSAGES", map[string]string{"LC_MESSAGES": "fr_CA.UTF-8"}, Options{}, "Bonjour"},
+		{"C locale is ignored", map[string]string{"LANG": "C"}, Options{Detector: testDetector{"ja-JP"}}, "こんにちは"},
+		{"detector", nil, Options{Detector: testDetector{"fr-BE"}}, "Bonjour"},
+		{"BCP 47 script", nil, Options{Detector: testDetector{"zh-CN"}}, "你好"},
+		{"fallbacks", nil, Options{Detector: testDetector{"ko-KR"}, Fallbacks: []string{"xx", "fr"}}, "Bonjour"},
+		{"default locale", nil, Options{Detector: testDetector{"ko-KR"}}, "Hello"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			for envVar, value := range testCase.env {
+				t.Setenv(envVar, value)
+			}
+
+			testCase.options.FS = fsys
+			testCase.options.PackageName = "app"
+
+			T, err := InitWithOptions(testCase.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if translation := T("Hello"); translation != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, translation)
+			}
+			if translation := T("Bye"); translation != "Bye" {
+				t.Errorf("expected the en_US translation Bye, got %s", translation)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pivotal-cf-experimental/jibber_jabber"
+	"golang.org/x/text/language"
+)
+
+// LOCALE_ENV_VARS are the POSIX locale environment variables in their order
+// of precedence
+var LOCALE_ENV_VARS = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+// Detector detects the locale of the user, e.g., from the OS settings
+type Detector interface {
+	DetectIETF() (string, error)
+	DetectLanguage() (string, error)
+}
+
+// JibberJabberDetector detects the locale of the user with jibber_jabber
+type JibberJabberDetector struct{}
+
+func (detector JibberJabberDetector) DetectIETF() (string, error) {
+	return jibber_jabber.DetectIETF()
+}
+
+func (detector JibberJabberDetector) DetectLanguage() (string, error) {
+	return jibber_jabber.DetectLanguage()
+}
+
+// Options of InitWithOptions, the locale is the first of Locale, the EnvVar
+// and LOCALE_ENV_VARS environment variables, the Detector locale and the
+// Fallbacks that matches, with BCP 47 matching, a locale with translations,
+// else DEFAULT_LOCALE
+type Options struct {
+	// FS has the translation files, <language>/<PackageName>/<locale>.all.json
+	FS          fs.FS
+	PackageName string
+
+	// Locale is an explicit locale, e.g., the value of a --locale flag
+	Locale string
+	// EnvVar is a custom environment variable with the locale, e.g., MYAPP_LOCALE
+	EnvVar string
+	// Detector defaults to JibberJabberDetector
+	Detector Detector
+	// Fallbacks are tried in order after the user locales
+	Fallbacks []string
+
+	// MissingHandler is called for the strings missing in the locale, e.g., a MissingCollector Handle method
+	MissingHandler MissingHandler
+}
+
+// locales returns the locales to match in their order of preference
+func (options Options) locales() []string {
+	var locales []string
+	if options.Locale != "" {
+		locales = append(locales, options.Locale)
+	}
+
+	var envVars []string
+	if options.EnvVar != "" {
+		envVars = append(envVars, options.EnvVar)
+	}
+	for _, envVar := range append(envVars, LOCALE_ENV_VARS...) {
+		if locale := posixLocale(os.Getenv(envVar)); locale != "" {
+			locales = append(locales, locale)
+		}
+	}
+
+	detector := options.Detector
+	if detector == nil {
+		detector = JibberJabberDetector{}
+	}
+	if locale, err := detector.DetectIETF(); err == nil && locale != "" {
+		locales = append(locales, locale)
+	}
+	if language, err := detector.DetectLanguage(); err == nil && language != "" {
+		locales = append(locales, language)
+	}
+
+	return append(locales, options.Fallbacks...)
+}
+
+// posixLocale returns the locale of a POSIX locale value such as
+// fr_FR.UTF-8@euro, the C and POSIX locales have none
+func posixLocale(value string) string {
+	if i := strings.IndexAny(value, ".@"); i >= 0 {
+		value = value[:i]
+	}
+
+	if value == "C" || value == "POSIX" {
+		return ""
+	}
+
+	return value
+}
+
+// availableLocales returns the locales with a translation file for the
+// package, keyed by their locale name, e.g., fr_FR, and the language
+// directory they are in
+func availableLocales(fsys fs.FS, packageName string) (map[string]string, error) {
+	fileNames, err := fs.Glob(fsys, path.Join("*", filepath.ToSlash(packageName), "*.all.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	locales := make(map[string]string, len(fileNames))
+	for _, fileName := range fileNames {
+		language := strings.SplitN(fileName, "/", 2)[0]
+		locales[strings.TrimSuffix(path.Base(fileName), ".all.json")] = language
+	}
+
+	return locales, nil
+}
+
+// localeMatcher matches BCP 47 tags to the locales with translations
+type localeMatcher struct {
+	matcher language.Matcher
+	locales []string
+}
+
+func newLocaleMatcher(available map[string]string) *localeMatcher {
+	var availableLocales []string
+	for locale := range available {
+		availableLocales = append(availableLocales, locale)
+	}
+	sort.Strings(availableLocales)
+
+	localeMatcher := &localeMatcher{}
+	var tags []language.Tag
+	for _, locale := range availableLocales {
+		if tag, ok := parseLocale(locale); ok {
+			tags = append(tags, tag)
+			localeMatcher.locales = append(localeMatcher.locales, locale)
+		}
+	}
+
+	if len(tags) > 0 {
+		localeMatcher.matcher = language.NewMatcher(tags)
+	}
+
+	return localeMatcher
+}
+
+// match returns the locale that best matches the first of the tags that
+// has a match, ok is false when none has
+func (localeMatcher *localeMatcher) match(tags ...language.Tag) (string, bool) {
+	if localeMatcher.matcher == nil {
+		return "", false
+	}
+
+	for _, tag := range tags {
+		_, index, confidence := localeMatcher.matcher.Match(tag)
+		if confidence != language.No {
+			return localeMatcher.locales[index], true
+		}
+	}
+
+	return "", false
+}
+
+// matchLocale returns the available locale that best matches the first of
+// the locales that has a match, ok is false when none has
+func matchLocale(locales []string, available map[string]string) (string, bool) {
+	var tags []language.Tag
+	for _, locale := range locales {
+		if tag, ok := parseLocale(locale); ok {
+			tags = append(tags, tag)
+		}
+	}
+
+	return newLocaleMatcher(available).match(tags...)
+}
+
+// parseLocale returns the BCP 47 tag of a locale such as fr_FR or zh-Hans,
+// locales with an unknown region such as ja_JA are their language
+func parseLocale(locale string) (language.Tag, bool) {
+	locale = strings.Replace(locale, "_", "-", -1)
+
+	tag, err := language.Parse(locale)
+	if err == nil {
+		return tag, true
+	}
+
+	tag, err = language.Parse(strings.SplitN(locale, "-", 2)[0])
+	return tag, err == nil
+}
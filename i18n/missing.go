@@ -0,0 +1,152 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+
+	go_i18n "github.com/EverlongProject/go-i18n/i18n"
+)
+
+// MissingString is a string translated in a locale that has no translation
+// for it, Fallback is whether the DEFAULT_LOCALE translation is used instead
+// of the ID, Caller is the file:line of the call to the translate func
+type MissingString struct {
+	ID       string
+	Locale   string
+	Fallback bool
+	Caller   string
+}
+
+// MissingHandler is called for each translation of a missing string, it
+// must be safe for concurrent use
+type MissingHandler func(missing MissingString)
+
+// reportMissing returns a translate func that reports the strings that are
+// not in ids, the IDs of the locale, to the handler
+func reportMissing(T go_i18n.TranslateFunc, locale string, ids, defaultIDs map[string]bool, handler MissingHandler) go_i18n.TranslateFunc {
+	return func(translationID string, args ...interface{}) string {
+		if !ids[translationID] {
+			missing := MissingString{ID: translationID, Locale: locale, Fallback: defaultIDs[translationID]}
+			if _, file, line, ok := runtime.Caller(1); ok {
+				missing.Caller = fmt.Sprintf("%s:%d", file, line)
+			}
+			handler(missing)
+		}
+
+		return T(translationID, args...)
+	}
+}
+
+func idSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+
+	return set
+}
+
+// MissingUsage is how many times a missing string was translated in a
+// locale and from where, as saved by MissingCollector
+type MissingUsage struct {
+	ID       string   `json:"id"`
+	Locale   string   `json:"locale"`
+	Fallback bool     `json:"fallback"`
+	Count    int      `json:"count"`
+	Callers  []string `json:"callers"`
+}
+
+// MissingCollector collects the missing strings reported to its Handle
+// method, use it as MissingHandler and Save them when the program exits
+type MissingCollector struct {
+	mutex  sync.Mutex
+	usages map[[2]string]*MissingUsage
+}
+
+func NewMissingCollector() *MissingCollector {
+	return &MissingCollector{usages: make(map[[2]string]*MissingUsage)}
+}
+
+func (collector *MissingCollector) Handle(missing MissingString) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	key := [2]string{missing.ID, missing.Locale}
+	usage, ok := collector.usages[key]
+	if !ok {
+		usage = &MissingUsage{ID: missing.ID, Locale: missing.Locale, Fallback: missing.Fallback}
+		collector.usages[key] = usage
+	}
+
+	usage.Count++
+	if missing.Caller != "" {
+		index := sort.SearchStrings(usage.Callers, missing.Caller)
+		if index == len(usage.Callers) || usage.Callers[index] != missing.Caller {
+			usage.Callers = append(usage.Callers, "")
+			copy(usage.Callers[index+1:], usage.Callers[index:])
+			usage.Callers[index] = missing.Caller
+		}
+	}
+}
+
+// Usages returns the missing strings, the most used first
+func (collector *MissingCollector) Usages() []MissingUsage {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	usages := make([]MissingUsage, 0, len(collector.usages))
+	for _, usage := range collector.usages {
+		callers := append([]string{}, usage.Callers...)
+		usages = append(usages, MissingUsage{ID: usage.ID, Locale: usage.Locale, Fallback: usage.Fallback, Count: usage.Count, Callers: callers})
+	}
+
+	sortMissingUsages(usages)
+
+	return usages
+}
+
+// Save writes the missing strings as a JSON file, show-missing-strings
+// reads it with --missing-usage-filename
+func (collector *MissingCollector) Save(fileName string) error {
+	content, err := json.MarshalIndent(collector.Usages(), "", "   ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fileName, content, 0644)
+}
+
+// LoadMissingUsages reads a file saved by MissingCollector, the most used
+// strings first
+func LoadMissingUsages(fileName string) ([]MissingUsage, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var usages []MissingUsage
+	err = json.Unmarshal(content, &usages)
+	if err != nil {
+		return nil, err
+	}
+
+	sortMissingUsages(usages)
+
+	return usages, nil
+}
+
+func sortMissingUsages(usages []MissingUsage) {
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Count != usages[j].Count {
+			return usages[i].Count > usages[j].Count
+		}
+		if usages[i].ID != usages[j].ID {
+			return usages[i].ID < usages[j].ID
+		}
+		return usages[i].Locale < usages[j].Locale
+	})
+}
@@ -0,0 +1,85 @@
+package i18n
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/text/language"
+)
+
+func TestBundleMissingCollector(t *testing.T) {
+	bundle, err := NewBundle(bundleFS, "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collector := NewMissingCollector()
+	bundle.SetMissingHandler(collector.Handle)
+
+	T := bundle.Translator(language.French)
+	for i := 0; i < 3; i++ {
+		T("Bye")
+	}
+	T("Hello")
+	T("Unknown")
+	bundle.Translator()("Unknown")
+
+	usages := collector.Usages()
+	if len(usages) != 3 {
+		t.Fatalf("expected 3 missing strings, got %v", usages)
+	}
+
+	bye := usages[0]
+	if bye.ID != "Bye" || bye.Locale != "fr_FR" || !bye.Fallback || bye.Count != 3 {
+		t.Errorf("unexpected missing string: %#v", bye)
+	}
+	if len(bye.Callers) != 1 || !strings.Contains(bye.Callers[0], "missing_test.go:") {
+		t.Errorf("expected the caller to be this test, got %v", bye.Callers)
+	}
+
+	if usages[1].ID != "Unknown" || usages[1].Fallback || usages[2].ID != "Unknown" || usages[1].Locale != DEFAULT_LOCALE {
+		t.Errorf("unexpected missing strings: %#v", usages[1:])
+	}
+
+	fileName := filepath.Join(t.TempDir(), "missing.json")
+	err = collector.Save(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loadedUsages, err := LoadMissingUsages(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loadedUsages) != 3 || loadedUsages[0].ID != "Bye" || loadedUsages[0].Count != 3 {
+		t.Errorf("unexpected loaded missing strings: %#v", loadedUsages)
+	}
+}
+
+func TestInitWithOptionsMissingHandler(t *testing.T) {
+	var missingStrings []MissingString
+	T, err := InitWithOptions(Options{
+		FS: fstest.MapFS{
+			"en/missing/en_US.all.json": {Data: []byte(`[{"id": "Bye", "translation": "Bye"}]`)},
+			"it/missing/it_IT.all.json": {Data: []byte(`[{"id": "Hello", "translation": "Ciao"}]`)},
+		},
+		PackageName: "missing",
+		Locale:      "it",
+		MissingHandler: func(missing MissingString) {
+			missingStrings = append(missingStrings, missing)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if T("Hello") != "Ciao" || T("Bye") != "Bye" {
+		t.Fatal("unexpected translations")
+	}
+
+	if len(missingStrings) != 1 || missingStrings[0].ID != "Bye" || missingStrings[0].Locale != "it_IT" || !missingStrings[0].Fallback {
+		t.Errorf("unexpected missing strings: %#v", missingStrings)
+	}
+}
@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"sync"
+	"time"
+)
+
+const DEFAULT_WATCH_INTERVAL = time.Second
+
+// WatchOptions of Bundle.Watch, the callbacks are called from the watch
+// goroutine
+type WatchOptions struct {
+	// Interval between two polls of the translation files, defaults to DEFAULT_WATCH_INTERVAL
+	Interval time.Duration
+	// OnReload is called with the locales whose translations were reloaded
+	OnReload func(locales []string)
+	// OnError is called with the errors of the translation files that could not be reloaded
+	OnError func(err error)
+}
+
+// Watch polls the translation files of the bundle and reloads the ones that
+// change until stop is called, see Reload, it is meant for development,
+// e.g., to review translations without restarting the server
+func (b *Bundle) Watch(options WatchOptions) (stop func()) {
+	interval := options.Interval
+	if interval <= 0 {
+		interval = DEFAULT_WATCH_INTERVAL
+	}
+
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				locales, err := b.Reload()
+				if err != nil && options.OnError != nil {
+					options.OnError(err)
+				}
+				if len(locales) > 0 && options.OnReload != nil {
+					options.OnReload(locales)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ticker.Stop()
+			close(done)
+		})
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"runtime"
 	"runtime/debug"
 	"strings"
 	"time"
 
 	"github.com/EverlongProject/i18n4go/cmds"
 	"github.com/EverlongProject/i18n4go/common"
+	"github.com/EverlongProject/i18n4go/tm"
+	"github.com/EverlongProject/i18n4go/translators"
 )
 
 const VERSION = "v0.2.7"
@@ -41,6 +44,22 @@ func main() {
 		checkupCmd()
 	case "fixup":
 		fixupCmd()
+	case "import-po":
+		importPoCmd()
+	case "export-xliff":
+		exportXliffCmd()
+	case "import-xliff":
+		importXliffCmd()
+	case "pseudo-localize":
+		pseudoLocalizeCmd()
+	case "update-tm":
+		updateTmCmd()
+	case "import-tmx":
+		importTmxCmd()
+	case "export-tmx":
+		exportTmxCmd()
+	case "generate-accessors":
+		generateAccessorsCmd()
 	default:
 		usage()
 	}
@@ -207,15 +226,183 @@ func fixupCmd() {
 	fixup.Println("Total time:", duration)
 }
 
+func importPoCmd() {
+	if options.HelpFlag || (options.FilenameFlag == "" && options.DirnameFlag == "") {
+		usage()
+		return
+	}
+
+	importPo := cmds.NewImportPo(options)
+
+	startTime := time.Now()
+
+	err := importPo.Run()
+	if err != nil {
+		importPo.Println("i18n4go: Could not import PO files, err:", err)
+		os.Exit(1)
+	}
+
+	duration := time.Now().Sub(startTime)
+	importPo.Println("Total time:", duration)
+}
+
+func exportXliffCmd() {
+	if options.HelpFlag || options.FilenameFlag == "" || (options.LanguagesFlag == "" && options.LanguageFilesFlag == "") {
+		usage()
+		return
+	}
+
+	exportXliff := cmds.NewExportXliff(options)
+
+	startTime := time.Now()
+
+	err := exportXliff.Run()
+	if err != nil {
+		exportXliff.Println("i18n4go: Could not export XLIFF files, err:", err)
+		os.Exit(1)
+	}
+
+	duration := time.Now().Sub(startTime)
+	exportXliff.Println("Total time:", duration)
+}
+
+func importXliffCmd() {
+	if options.HelpFlag || options.FilenameFlag == "" || options.XliffFilesFlag == "" {
+		usage()
+		return
+	}
+
+	importXliff := cmds.NewImportXliff(options)
+
+	startTime := time.Now()
+
+	err := importXliff.Run()
+	if err != nil {
+		importXliff.Println("i18n4go: Could not import XLIFF files, err:", err)
+		os.Exit(1)
+	}
+
+	duration := time.Now().Sub(startTime)
+	importXliff.Println("Total time:", duration)
+}
+
+func pseudoLocalizeCmd() {
+	if options.HelpFlag || options.FilenameFlag == "" {
+		usage()
+		return
+	}
+
+	pseudoLocalize := cmds.NewPseudoLocalize(options)
+
+	startTime := time.Now()
+
+	err := pseudoLocalize.Run()
+	if err != nil {
+		pseudoLocalize.Println("i18n4go: Could not pseudo-localize strings, err:", err)
+		os.Exit(1)
+	}
+
+	duration := time.Now().Sub(startTime)
+	pseudoLocalize.Println("Total time:", duration)
+}
+
+func updateTmCmd() {
+	if options.HelpFlag || options.FilenameFlag == "" || options.TmFilenameFlag == "" || (options.LanguagesFlag == "" && options.LanguageFilesFlag == "") {
+		usage()
+		return
+	}
+
+	updateTm := cmds.NewUpdateTm(options)
+
+	startTime := time.Now()
+
+	err := updateTm.Run()
+	if err != nil {
+		updateTm.Println("i18n4go: Could not update translation memory, err:", err)
+		os.Exit(1)
+	}
+
+	duration := time.Now().Sub(startTime)
+	updateTm.Println("Total time:", duration)
+}
+
+func importTmxCmd() {
+	if options.HelpFlag || options.TmFilenameFlag == "" || options.TmxFilenameFlag == "" {
+		usage()
+		return
+	}
+
+	importTmx := cmds.NewImportTmx(options)
+
+	startTime := time.Now()
+
+	err := importTmx.Run()
+	if err != nil {
+		importTmx.Println("i18n4go: Could not import TMX file, err:", err)
+		os.Exit(1)
+	}
+
+	duration := time.Now().Sub(startTime)
+	importTmx.Println("Total time:", duration)
+}
+
+func exportTmxCmd() {
+	if options.HelpFlag || options.TmFilenameFlag == "" || options.TmxFilenameFlag == "" {
+		usage()
+		return
+	}
+
+	exportTmx := cmds.NewExportTmx(options)
+
+	startTime := time.Now()
+
+	err := exportTmx.Run()
+	if err != nil {
+		exportTmx.Println("i18n4go: Could not export TMX file, err:", err)
+		os.Exit(1)
+	}
+
+	duration := time.Now().Sub(startTime)
+	exportTmx.Println("Total time:", duration)
+}
+
+func generateAccessorsCmd() {
+	if options.HelpFlag || options.FilenameFlag == "" || options.OutputDirFlag == "" {
+		usage()
+		return
+	}
+
+	generateAccessors := cmds.NewGenerateAccessors(options)
+
+	startTime := time.Now()
+
+	err := generateAccessors.Run()
+	if err != nil {
+		generateAccessors.Println("i18n4go: Could not generate accessors, err:", err)
+		os.Exit(1)
+	}
+
+	duration := time.Now().Sub(startTime)
+	generateAccessors.Println("Total time:", duration)
+}
+
 func init() {
-	flag.StringVar(&options.CommandFlag, "c", "", "the command, one of: extract-strings, create-translations, rewrite-package, verify-strings, merge-strings, checkup, fixup")
+	flag.StringVar(&options.CommandFlag, "c", "", "the command, one of: extract-strings, create-translations, rewrite-package, verify-strings, merge-strings, checkup, fixup, import-po, export-xliff, import-xliff, pseudo-localize, update-tm, import-tmx, export-tmx, generate-accessors")
+
+	flag.StringVar(&options.ConfigFilenameFlag, "config", "", "[optional] the project configuration file, defaults to the first .i18n4go.yaml, .i18n4go.yml or .i18n4go.json file found from the working directory up")
 
 	flag.BoolVar(&options.HelpFlag, "h", false, "prints the usage")
 	flag.BoolVar(&options.LongHelpFlag, "help", false, "prints the usage")
 
-	flag.StringVar(&options.SourceLanguageFlag, "source-language", "en", "the source language of the file, typically also part of the file name, e.g., \"en_US\"")
+	flag.StringVar(&options.SourceLanguageFlag, "source-language", common.DEFAULT_SOURCE_LANGUAGE, "the source language of the file, typically also part of the file name, e.g., \"en_US\"")
 	flag.StringVar(&options.LanguagesFlag, "languages", "", "a comma separated list of valid languages with optional territory, e.g., \"en, en_US, fr_FR, es\"")
 	flag.StringVar(&options.GoogleTranslateApiKeyFlag, "google-translate-api-key", "", "[optional] your public Google Translate API key which is used to generate translations (charge is applicable)")
+	flag.StringVar(&options.TranslatorFlag, "translator", "", "[optional] the machine translator used to generate translations, one of: "+strings.Join(translators.Names(), ", "))
+	flag.StringVar(&options.TranslatorConfigFlag, "translator-config", "", "[optional] the JSON file configuring the translators, e.g., their URLs and API keys")
+	flag.IntVar(&options.TranslateBatchSizeFlag, "translate-batch-size", translators.DEFAULT_BATCH_SIZE, "[optional] the number of strings sent to the translator per request")
+	flag.IntVar(&options.TranslateConcurrencyFlag, "translate-concurrency", translators.DEFAULT_CONCURRENCY, "[optional] the number of concurrent requests to the translator")
+	flag.IntVar(&options.TranslateMaxRetriesFlag, "translate-max-retries", translators.DEFAULT_MAX_RETRIES, "[optional] the number of times a failed request to the translator is retried, with an exponential backoff")
+	flag.Float64Var(&options.TranslateRateLimitFlag, "translate-rate-limit", 0, "[optional] the maximum number of requests per second to the translator, 0 means no limit")
 
 	flag.BoolVar(&options.VerboseFlag, "v", false, "verbose mode where lots of output is generated during execution")
 
@@ -232,6 +419,7 @@ func init() {
 
 	flag.BoolVar(&options.OutputFlatFlag, "output-flat", true, "generated files are created in the specified output directory")
 	flag.BoolVar(&options.OutputMatchPackageFlag, "output-match-package", false, "generated files are created in directory to match the package name")
+	flag.BoolVar(&options.OutputMatchImportFlag, "output-match-import", false, "generated files are created in directory to match the full import path of the package (module aware)")
 	flag.BoolVar(&options.OutputFormatFlatFlag, "output-format-flat", false, "generated files are created in flat file format")
 
 	flag.StringVar(&options.FilenameFlag, "f", "", "the file name for which strings are extracted")
@@ -240,6 +428,12 @@ func init() {
 
 	flag.BoolVar(&options.RecurseFlag, "r", false, "recursively extract strings from all files in the same directory as filename or dirName")
 
+	flag.StringVar(&options.SinkFuncsFlag, "sink-funcs", "", "[optional] a comma separated list of functions, e.g., \"fmt.Printf,errors.New,ui.Say\", when specified packages are loaded with type information and only strings passed as string parameters to these functions are extracted")
+
+	flag.StringVar(&options.CacheDirFlag, "cache-dir", "", "[optional] the dir of the extraction cache, e.g., \".i18n4go-cache\", the files unchanged since the previous extraction are skipped and the outputs of the deleted files are removed")
+	flag.IntVar(&options.ExtractConcurrencyFlag, "extract-concurrency", runtime.NumCPU(), "[optional] the number of files extracted at the same time with -d, defaults to the number of CPUs")
+	flag.StringVar(&options.SinceFlag, "since", "", "[optional] a git ref, only the files changed since this ref, uncommitted and untracked ones included, have their strings extracted")
+
 	flag.StringVar(&options.IgnoreRegexpFlag, "ignore-regexp", ".*test.*", "a perl-style regular expression for files to ignore, e.g., \".*test.*\"")
 
 	flag.StringVar(&options.LanguageFilesFlag, "language-files", "", `[optional] a comma separated list of target files for different languages to compare,  e.g., \"en, en_US, fr_FR, es\"	                                                                  if not specified then the languages flag is used to find target files in same directory as source`)
@@ -249,33 +443,116 @@ func init() {
 	flag.StringVar(&options.RootPathFlag, "root-path", "", "the root path to the Go source files whose packages are being rewritten, defaults to working directory, if not specified")
 
 	flag.StringVar(&options.InitCodeSnippetFilenameFlag, "init-code-snippet-filename", "", "[optional] the path to a file containing the template snippet for the code that is used for go-i18n initialization")
+	flag.StringVar(&options.InitEmbedDirnameFlag, "init-embed-dirname", "", "[optional] the directory, relative to the rewritten package, with the translation files to embed with //go:embed in the generated i18n_init.go")
+	flag.StringVar(&options.AccessorsFilenameFlag, "accessors-filename", "", "[optional] the source translation file the accessors were generated from with generate-accessors, the T() calls of its strings are rewritten to call their accessors")
+	flag.BoolVar(&options.TemplatesFlag, "templates", false, "[optional] rewrite-package also wraps the texts of the text/template and html/template files and template literals in {{T \"...\"}} actions")
+
+	flag.StringVar(&options.MissingUsageFilenameFlag, "missing-usage-filename", "", "[optional] a JSON file of the strings missing at runtime saved by an i18n.MissingCollector")
+
+	flag.StringVar(&options.FormatFlag, "format", common.FORMAT_TEXT, "[optional] the format of the findings of verify-strings, checkup and show-missing-strings: text, json, sarif or junit")
 
 	flag.StringVar(&options.QualifierFlag, "q", "", "[optional] the qualifier string that is used when using the T(...) function, default to nothing but could be set to `i18n` so that all calls would be: i18n.T(...)")
+	flag.StringVar(&options.TFuncsFlag, "t-funcs", "", "[optional] a comma separated list of the names of the translate funcs found in the code, defaults to \"T,t\"")
+
+	flag.BoolVar(&options.NonInteractiveFlag, "non-interactive", false, "[optional] fixup pairs new and removed strings automatically instead of asking the user")
+	flag.Float64Var(&options.RenameThresholdFlag, "rename-threshold", 0.5, "[optional] the similarity score (0 to 1) above which a new string is considered an update of a removed string with -non-interactive")
+	flag.StringVar(&options.RenameFilenameFlag, "rename-file", "", "[optional] a JSON file mapping removed strings to the new strings that update them, used with -non-interactive")
+	flag.StringVar(&options.ReportFilenameFlag, "report", "", "[optional] a JSON file where fixup writes the source locale and the strings it added, updated and removed")
+
+	flag.StringVar(&options.XliffVersionFlag, "xliff-version", "1.2", "[optional] the XLIFF version of the exported files, 1.2 or 2.0")
+	flag.StringVar(&options.XliffFilesFlag, "xliff-files", "", "a comma separated list of XLIFF files to import")
+
+	flag.IntVar(&options.PseudoExpansionFlag, "pseudo-expansion", 30, "[optional] the percentage of characters added to the pseudo-localized strings, e.g., 30 to 50")
+	flag.BoolVar(&options.PseudoRightToLeftFlag, "pseudo-rtl", false, "[optional] wraps the pseudo-localized strings of all the languages to be shown right to left, not only those of ar_XB, the default language is then ar_XB")
+
+	flag.StringVar(&options.TmFilenameFlag, "tm", "", "[optional] the translation memory file, JSON lines, used by create-translations before any translator")
+	flag.StringVar(&options.TmxFilenameFlag, "tmx", "", "the TMX file imported into or exported from the translation memory")
+	flag.Float64Var(&options.TmFuzzyThresholdFlag, "tm-fuzzy-threshold", tm.DEFAULT_FUZZY_THRESHOLD, "[optional] the similarity score (0 to 1) from which a translation memory fuzzy match is used, 1 only uses exact matches")
 
 	flag.Parse()
+
+	setFlags := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = true
+	})
+	options.SourceLanguageSet = setFlags["source-language"]
+
+	loadConfig(setFlags)
+}
+
+// loadConfig sets the options of the flags that are not set from the project
+// configuration file, if any
+func loadConfig(setFlags map[string]bool) {
+	fileName := options.ConfigFilenameFlag
+	if fileName == "" {
+		var err error
+		fileName, err = common.FindConfigFile(".")
+		if err != nil || fileName == "" {
+			return
+		}
+	}
+
+	config, err := common.LoadConfig(fileName)
+	if err != nil {
+		fmt.Println("i18n4go: Could not load the configuration file, err:", err)
+		os.Exit(1)
+	}
+
+	config.Apply(&options, setFlags)
+
+	if options.VerboseFlag {
+		fmt.Println("i18n4go: using the configuration file:", fileName)
+	}
 }
 
 func usage() {
 	usageString := `
-usage: i18n4go -c extract-strings [-vpe] [--dry-run] [--output-flat|--output-match-package|-o <outputDir>] -f <fileName>
-   or: i18n4go -c extract-strings [-vpe] [--dry-run] [--output-flat|--output-match-package|-o <outputDir>] -d <dirName> [-r] [--ignore-regexp <fileNameRegexp>]
+usage: i18n4go -c extract-strings [-vpe] [--dry-run] [--output-flat|--output-match-package|--output-match-import|-o <outputDir>] -f <fileName>
+   or: i18n4go -c extract-strings [-vpe] [--dry-run] [--output-flat|--output-match-package|--output-match-import|-o <outputDir>] -d <dirName> [-r] [--ignore-regexp <fileNameRegexp>] [--extract-concurrency <n>] [--cache-dir <cacheDir>] [--since <gitRef>]
+   or: i18n4go -c extract-strings [-vpe] [--dry-run] [--output-flat|--output-match-package|--output-match-import|-o <outputDir>] --sink-funcs <func1,func2,...> [-f <fileName> | -d <dirName> [-r]]
 
-usage: i18n4go -c rewrite-package [-v] [-r] -d <dirName> [--i18n-strings-filename <fileName> | --i18n-strings-dirname <dirName>] [--init-code-snippet-filename <fileName>]
-   or: i18n4go -c rewrite-package [-v] [-r] -f <fileName> --i18n-strings-filename <fileName> [--init-code-snippet-filename <fileName>]
+usage: i18n4go -c rewrite-package [-v] [-r] -d <dirName> [--i18n-strings-filename <fileName> | --i18n-strings-dirname <dirName>] [--init-code-snippet-filename <fileName> | --init-embed-dirname <dirName>] [--accessors-filename <sourceFileName>] [--templates]
+   or: i18n4go -c rewrite-package [-v] [-r] -f <fileName> --i18n-strings-filename <fileName> [--init-code-snippet-filename <fileName> | --init-embed-dirname <dirName>] [--accessors-filename <sourceFileName>] [--templates]
 
 usage: i18n4go -c create-translations [-v] [--google-translate-api-key <api key>] [--source-language <language>] -f <fileName> --languages <lang1,lang2,...> -o <outputDir>
+   or: i18n4go -c create-translations [-v] --tm <tmFileName> [--tm-fuzzy-threshold <score>] [--source-language <language>] -f <fileName> --languages <lang1,lang2,...> -o <outputDir>
+   or: i18n4go -c create-translations [-v] --translator <name> [--translator-config <fileName>] [--translate-batch-size <size>] [--translate-concurrency <requests>] [--translate-max-retries <retries>] [--translate-rate-limit <requests per second>] [--source-language <language>] -f <fileName> --languages <lang1,lang2,...> -o <outputDir>
 
 usage: i18n4go -c merge-strings [-v] [-r] [--source-language <language>] -d <dirName>
 
-usage: i18n4go -c verify-strings [-v] [--source-language <language>] -f <sourceFileName> --language-files <language files>
-   or: i18n4go -c verify-strings [-v] [--source-language <language>] -f <sourceFileName> --languages <lang1,lang2,...>
+usage: i18n4go -c verify-strings [-v] [--format text|json|sarif|junit] [--source-language <language>] [-d <extractedDirName>] -f <sourceFileName> --language-files <language files>
+   or: i18n4go -c verify-strings [-v] [--format text|json|sarif|junit] [--source-language <language>] [-d <extractedDirName>] -f <sourceFileName> --languages <lang1,lang2,...>
+
+usage: i18n4go -c show-missing-strings [-v] [--format text|json|sarif|junit] -d <dirName> --i18n-strings-filename <language file> [--missing-usage-filename <fileName>]
+
+usage: i18n4go -c checkup [-v] [--format text|json|sarif|junit] [--source-language <language>] [-d <sourcesDirName>] [--i18n-strings-dirname <resourcesDirName>] [-q <qualifier>] [--t-funcs <func1,func2,...>]
 
-usage: i18n4go -c show-missing-strings [-v] -d <dirName> --i18n-strings-filename <language file>
+usage: i18n4go -c fixup [--source-language <language>] [-d <sourcesDirName>] [--i18n-strings-dirname <resourcesDirName>] [-q <qualifier>] [--t-funcs <func1,func2,...>]
+   or: i18n4go -c fixup --non-interactive [--rename-threshold <score>] [--rename-file <fileName>] [--report <fileName>]
 
-usage: i18n4go -c checkup
+usage: i18n4go -c import-po [-v] [--output-format-flat] [-o <outputDir>] -f <fileName>
+   or: i18n4go -c import-po [-v] [--output-format-flat] [-o <outputDir>] -d <dirName> [-r]
+
+usage: i18n4go -c export-xliff [-v] [--xliff-version 1.2|2.0] [--source-language <language>] [-d <extractedDirName>] [-o <outputDir>] -f <sourceFileName> --languages <lang1,lang2,...>
+   or: i18n4go -c export-xliff [-v] [--xliff-version 1.2|2.0] [--source-language <language>] [-d <extractedDirName>] [-o <outputDir>] -f <sourceFileName> --language-files <language files>
+
+usage: i18n4go -c import-xliff [-v] [-o <outputDir>] -f <sourceFileName> --xliff-files <xliff files>
+
+usage: i18n4go -c pseudo-localize [-v] [--pseudo-expansion <percentage>] [--pseudo-rtl] [--source-language <language>] [--languages <lang1,lang2,...>] [-o <outputDir>] -f <sourceFileName>
+
+usage: i18n4go -c update-tm [-v] [--source-language <language>] --tm <tmFileName> -f <sourceFileName> --languages <lang1,lang2,...>
+   or: i18n4go -c update-tm [-v] [--source-language <language>] --tm <tmFileName> -f <sourceFileName> --language-files <language files>
+
+usage: i18n4go -c import-tmx [-v] --tm <tmFileName> --tmx <tmxFileName>
+
+usage: i18n4go -c export-tmx [-v] [--source-language <language>] --tm <tmFileName> --tmx <tmxFileName>
+
+usage: i18n4go -c generate-accessors [-v] [--dry-run] -f <sourceFileName> -o <packageDir>
 
   -h | --help                prints the usage
   -v                         verbose
+  --config                   [optional] the project configuration file, defaults to the first .i18n4go.yaml, .i18n4go.yml or .i18n4go.json
+                             file found from the working directory up, the flags override its settings
 
   EXTRACT-STRINGS:
 
@@ -290,15 +567,26 @@ usage: i18n4go -c checkup
 
   --output-flat              generated files are created in the specified output directory (default)
   --output-match-package     generated files are created in directory to match the package name
+  --output-match-import      generated files are created in directory to match the full import path of the package (module aware)
   -o                         the output directory where the translation files will be placed
 
-  -f                         the go file name to extract strings
+  -f                         the go or template (.tmpl, .gotmpl, .tpl) file name to extract strings
 
-  -d                         the directory containing the go files to extract strings
+  -d                         the directory containing the go and template files to extract strings
 
   -r                         [optional] recursesively extract strings from all subdirectories
   --ignore-regexp            [optional] a perl-style regular expression for files to ignore, e.g., ".*test.*"
 
+  --extract-concurrency      [optional] the number of files extracted at the same time with -d, defaults to the number of CPUs,
+                             the outputs are the same whatever the number
+  --cache-dir                [optional] the dir of the extraction cache, e.g., ".i18n4go-cache", the files unchanged since the previous extraction
+                             are skipped and the outputs of the deleted files are removed
+  --since                    [optional] a git ref, e.g., "HEAD" or "origin/main", only the files changed since this ref, uncommitted and untracked
+                             files included, have their strings extracted
+
+  --sink-funcs               [optional] a comma separated list of functions, e.g., "fmt.Printf,errors.New,ui.Say", when specified the packages are loaded
+                             with type information and only string literals and constants passed as string parameters to these functions are extracted
+
   REWRITE-PACKAGE:
 
   -c rewrite-package         the rewrite package command
@@ -310,6 +598,10 @@ usage: i18n4go -c checkup
   --root-path                the root path to the Go source files whose packages are being rewritten, defaults to working directory, if not specified
 
   --init-code-snippet-filename [optional] the path to a file containing the template snippet for the code that is used for go-i18n initialization"
+  --init-embed-dirname         [optional] the directory, relative to the rewritten package, with the <language>/<package>/<locale>.all.json translation files
+                               to embed with //go:embed, the generated i18n_init.go then uses i18n.InitFS instead of i18n.Init
+  --accessors-filename         [optional] the source translation file given to generate-accessors, the T() calls of its strings are rewritten to call their accessors
+  --templates                  [optional] also wrap the texts of the .tmpl, .gotmpl and .tpl template files and of the template.New(...).Parse(...) literals in {{T "..."}} actions
   -o                           [optional] output diretory for rewritten file. If not specified, the original file will be overwritten
 
   MERGE STRINGS:
@@ -326,6 +618,14 @@ usage: i18n4go -c checkup
   -c create-translations     the create translations command
 
   --google-translate-api-key [optional] your public Google Translate API key which is used to generate translations (charge is applicable)
+  --translator               [optional] the machine translator used to generate translations: google, google-v2, deepl, libretranslate, http, echo or pseudo
+  --translator-config        [optional] the JSON file configuring the translators, e.g., their URLs and API keys
+  --translate-batch-size     [optional] the number of strings sent to the translator per request (default 50)
+  --translate-concurrency    [optional] the number of concurrent requests to the translator (default 4)
+  --translate-max-retries    [optional] the number of times a failed request to the translator is retried, with an exponential backoff (default 3)
+  --translate-rate-limit     [optional] the maximum number of requests per second to the translator, 0 means no limit (default 0)
+  --tm                       [optional] the translation memory file, its translations are used before any translator and it is updated with the existing translation files
+  --tm-fuzzy-threshold       [optional] the similarity score (0 to 1) from which a fuzzy match of the translation memory is used, marked as modified (default 0.75)
   --source-language          [optional] the source language of the file, typically also part of the file name, e.g., \"en_US\"
 
   -f                         the source translation file
@@ -343,6 +643,8 @@ usage: i18n4go -c checkup
   --language-files           a comma separated list of target files for different languages to compare, e.g., "en, en_US, fr_FR, es"
                              if not specified then the languages flag is used to find target files in same directory as source
   --languages                a comma separated list of valid languages with optional territory, e.g., "en, en_US, fr_FR, es"
+  -d                         [optional] the directory with the *.extracted.json files of extract-strings --meta, locating the findings in the Go files
+  --format                   [optional] the format of the findings written to the standard output: text, json, sarif or junit (default to 'text')
 
   SHOW-MISSING-STRINGS:
 
@@ -350,15 +652,94 @@ usage: i18n4go -c checkup
 
   -d                         the directory containing the go files to validate
   --i18n-strings-filename    a JSON file with the strings that should be i18n enabled, typically the output of -extract-strings command
+  --missing-usage-filename   [optional] a JSON file of the strings missing at runtime saved by an i18n.MissingCollector, the most used missing strings
+                             are shown first and the ones missing from the language file are shown too
+  --format                   [optional] the format of the findings written to the standard output: text, json, sarif or junit (default to 'text')
 
   CHECKUP:
 
   -c checkup                 the checkup command which ensures that the strings in code match strings in resource files and vice versa
+  --source-language          [optional] the locale of the source translation files, e.g., en_GB for en_GB.all.json (default to 'en_US' when neither the flag nor the configuration file set it)
+  -d                         [optional] the directory with the Go files, defaults to the working directory
+  --i18n-strings-dirname     [optional] the directory with the *.all.json translation files, defaults to the working directory
   -q                         the qualifier to use when calling the T(...), defaults to empty but can be used to set to something like i18n for example, such that, i18n.T(...) is used for T(...) function
+  --t-funcs                  [optional] a comma separated list of the names of the translate funcs, defaults to "T,t"
+  --format                   [optional] the format of the findings written to the standard output: text, json, sarif or junit (default to 'text')
 
   FIXUP:
 
   -c fixup                   the fixup command which interactively lets users add, update, or remove translations keys from code and resource files.
+  --source-language          [optional] the locale of the source translation files, e.g., en_GB for en_GB.all.json (default to 'en_US' when neither the flag nor the configuration file set it)
+  -d                         [optional] the directory with the Go files, defaults to the working directory
+  --i18n-strings-dirname     [optional] the directory with the *.all.json translation files, defaults to the working directory
+  --non-interactive          pairs new and removed strings automatically, without asking the user, e.g., in CI
+  --rename-threshold         [optional] the similarity score (0 to 1) above which a new string is considered an update of a removed string, defaults to 0.5
+  --rename-file              [optional] a JSON file mapping removed strings to the new strings that update them, applied before the similarity pairing
+  --report                   [optional] a JSON file where the source locale and the strings added, updated and removed are written
+
+  IMPORT-PO:
+
+  -c import-po               the import PO command which converts translated .po files, e.g., fr_FR.all.po, back into *.all.json files, e.g., fr_FR.all.json
+  -f                         the .po file to import
+  -d                         the directory with the .po files to import, use -r to also import the .po files in sub directories
+  -o                         [optional] the output directory, defaults to the directory of each .po file
+
+  EXPORT-XLIFF:
+
+  -c export-xliff            the export XLIFF command which creates an XLIFF file per language from the source translation file and the language translation files
+  -f                         the source translation file, e.g., en.all.json
+  -d                         [optional] a directory with the *.extracted.json files created with extract-strings --meta, their file:line locations, contexts and translator comments become notes
+  -o                         [optional] the output directory, defaults to the directory of the source translation file
+  --languages                a comma separated list of languages whose translation files are next to the source translation file
+  --language-files           a comma separated list of translation files, instead of --languages
+  --xliff-version            [optional] the XLIFF version, 1.2 (default) or 2.0
+
+  IMPORT-XLIFF:
+
+  -c import-xliff            the import XLIFF command which converts translated XLIFF files back into translation files, e.g., fr.all.xlf into fr.all.json
+  -f                         the source translation file, the IDs of the XLIFF units must be in it
+  -o                         [optional] the output directory, defaults to the directory of each XLIFF file
+  --xliff-files              a comma separated list of XLIFF 1.2 or 2.0 files to import
+
+  PSEUDO-LOCALIZE:
+
+  -c pseudo-localize         the pseudo-localize command which creates a synthetic translation file with accented, bracketed and longer strings
+  -f                         the source translation file, e.g., en.all.json
+  -o                         [optional] the output directory, defaults to the directory of the source translation file
+  --languages                [optional] a comma separated list of the pseudo languages, defaults to en_XA, or ar_XB with --pseudo-rtl
+  --source-language          [optional] the source language of the file, replaced with the pseudo language in the file name (default to 'en')
+  --pseudo-expansion         [optional] the percentage of characters added to the strings, e.g., 30 to 50 (default 30)
+  --pseudo-rtl               [optional] wraps the strings of all the languages to be shown right to left, not only those of ar_XB
+
+  UPDATE-TM:
+
+  -c update-tm               the update translation memory command which adds the reviewed translations of the translation files to the translation memory
+  --tm                       the translation memory file, created if it does not exist
+  -f                         the source translation file, e.g., en.all.json
+  --languages                a comma separated list of languages whose translation files are next to the source translation file
+  --language-files           a comma separated list of translation files, instead of --languages
+  --source-language          [optional] the source language of the file (default to 'en')
+
+  IMPORT-TMX:
+
+  -c import-tmx              the import TMX command which adds the translations of a TMX file to the translation memory
+  --tm                       the translation memory file, created if it does not exist
+  --tmx                      the TMX file to import
+
+  EXPORT-TMX:
+
+  -c export-tmx              the export TMX command which writes the translation memory in a TMX file
+  --tm                       the translation memory file
+  --tmx                      the TMX file to create
+  --source-language          [optional] the source language of the translations to export (default to 'en')
+
+  GENERATE-ACCESSORS:
+
+  -c generate-accessors      the generate accessors command which creates an i18n_accessors.go file with a func per string, e.g., MsgDeletingApp(name string)
+                             for "Deleting app {{.Name}}...", so that a typo in an ID or a template arg is a compile error
+  -f                         the source translation file, e.g., en.all.json
+  -o                         the directory of the package with the T() func, e.g., with its i18n_init.go
+  --dry-run                  [optional] prevents the accessors file from being created
 `
 	fmt.Println(fmt.Sprintf("%s\nVersion %s", usageString, VERSION))
 }
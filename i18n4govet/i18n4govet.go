@@ -0,0 +1,13 @@
+// The i18n4govet command runs the i18n4go analyzer, standalone or with
+// go vet -vettool=$(which i18n4govet)
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/EverlongProject/i18n4go/analyzer"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
@@ -1,10 +1,12 @@
 package checkup_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/EverlongProject/i18n4go/common"
 	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -73,6 +75,24 @@ var _ = Describe("checkup", func() {
 		})
 	})
 
+	Context("when a missing string is ignored with an //i18n4go:ignore directive", func() {
+		BeforeEach(func() {
+			fixturesPath = filepath.Join("..", "..", "test_fixtures", "checkup", "directives")
+			err = os.Chdir(fixturesPath)
+			Ω(err).ToNot(HaveOccurred(), "Could not change to fixtures directory")
+
+			session = Runi18n("-c", "checkup", "-v")
+		})
+
+		It("returns 0", func() {
+			Ω(session.ExitCode()).Should(Equal(0))
+		})
+
+		It("does not report the ignored string", func() {
+			Ω(session).ShouldNot(Say("Not yet translated"))
+		})
+	})
+
 	Context("When there are problems", func() {
 		BeforeEach(func() {
 			fixturesPath = filepath.Join("..", "..", "test_fixtures", "checkup", "notsogood")
@@ -100,4 +120,106 @@ var _ = Describe("checkup", func() {
 			Ω(session.ExitCode()).Should(Equal(1))
 		})
 	})
+
+	Context("when there is a project configuration file", func() {
+		BeforeEach(func() {
+			fixturesPath = filepath.Join("..", "..", "test_fixtures", "checkup", "config", "src", "code")
+			err = os.Chdir(fixturesPath)
+			Ω(err).ToNot(HaveOccurred(), "Could not change to fixtures directory")
+		})
+
+		It("uses its source language, directories and translate funcs", func() {
+			session = Runi18n("-c", "checkup", "-v")
+
+			Ω(session).Should(Say("OK"))
+			Ω(session.ExitCode()).Should(Equal(0))
+		})
+
+		It("is overridden by the flags", func() {
+			session = Runi18n("-c", "checkup", "-v", "--t-funcs", "T")
+
+			Ω(session).Should(Say("\"Hallo Welt!\" exists in de_DE, but not in the code"))
+			Ω(session.ExitCode()).Should(Equal(1))
+		})
+	})
+
+	Context("when the source locale and directories are given", func() {
+		BeforeEach(func() {
+			fixturesPath = filepath.Join("..", "..", "test_fixtures", "checkup", "source_language")
+			err = os.Chdir(fixturesPath)
+			Ω(err).ToNot(HaveOccurred(), "Could not change to fixtures directory")
+		})
+
+		It("compares the translations to the --source-language translation files", func() {
+			session = Runi18n("-c", "checkup", "-v", "--source-language", "en_GB", "-d", "src", "--i18n-strings-dirname", "resources")
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("\"Favourite flavour\" exists in en_GB, but not in fr_FR"))
+			Ω(output).ShouldNot(ContainSubstring("Outside of the sources"))
+			Ω(session.ExitCode()).Should(Equal(1))
+		})
+
+		It("names the source locale it could not find", func() {
+			session = Runi18n("-c", "checkup", "-v", "-d", "src", "--i18n-strings-dirname", "resources")
+
+			Ω(session).Should(Say("Could not find an i18n file for locale: en_US"))
+			Ω(session.ExitCode()).Should(Equal(1))
+		})
+	})
+
+	Context("when the source language is en", func() {
+		BeforeEach(func() {
+			fixturesPath = filepath.Join("..", "..", "test_fixtures", "checkup", "source_language_en")
+			err = os.Chdir(fixturesPath)
+			Ω(err).ToNot(HaveOccurred(), "Could not change to fixtures directory")
+		})
+
+		It("compares the translations to the en translation files", func() {
+			session = Runi18n("-c", "checkup", "-v", "--source-language", "en", "-d", "src", "--i18n-strings-dirname", "resources")
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("\"Favorite flavor\" exists in en, but not in fr_FR"))
+			Ω(output).ShouldNot(ContainSubstring("en_US"))
+			Ω(session.ExitCode()).Should(Equal(1))
+		})
+	})
+
+	Context("when the findings are written in another format", func() {
+		BeforeEach(func() {
+			fixturesPath = filepath.Join("..", "..", "test_fixtures", "checkup", "notsogood")
+			err = os.Chdir(fixturesPath)
+			Ω(err).ToNot(HaveOccurred(), "Could not change to fixtures directory")
+		})
+
+		It("prints the inconsistent strings without -v in the text format", func() {
+			session = Runi18n("-c", "checkup")
+
+			Ω(session).Should(Say("\"Heal the world\" exists in the code, but not in en_US"))
+			Ω(session.ExitCode()).Should(Equal(1))
+		})
+
+		It("writes the findings in JSON, with the position of the strings of the code", func() {
+			session = Runi18n("-c", "checkup", "-format", "json")
+
+			var findings []common.Finding
+			Ω(json.Unmarshal(session.Out.Contents(), &findings)).Should(Succeed())
+
+			Ω(findings).Should(HaveLen(4))
+			Ω(findings[0]).Should(Equal(common.Finding{
+				RuleID:   common.RULE_MISSING_STRING,
+				Severity: common.SEVERITY_ERROR,
+				Message:  "\"Heal the world\" exists in the code, but not in en_US",
+				Locale:   "en_US",
+				Key:      "Heal the world",
+				Filename: filepath.Join("src", "code", "main.go"),
+				Line:     7,
+				Column:   15,
+				Fix:      "Add \"Heal the world\" to " + filepath.Join("translations", "en_US.all.json"),
+			}))
+			Ω(findings[3].RuleID).Should(Equal(common.RULE_MISSING_TRANSLATION))
+			Ω(findings[3].Locale).Should(Equal("zh_CN"))
+			Ω(findings[3].Key).Should(Equal("And the entire human race"))
+			Ω(session.ExitCode()).Should(Equal(1))
+		})
+	})
 })
@@ -0,0 +1,157 @@
+package create_translations_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/EverlongProject/i18n4go/common"
+	"github.com/EverlongProject/i18n4go/translators"
+	"github.com/EverlongProject/i18n4go/translators/translatorstest"
+
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("create-translations --translator name", func() {
+	var (
+		inputFilesPath string
+		outputPath     string
+		server         *translatorstest.Server
+	)
+
+	BeforeEach(func() {
+		inputFilesPath = filepath.Join("..", "..", "test_fixtures", "create_translations", "f_option", "input_files")
+
+		var err error
+		outputPath, err = ioutil.TempDir("", "i18n4go4go")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		server = translatorstest.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(outputPath)
+	})
+
+	loadTranslations := func(fileName string) map[string]string {
+		i18nStringInfos, err := common.LoadI18nStringInfos(filepath.Join(outputPath, fileName))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		translations := make(map[string]string)
+		for _, i18nStringInfo := range i18nStringInfos {
+			translations[i18nStringInfo.ID] = i18nStringInfo.Translation
+		}
+		return translations
+	}
+
+	Context("with the offline pseudo translator", func() {
+		It("pseudo-localizes the strings", func() {
+			session := Runi18n("-c", "create-translations", "-v", "-f", filepath.Join(inputFilesPath, "quota.go.en.json"), "--languages", "fr", "-o", outputPath, "--translator", "pseudo")
+			Ω(session.ExitCode()).Should(Equal(0))
+
+			translations := loadTranslations("quota.go.fr.json")
+			Ω(translations).Should(HaveLen(7))
+			Ω(translations["Getting quota {{.QuotaName}} info as {{.Username}}..."]).Should(Equal("[Ĝéţţíñĝ ǫúóţá {{.QuotaName}} íñƒó áš {{.Username}}...]"))
+		})
+	})
+
+	Context("with a translator configured in a file", func() {
+		var configFilename string
+
+		BeforeEach(func() {
+			configFilename = filepath.Join(outputPath, "translators.json")
+			content, err := json.Marshal(translators.ConfigFile{
+				Translator: "deepl",
+				Translators: map[string]translators.Config{
+					"deepl": {URL: server.URL, APIKeyEnv: "I18N4GO_TEST_DEEPL_KEY"},
+					"http":  {URL: server.URL + "/translate-json"},
+				},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ioutil.WriteFile(configFilename, content, 0644)).Should(Succeed())
+		})
+
+		It("uses the translator of the file", func() {
+			session := Runi18n("-c", "create-translations", "-v", "-f", filepath.Join(inputFilesPath, "quota.go.en.json"), "--languages", "fr,de", "-o", outputPath, "--translator-config", configFilename)
+			Ω(session.ExitCode()).Should(Equal(0))
+
+			Ω(loadTranslations("quota.go.fr.json")["Memory"]).Should(Equal(translatorstest.Translate("Memory", "FR")))
+			Ω(loadTranslations("quota.go.de.json")["Memory"]).Should(Equal(translatorstest.Translate("Memory", "DE")))
+			Ω(server.Requests()[0].Path).Should(Equal("/v2/translate"))
+		})
+
+		It("uses the translator of the --translator flag", func() {
+			session := Runi18n("-c", "create-translations", "-v", "-f", filepath.Join(inputFilesPath, "quota.go.en.json"), "--languages", "fr", "-o", outputPath, "--translator-config", configFilename, "--translator", "http")
+			Ω(session.ExitCode()).Should(Equal(0))
+
+			Ω(loadTranslations("quota.go.fr.json")["Routes"]).Should(Equal(translatorstest.Translate("Routes", "fr")))
+			Ω(server.Requests()[0].Path).Should(Equal("/translate-json"))
+		})
+	})
+
+	Context("when some strings cannot be translated", func() {
+		var args []string
+
+		BeforeEach(func() {
+			server.TranslateFunc = func(text, targetLanguage string) string {
+				if strings.HasPrefix(text, "Getting quota") {
+					return "Obtention du quota"
+				}
+				return translatorstest.Translate(text, targetLanguage)
+			}
+
+			args = []string{"-c", "create-translations", "-v", "-f", filepath.Join(inputFilesPath, "quota.go.en.json"), "--languages", "fr", "-o", outputPath,
+				"--translator", "libretranslate", "--translator-config", filepath.Join(outputPath, "translators.json"), "--translate-batch-size", "3"}
+
+			content, err := json.Marshal(translators.ConfigFile{Translators: map[string]translators.Config{"libretranslate": {URL: server.URL}}})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ioutil.WriteFile(filepath.Join(outputPath, "translators.json"), content, 0644)).Should(Succeed())
+		})
+
+		It("reports them, leaves them out and resumes the translation on the next run", func() {
+			session := Runi18n(args...)
+			Ω(session.ExitCode()).Should(Equal(1))
+			Ω(session.Out).Should(gbytes.Say(`could not translate string "Getting quota`))
+			Ω(server.Requests()).Should(HaveLen(3))
+
+			translations := loadTranslations("quota.go.fr.json")
+			Ω(translations).Should(HaveLen(6))
+			Ω(translations).ShouldNot(HaveKey(""))
+			Ω(translations).ShouldNot(HaveKey("Getting quota {{.QuotaName}} info as {{.Username}}..."))
+
+			_, err := os.Stat(filepath.Join(outputPath, "quota.go.fr.json.progress"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			server.TranslateFunc = nil
+			session = Runi18n(args...)
+			Ω(session.ExitCode()).Should(Equal(0))
+
+			requests := server.Requests()
+			Ω(requests).Should(HaveLen(4))
+			Ω(requests[3].Texts).Should(Equal([]string{"Getting quota ⟦0⟧ info as ⟦1⟧..."}))
+
+			translations = loadTranslations("quota.go.fr.json")
+			Ω(translations).Should(HaveLen(7))
+			Ω(translations["Getting quota {{.QuotaName}} info as {{.Username}}..."]).Should(Equal(translatorstest.Translate("Getting quota {{.QuotaName}} info as {{.Username}}...", "fr")))
+
+			_, err = os.Stat(filepath.Join(outputPath, "quota.go.fr.json.progress"))
+			Ω(os.IsNotExist(err)).Should(BeTrue())
+		})
+	})
+
+	Context("with an unknown translator", func() {
+		It("fails without creating the language file", func() {
+			session := Runi18n("-c", "create-translations", "-v", "-f", filepath.Join(inputFilesPath, "quota.go.en.json"), "--languages", "fr", "-o", outputPath, "--translator", "babelfish")
+			Ω(session.ExitCode()).Should(Equal(1))
+
+			_, err := os.Stat(filepath.Join(outputPath, "quota.go.fr.json"))
+			Ω(os.IsNotExist(err)).Should(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,79 @@
+package extract_strings_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/EverlongProject/i18n4go/common"
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("extract-strings with // i18n: comments", func() {
+	var (
+		inputFilePath     string
+		expectedFilesPath string
+		outputPath        string
+		session           *Session
+	)
+
+	BeforeEach(func() {
+		var err error
+		outputPath, err = ioutil.TempDir("", "i18n4go4go")
+		Ω(err).ToNot(HaveOccurred())
+
+		fixturesPath := filepath.Join("..", "..", "test_fixtures", "extract_strings", "annotations")
+		inputFilePath = filepath.Join(fixturesPath, "input_files", "app.go")
+		expectedFilesPath = filepath.Join(fixturesPath, "expected_output")
+
+		session = Runi18n("-c", "extract-strings", "-v", "--po", "--meta", "-f", inputFilePath, "-o", outputPath)
+		Ω(session.ExitCode()).Should(Equal(0))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(outputPath)
+	})
+
+	It("keeps the translator comments and contexts in the metadata", func() {
+		content, err := ioutil.ReadFile(filepath.Join(outputPath, "app.go.extracted.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		var stringInfos []common.StringInfo
+		Ω(json.Unmarshal(content, &stringInfos)).Should(Succeed())
+
+		annotations := make(map[string][]string)
+		for _, stringInfo := range stringInfos {
+			annotations[stringInfo.Key()] = stringInfo.Comments
+		}
+		Ω(annotations).Should(Equal(map[string][]string{
+			common.StringKey("Stopped", "app state"): {"the state of an app that is not running"},
+			common.StringKey("Stopped", "button"):    nil,
+			"Space":                                  {"the space key of the keyboard"},
+			"Done":                                   nil,
+		}))
+	})
+
+	It("writes the contexts and comments in the PO file", func() {
+		expectedOutput, err := ioutil.ReadFile(filepath.Join(expectedFilesPath, "app.go.en.po"))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		actualOutput, err := ioutil.ReadFile(filepath.Join(outputPath, "app.go.en.po"))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(string(actualOutput)).Should(Equal(string(expectedOutput)))
+	})
+
+	It("saves a string in several contexts once in the translation file", func() {
+		translationFilename := strings.Replace(inputFilePath, string(os.PathSeparator), "-", -1) + ".en.json"
+		i18nStringInfos, err := common.LoadI18nStringInfos(filepath.Join(outputPath, translationFilename))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(i18nStringInfos).Should(HaveLen(3))
+		Ω(session).Should(Say("the contexts are only kept in the .po file: Stopped"))
+	})
+})
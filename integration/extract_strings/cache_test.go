@@ -0,0 +1,123 @@
+package extract_strings_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("extract-strings -d dirName with the extraction cache", func() {
+	var (
+		workPath   string
+		sourcePath string
+		outputPath string
+		cachePath  string
+	)
+
+	BeforeEach(func() {
+		var err error
+		workPath, err = ioutil.TempDir("", "i18n4go4go")
+		Ω(err).ToNot(HaveOccurred())
+
+		sourcePath = filepath.Join(workPath, "src")
+		outputPath = filepath.Join(workPath, "out")
+		cachePath = filepath.Join(workPath, ".i18n4go-cache")
+		Ω(os.Mkdir(sourcePath, 0755)).Should(Succeed())
+
+		inputFilesPath := filepath.Join("..", "..", "test_fixtures", "extract_strings", "cache", "input_files")
+		for _, fileName := range []string{"hello.go", "goodbye.go"} {
+			CopyFile(filepath.Join(inputFilesPath, fileName), filepath.Join(sourcePath, fileName))
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(workPath)
+	})
+
+	outputFilename := func(fileName string) string {
+		return filepath.Join(outputPath, fileName+".extracted.json")
+	}
+
+	Context("with --cache-dir", func() {
+		extract := func() *Session {
+			session := Runi18n("-c", "extract-strings", "-v", "--meta", "-d", sourcePath, "-o", outputPath, "--cache-dir", cachePath, "--ignore-regexp", "^[.]\\w+.go$")
+			Ω(session.ExitCode()).Should(Equal(0))
+			return session
+		}
+
+		BeforeEach(func() {
+			extract()
+		})
+
+		It("saves the cache", func() {
+			Ω(filepath.Join(cachePath, "index.json")).Should(BeAnExistingFile())
+			Ω(outputFilename("hello.go")).Should(BeAnExistingFile())
+			Ω(outputFilename("goodbye.go")).Should(BeAnExistingFile())
+		})
+
+		It("skips the unchanged files", func() {
+			session := extract()
+			Ω(session).Should(Say("Total files parsed: 0"))
+			Ω(session).Should(Say("Total files unchanged: 2"))
+		})
+
+		It("extracts the strings of the changed files again", func() {
+			CopyFile(filepath.Join(sourcePath, "goodbye.go"), filepath.Join(sourcePath, "hello.go"))
+
+			session := extract()
+			Ω(session).Should(Say("Total files parsed: 1"))
+
+			content, err := ioutil.ReadFile(outputFilename("hello.go"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(content)).Should(ContainSubstring("Goodbye world!"))
+		})
+
+		It("saves the outputs removed since the previous extraction", func() {
+			Ω(os.Remove(outputFilename("hello.go"))).Should(Succeed())
+
+			extract()
+			Ω(outputFilename("hello.go")).Should(BeAnExistingFile())
+		})
+
+		It("removes the outputs of the deleted files", func() {
+			Ω(os.Remove(filepath.Join(sourcePath, "goodbye.go"))).Should(Succeed())
+
+			extract()
+			Ω(outputFilename("goodbye.go")).ShouldNot(BeAnExistingFile())
+			Ω(outputFilename("hello.go")).Should(BeAnExistingFile())
+		})
+	})
+
+	Context("with --since", func() {
+		BeforeEach(func() {
+			git := func(args ...string) {
+				session := RunCommand("git", append([]string{"-C", workPath, "-c", "user.name=i18n4go", "-c", "user.email=i18n4go@example.com"}, args...)...)
+				Ω(session.ExitCode()).Should(Equal(0))
+			}
+			git("init", "-q")
+			git("add", "-A")
+			git("commit", "-q", "-m", "sources")
+
+			CopyFile(filepath.Join(sourcePath, "goodbye.go"), filepath.Join(sourcePath, "hello.go"))
+		})
+
+		It("only extracts the strings of the files changed since the git ref", func() {
+			session := Runi18n("-c", "extract-strings", "-v", "--meta", "-d", sourcePath, "-o", outputPath, "--since", "HEAD", "--ignore-regexp", "^[.]\\w+.go$")
+			Ω(session.ExitCode()).Should(Equal(0))
+
+			Ω(outputFilename("hello.go")).Should(BeAnExistingFile())
+			Ω(outputFilename("goodbye.go")).ShouldNot(BeAnExistingFile())
+		})
+
+		It("fails with an unknown git ref", func() {
+			session := Runi18n("-c", "extract-strings", "-v", "-d", sourcePath, "-o", outputPath, "--since", "unknown-ref")
+			Ω(session.ExitCode()).Should(Equal(1))
+		})
+	})
+})
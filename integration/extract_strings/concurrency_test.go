@@ -0,0 +1,54 @@
+package extract_strings_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("extract-strings -d dirName --extract-concurrency", func() {
+	var (
+		inputFilesPath string
+		outputPaths    []string
+	)
+
+	BeforeEach(func() {
+		inputFilesPath = filepath.Join("..", "..", "test_fixtures", "extract_strings", "d_option", "input_files")
+
+		outputPaths = nil
+		for _, concurrency := range []string{"1", "8", "8"} {
+			outputPath, err := ioutil.TempDir("", "i18n4go4go")
+			Ω(err).ToNot(HaveOccurred())
+			outputPaths = append(outputPaths, outputPath)
+
+			session := Runi18n("-c", "extract-strings", "-v", "--po", "--meta", "-d", inputFilesPath, "-r", "-o", outputPath, "--extract-concurrency", concurrency, "--ignore-regexp", "^[.]\\w+.go$")
+			Ω(session.ExitCode()).Should(Equal(0))
+		}
+	})
+
+	AfterEach(func() {
+		for _, outputPath := range outputPaths {
+			os.RemoveAll(outputPath)
+		}
+	})
+
+	It("writes the same outputs whatever the number of files extracted at the same time", func() {
+		fileInfos, err := ioutil.ReadDir(outputPaths[0])
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(fileInfos).ShouldNot(BeEmpty())
+
+		for _, outputPath := range outputPaths[1:] {
+			otherFileInfos, err := ioutil.ReadDir(outputPath)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(otherFileInfos).Should(HaveLen(len(fileInfos)))
+
+			for _, fileInfo := range fileInfos {
+				CompareExpectedOutputToGeneratedOutput(filepath.Join(outputPaths[0], fileInfo.Name()), filepath.Join(outputPath, fileInfo.Name()))
+			}
+		}
+	})
+})
@@ -0,0 +1,56 @@
+package extract_strings_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/EverlongProject/i18n4go/common"
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("extract-strings with //i18n4go:ignore directives", func() {
+	var (
+		inputFilesPath string
+		outputPath     string
+	)
+
+	BeforeEach(func() {
+		var err error
+		outputPath, err = ioutil.TempDir("", "i18n4go4go")
+		Ω(err).ToNot(HaveOccurred())
+
+		inputFilesPath = filepath.Join("..", "..", "test_fixtures", "extract_strings", "directives", "input_files")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(outputPath)
+	})
+
+	It("does not extract the strings of the ignored lines and blocks", func() {
+		session := Runi18n("-c", "extract-strings", "-v", "--meta", "-f", filepath.Join(inputFilesPath, "app.go"), "-o", outputPath)
+		Ω(session.ExitCode()).Should(Equal(0))
+
+		content, err := ioutil.ReadFile(filepath.Join(outputPath, "app.go.extracted.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		var stringInfos []common.StringInfo
+		Ω(json.Unmarshal(content, &stringInfos)).Should(Succeed())
+
+		var values []string
+		for _, stringInfo := range stringInfos {
+			values = append(values, stringInfo.Value)
+		}
+		Ω(values).Should(ConsistOf("Hello world!", "See you soon!"))
+	})
+
+	It("does not extract the strings of an ignored file", func() {
+		session := Runi18n("-c", "extract-strings", "-v", "--meta", "-f", filepath.Join(inputFilesPath, "internal.go"), "-o", outputPath)
+		Ω(session.ExitCode()).Should(Equal(0))
+
+		Ω(filepath.Join(outputPath, "internal.go.extracted.json")).ShouldNot(BeAnExistingFile())
+	})
+})
@@ -0,0 +1,79 @@
+package extract_strings_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("extract-strings with templates", func() {
+	var (
+		inputFilesPath    string
+		expectedFilesPath string
+		outputPath        string
+	)
+
+	// the translation files are named after the path of their input file
+	outputFilename := func(dirName, fileName string) string {
+		path := filepath.Join(inputFilesPath, fileName)
+		return filepath.Join(dirName, strings.Replace(path, string(os.PathSeparator), "-", -1)+".en.json")
+	}
+
+	BeforeEach(func() {
+		var err error
+		outputPath, err = ioutil.TempDir("", "i18n4go4go")
+		Ω(err).ToNot(HaveOccurred())
+
+		fixturesPath := filepath.Join("..", "..", "test_fixtures", "extract_strings", "templates")
+		inputFilesPath = filepath.Join(fixturesPath, "input_files")
+		expectedFilesPath = filepath.Join(fixturesPath, "expected_output")
+
+		for _, fileName := range []string{"page.tmpl", "mail.go"} {
+			session := Runi18n("-c", "extract-strings", "-v", "--meta", "-f", filepath.Join(inputFilesPath, fileName), "-o", outputPath)
+			Ω(session.ExitCode()).Should(Equal(0))
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(outputPath)
+	})
+
+	It("extracts the texts and the {{T}} strings of the template files", func() {
+		CompareExpectedToGeneratedTraslationJson(
+			filepath.Join(expectedFilesPath, "page.tmpl.en.json"),
+			outputFilename(outputPath, "page.tmpl"),
+		)
+	})
+
+	It("extracts the texts of the template literals instead of the whole template", func() {
+		CompareExpectedToGeneratedTraslationJson(
+			filepath.Join(expectedFilesPath, "mail.go.en.json"),
+			outputFilename(outputPath, "mail.go"),
+		)
+	})
+
+	It("records the line and column of the template strings", func() {
+		stringInfos := ReadJsonExtended(filepath.Join(outputPath, "page.tmpl.extracted.json"))
+		Ω(stringInfos["Hello"]["line"]).Should(Equal("4"))
+		Ω(stringInfos["Hello"]["column"]).Should(Equal("7"))
+		Ω(stringInfos["No messages"]["line"]).Should(Equal("5"))
+		Ω(stringInfos["No messages"]["column"]).Should(Equal("60"))
+
+		stringInfos = ReadJsonExtended(filepath.Join(outputPath, "mail.go.extracted.json"))
+		Ω(stringInfos["Your order has shipped."]["line"]).Should(Equal("5"))
+		Ω(stringInfos["Thank you for your order"]["line"]).Should(Equal("6"))
+	})
+
+	It("extracts the strings of the template files of a dir", func() {
+		dirOutputPath := filepath.Join(outputPath, "dir")
+		session := Runi18n("-c", "extract-strings", "-v", "-d", inputFilesPath, "-o", dirOutputPath, "--ignore-regexp", "^[.]\\w+.go$")
+		Ω(session.ExitCode()).Should(Equal(0))
+
+		Ω(outputFilename(dirOutputPath, "page.tmpl")).Should(BeAnExistingFile())
+	})
+})
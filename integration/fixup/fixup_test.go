@@ -2,6 +2,7 @@ package fixup_test
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -10,6 +11,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/EverlongProject/i18n4go/cmds"
 	"github.com/EverlongProject/i18n4go/common"
 
 	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
@@ -20,6 +22,7 @@ import (
 var _ = Describe("fixup", func() {
 	var (
 		fixturesPath string
+		args         []string
 		cmd          *exec.Cmd
 		stdinPipe    io.WriteCloser
 		stdoutPipe   io.ReadCloser
@@ -30,6 +33,8 @@ var _ = Describe("fixup", func() {
 	)
 
 	BeforeEach(func() {
+		args = []string{"-c", "fixup"}
+
 		curDir, err = os.Getwd()
 		if err != nil {
 			fmt.Println("Could not get working directory")
@@ -69,7 +74,7 @@ var _ = Describe("fixup", func() {
 		}
 
 		//session = Runi18n("-c", "fixup")
-		cmd = exec.Command(I18n4goExec, "-c", "fixup")
+		cmd = exec.Command(I18n4goExec, args...)
 
 		stdinPipe, err = cmd.StdinPipe()
 		if err != nil {
@@ -288,6 +293,194 @@ var _ = Describe("fixup", func() {
 		})
 	})
 
+	Context("When fixup is run with -non-interactive", func() {
+		var reportFilename string
+
+		BeforeEach(func() {
+			fixturesPath = filepath.Join("..", "..", "test_fixtures", "fixup", "notsogood", "update")
+
+			reportFile, err := ioutil.TempFile("", "fixup_report")
+			Ω(err).ShouldNot(HaveOccurred())
+			reportFilename = reportFile.Name()
+			reportFile.Close()
+
+			args = append(args, "-non-interactive", "-report", reportFilename)
+		})
+
+		AfterEach(func() {
+			os.Remove(reportFilename)
+		})
+
+		It("does not prompt the user", func() {
+			stdinPipe.Close()
+			output, err := ioutil.ReadAll(stdoutReader)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(output)).ShouldNot(ContainSubstring("new or updated string"))
+			Ω(string(output)).Should(HaveSuffix("OK"))
+
+			exitCode := cmd.Wait()
+			Ω(exitCode).Should(BeNil())
+		})
+
+		It("updates similar strings and marks the foreign language translations as updated", func() {
+			cmd.Wait()
+
+			translations, err := common.LoadI18nStringInfos(filepath.Join(".", "translations", "en_US.all.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			mappedTranslations, err := common.CreateI18nStringInfoMap(translations)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(mappedTranslations["I like bananas."]).Should(Equal(common.I18nStringInfo{}))
+			Ω(mappedTranslations["I like apples."]).Should(Equal(common.I18nStringInfo{ID: "I like apples.", Translation: "I like apples."}))
+
+			translations, err = common.LoadI18nStringInfos(filepath.Join(".", "translations", "zh_CN.all.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			mappedTranslations, err = common.CreateI18nStringInfoMap(translations)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(mappedTranslations["I like bananas."]).Should(Equal(common.I18nStringInfo{}))
+			Ω(mappedTranslations["I like apples."]).Should(Equal(common.I18nStringInfo{ID: "I like apples.", Translation: "我喜欢吃香蕉", Modified: true}))
+		})
+
+		It("writes the decisions to the report", func() {
+			cmd.Wait()
+
+			report := readFixupReport(reportFilename)
+			Ω(report.SourceLocale).Should(Equal("en_US"))
+			Ω(report.Added).Should(BeEmpty())
+			Ω(report.Removed).Should(BeEmpty())
+			Ω(report.Updated).Should(HaveLen(1))
+			Ω(report.Updated[0].From).Should(Equal("I like bananas."))
+			Ω(report.Updated[0].To).Should(Equal("I like apples."))
+			Ω(report.Updated[0].Reason).Should(Equal("similarity"))
+		})
+
+		Context("when the strings are less similar than -rename-threshold", func() {
+			BeforeEach(func() {
+				args = append(args, "-rename-threshold", "0.9")
+			})
+
+			It("adds the new string and removes the old one", func() {
+				cmd.Wait()
+
+				translations, err := common.LoadI18nStringInfos(filepath.Join(".", "translations", "zh_CN.all.json"))
+				Ω(err).ShouldNot(HaveOccurred())
+				mappedTranslations, err := common.CreateI18nStringInfoMap(translations)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(mappedTranslations["I like bananas."]).Should(Equal(common.I18nStringInfo{}))
+				Ω(mappedTranslations["I like apples."]).Should(Equal(common.I18nStringInfo{ID: "I like apples.", Translation: "I like apples."}))
+
+				report := readFixupReport(reportFilename)
+				Ω(report.Added).Should(Equal([]string{"I like apples."}))
+				Ω(report.Removed).Should(Equal([]string{"I like bananas."}))
+				Ω(report.Updated).Should(BeEmpty())
+			})
+		})
+
+		Context("when a -rename-file maps the old string to the new one", func() {
+			var renameFilename string
+
+			BeforeEach(func() {
+				renameFile, err := ioutil.TempFile("", "fixup_renames")
+				Ω(err).ShouldNot(HaveOccurred())
+				_, err = renameFile.WriteString(`{"I like bananas.": "I like apples."}`)
+				Ω(err).ShouldNot(HaveOccurred())
+				renameFilename = renameFile.Name()
+				renameFile.Close()
+
+				args = append(args, "-rename-threshold", "1", "-rename-file", renameFilename)
+			})
+
+			AfterEach(func() {
+				os.Remove(renameFilename)
+			})
+
+			It("updates the string regardless of the threshold", func() {
+				cmd.Wait()
+
+				translations, err := common.LoadI18nStringInfos(filepath.Join(".", "translations", "zh_CN.all.json"))
+				Ω(err).ShouldNot(HaveOccurred())
+				mappedTranslations, err := common.CreateI18nStringInfoMap(translations)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(mappedTranslations["I like apples."].Modified).Should(BeTrue())
+
+				report := readFixupReport(reportFilename)
+				Ω(report.Updated).Should(HaveLen(1))
+				Ω(report.Updated[0].Reason).Should(Equal("rename-file"))
+			})
+		})
+	})
+
+	Context("When fixup renames a plural string", func() {
+		var renameFilename string
+
+		BeforeEach(func() {
+			fixturesPath = filepath.Join("..", "..", "test_fixtures", "fixup", "notsogood", "update_plural")
+
+			renameFile, err := ioutil.TempFile("", "fixup_renames")
+			Ω(err).ShouldNot(HaveOccurred())
+			_, err = renameFile.WriteString(`{"I like {{.Count}} bananas.": "I like {{.Count}} apples."}`)
+			Ω(err).ShouldNot(HaveOccurred())
+			renameFilename = renameFile.Name()
+			renameFile.Close()
+
+			args = append(args, "-non-interactive", "-rename-file", renameFilename)
+		})
+
+		AfterEach(func() {
+			os.Remove(renameFilename)
+		})
+
+		It("keeps the plural translations", func() {
+			Ω(cmd.Wait()).Should(BeNil())
+
+			translations, err := common.LoadI18nStringInfos(filepath.Join(".", "translations", "en_US.all.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			mappedTranslations, err := common.CreateI18nStringInfoMap(translations)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(mappedTranslations["I like {{.Count}} bananas."]).Should(Equal(common.I18nStringInfo{}))
+			Ω(mappedTranslations["I like {{.Count}} apples."]).Should(Equal(common.NewPluralI18nStringInfo("I like {{.Count}} apples.", map[string]string{"one": "I like {{.Count}} banana.", "other": "I like {{.Count}} apples."}, false)))
+
+			translations, err = common.LoadI18nStringInfos(filepath.Join(".", "translations", "zh_CN.all.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			mappedTranslations, err = common.CreateI18nStringInfoMap(translations)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(mappedTranslations["I like {{.Count}} bananas."]).Should(Equal(common.I18nStringInfo{}))
+			Ω(mappedTranslations["I like {{.Count}} apples."]).Should(Equal(common.NewPluralI18nStringInfo("I like {{.Count}} apples.", map[string]string{"other": "我喜欢吃{{.Count}}个香蕉"}, true)))
+		})
+
+		It("adds the plural forms of the source locale to the other languages", func() {
+			Ω(cmd.Wait()).Should(BeNil())
+
+			translations, err := common.LoadI18nStringInfos(filepath.Join(".", "translations", "zh_CN.all.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			mappedTranslations, err := common.CreateI18nStringInfoMap(translations)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(mappedTranslations["{{.Count}} files"]).Should(Equal(common.NewPluralI18nStringInfo("{{.Count}} files", map[string]string{"one": "{{.Count}} file", "other": "{{.Count}} files"}, false)))
+		})
+	})
+
+	Context("When fixup is run with --source-language and directories", func() {
+		BeforeEach(func() {
+			fixturesPath = filepath.Join("..", "..", "test_fixtures", "fixup", "source_language")
+			args = append(args, "-non-interactive", "--source-language", "en_GB", "-d", "src", "--i18n-strings-dirname", "resources")
+		})
+
+		It("adds the new strings to the source locale and the other languages", func() {
+			stdinPipe.Close()
+			output, err := ioutil.ReadAll(stdoutReader)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(output)).Should(HaveSuffix("OK"))
+			Ω(cmd.Wait()).Should(BeNil())
+
+			for _, locale := range []string{"en_GB", "fr_FR"} {
+				translations, err := common.LoadI18nStringInfos(filepath.Join(".", "resources", locale+".all.json"))
+				Ω(err).ShouldNot(HaveOccurred())
+				mappedTranslations, err := common.CreateI18nStringInfoMap(translations)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(mappedTranslations["Favourite flavour"].Translation).Should(Equal("Favourite flavour"))
+			}
+		})
+	})
+
 	Context("When a foreign language is missing an english translation", func() {
 		BeforeEach(func() {
 			fixturesPath = filepath.Join("..", "..", "test_fixtures", "fixup", "notsogood", "missing_foreign_key")
@@ -362,3 +555,13 @@ func getNextOutputLine(reader *bufio.Reader) string {
 
 	return string(line)
 }
+
+func readFixupReport(fileName string) cmds.FixupReport {
+	content, err := ioutil.ReadFile(fileName)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	var report cmds.FixupReport
+	Ω(json.Unmarshal(content, &report)).Should(Succeed())
+
+	return report
+}
@@ -0,0 +1,108 @@
+package generate_accessors_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("generate-accessors -f fileName -o packageDir", func() {
+	var (
+		outputDir         string
+		inputFilesPath    string
+		expectedFilesPath string
+	)
+
+	BeforeEach(func() {
+		var err error
+		outputDir, err = ioutil.TempDir("", "i18n4go_generate_accessors")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		fixturesPath := filepath.Join("..", "..", "test_fixtures", "generate_accessors")
+		inputFilesPath = filepath.Join(fixturesPath, "input_files")
+		expectedFilesPath = filepath.Join(fixturesPath, "expected_output")
+	})
+
+	AfterEach(func() {
+		err := os.RemoveAll(outputDir)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	Context("when the package directory has no Go file", func() {
+		BeforeEach(func() {
+			session := Runi18n("-c", "generate-accessors", "-v",
+				"-f", filepath.Join(inputFilesPath, "en.all.json"),
+				"-o", filepath.Join(outputDir, "app"),
+			)
+
+			Ω(session.ExitCode()).Should(Equal(0))
+		})
+
+		It("generates an accessor per string in a package named after the directory", func() {
+			expectedOutput, err := ioutil.ReadFile(filepath.Join(expectedFilesPath, "i18n_accessors.go"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			actualOutput, err := ioutil.ReadFile(filepath.Join(outputDir, "app", "i18n_accessors.go"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(string(actualOutput)).Should(Equal(string(expectedOutput)))
+		})
+	})
+
+	Context("when the package directory has Go files", func() {
+		BeforeEach(func() {
+			err := ioutil.WriteFile(filepath.Join(outputDir, "i18n_init.go"), []byte("package commands\n"), 0666)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			session := Runi18n("-c", "generate-accessors", "-v",
+				"-f", filepath.Join(inputFilesPath, "en.all.json"),
+				"-o", outputDir,
+			)
+
+			Ω(session.ExitCode()).Should(Equal(0))
+		})
+
+		It("generates the accessors in their package", func() {
+			actualOutput, err := ioutil.ReadFile(filepath.Join(outputDir, "i18n_accessors.go"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(string(actualOutput)).Should(ContainSubstring("\npackage commands\n"))
+		})
+	})
+
+	Context("when the accessors of two strings have the same name", func() {
+		It("fails naming both strings", func() {
+			session := Runi18n("-c", "generate-accessors", "-v",
+				"-f", filepath.Join(inputFilesPath, "collisions.all.json"),
+				"-o", outputDir,
+			)
+
+			Ω(session.ExitCode()).Should(Equal(1))
+			Ω(session).Should(gbytes.Say(`the accessors of "World!" and "world" are both named MsgWorld`))
+
+			_, err := os.Stat(filepath.Join(outputDir, "i18n_accessors.go"))
+			Ω(os.IsNotExist(err)).Should(BeTrue())
+		})
+	})
+
+	Context("with --dry-run", func() {
+		BeforeEach(func() {
+			session := Runi18n("-c", "generate-accessors", "-v", "--dry-run",
+				"-f", filepath.Join(inputFilesPath, "en.all.json"),
+				"-o", outputDir,
+			)
+
+			Ω(session.ExitCode()).Should(Equal(0))
+		})
+
+		It("does not create the accessors file", func() {
+			_, err := os.Stat(filepath.Join(outputDir, "i18n_accessors.go"))
+			Ω(os.IsNotExist(err)).Should(BeTrue())
+		})
+	})
+})
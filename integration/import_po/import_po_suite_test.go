@@ -0,0 +1,22 @@
+package import_po_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/EverlongProject/i18n4go/integration/test_helpers"
+	"github.com/onsi/gomega/gexec"
+
+	"testing"
+)
+
+func TestImportPo(t *testing.T) {
+	BeforeSuite(test_helpers.BuildExecutable)
+
+	AfterSuite(func() {
+		gexec.CleanupBuildArtifacts()
+	})
+
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ImportPo Suite")
+}
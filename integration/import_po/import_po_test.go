@@ -0,0 +1,92 @@
+package import_po_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/EverlongProject/i18n4go/common"
+
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("import-po", func() {
+	var (
+		fixturesPath      string
+		inputFilesPath    string
+		expectedFilesPath string
+		outputPath        string
+	)
+
+	BeforeEach(func() {
+		fixturesPath = filepath.Join("..", "..", "test_fixtures", "import_po")
+		inputFilesPath = filepath.Join(fixturesPath, "input_files")
+		expectedFilesPath = filepath.Join(fixturesPath, "expected_output")
+
+		var err error
+		outputPath, err = ioutil.TempDir("", "i18n4go4go")
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(outputPath)
+	})
+
+	Context("-f fileName -o outputDir", func() {
+		BeforeEach(func() {
+			session := Runi18n("-c", "import-po", "-v", "-f", filepath.Join(inputFilesPath, "ru_RU.all.po"), "-o", outputPath)
+			Ω(session.ExitCode()).Should(Equal(0))
+		})
+
+		It("converts the PO file into an *.all.json file with plurals and modified flags", func() {
+			expectedBytes, err := ioutil.ReadFile(filepath.Join(expectedFilesPath, "ru_RU.all.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			actualBytes, err := ioutil.ReadFile(filepath.Join(outputPath, "ru_RU.all.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(string(actualBytes)).Should(Equal(string(expectedBytes)))
+		})
+	})
+
+	Context("-d dirName -o outputDir", func() {
+		BeforeEach(func() {
+			session := Runi18n("-c", "import-po", "-v", "-d", inputFilesPath, "-o", outputPath)
+			Ω(session.ExitCode()).Should(Equal(0))
+		})
+
+		It("converts all the PO files of the directory", func() {
+			_, err := os.Stat(filepath.Join(outputPath, "ru_RU.all.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("create-translations --po", func() {
+		BeforeEach(func() {
+			session := Runi18n("-c", "create-translations", "-v", "--po", "-f", filepath.Join(fixturesPath, "en.all.json"), "--languages", "fr", "-o", outputPath)
+			Ω(session.ExitCode()).Should(Equal(0))
+		})
+
+		It("writes a PO file with headers that can be imported back", func() {
+			po, err := common.ReadPoFile(filepath.Join(outputPath, "fr.all.po"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(po.Language()).Should(Equal("fr"))
+			Ω(po.Header("Plural-Forms")).Should(Equal("nplurals=2; plural=(n > 1);"))
+			Ω(po.Entries).Should(HaveLen(2))
+			Ω(po.Entries[1].ID).Should(Equal("Usage:\n  app [options]\n"))
+			Ω(po.Entries[1].Translation).Should(BeEmpty())
+
+			session := Runi18n("-c", "import-po", "-v", "-f", filepath.Join(outputPath, "fr.all.po"))
+			Ω(session.ExitCode()).Should(Equal(0))
+
+			translations, err := common.LoadI18nStringInfos(filepath.Join(outputPath, "fr.all.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(translations).Should(Equal([]common.I18nStringInfo{
+				{ID: "Hello %s", Translation: "Hello %s", Modified: true},
+				{ID: "Usage:\n  app [options]\n", Translation: "Usage:\n  app [options]\n", Modified: true},
+			}))
+		})
+	})
+})
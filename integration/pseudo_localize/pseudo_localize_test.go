@@ -0,0 +1,82 @@
+package pseudo_localize_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pseudo-localize -f fileName", func() {
+	var (
+		inputFilesPath    string
+		expectedFilesPath string
+		outputPath        string
+	)
+
+	BeforeEach(func() {
+		fixturesPath := filepath.Join("..", "..", "test_fixtures", "pseudo_localize")
+		inputFilesPath = filepath.Join(fixturesPath, "input_files")
+		expectedFilesPath = filepath.Join(fixturesPath, "expected_output")
+
+		var err error
+		outputPath, err = ioutil.TempDir("", "i18n4go4go")
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(outputPath)
+	})
+
+	compareFiles := func(fileName string) {
+		expectedBytes, err := ioutil.ReadFile(filepath.Join(expectedFilesPath, fileName))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		actualBytes, err := ioutil.ReadFile(filepath.Join(outputPath, fileName))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(string(actualBytes)).Should(Equal(string(expectedBytes)))
+	}
+
+	It("creates the en_XA pseudo locale with accented, bracketed and expanded strings", func() {
+		session := Runi18n("-c", "pseudo-localize", "-v", "-f", filepath.Join(inputFilesPath, "en.all.json"), "-o", outputPath)
+		Ω(session.ExitCode()).Should(Equal(0))
+
+		compareFiles("en_XA.all.json")
+	})
+
+	It("creates the ar_XB right-to-left pseudo locale", func() {
+		session := Runi18n("-c", "pseudo-localize", "-v", "-f", filepath.Join(inputFilesPath, "en.all.json"), "-o", outputPath, "--pseudo-rtl", "--pseudo-expansion", "50")
+		Ω(session.ExitCode()).Should(Equal(0))
+
+		compareFiles("ar_XB.all.json")
+	})
+
+	It("creates the left-to-right and right-to-left pseudo locales at once", func() {
+		session := Runi18n("-c", "pseudo-localize", "-v", "-f", filepath.Join(inputFilesPath, "en.all.json"), "-o", outputPath, "--languages", "en_XA,ar_XB")
+		Ω(session.ExitCode()).Should(Equal(0))
+
+		compareFiles("en_XA.all.json")
+
+		content, err := ioutil.ReadFile(filepath.Join(outputPath, "ar_XB.all.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(content)).Should(ContainSubstring("\u202e["))
+	})
+
+	It("names the files like create-translations for the given languages", func() {
+		session := Runi18n("-c", "pseudo-localize", "-v", "-f", filepath.Join(inputFilesPath, "en.all.json"), "-o", outputPath, "--languages", "en_XA,fr_XA")
+		Ω(session.ExitCode()).Should(Equal(0))
+
+		compareFiles("en_XA.all.json")
+		_, err := os.Stat(filepath.Join(outputPath, "fr_XA.all.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("fails with a negative expansion", func() {
+		session := Runi18n("-c", "pseudo-localize", "-v", "-f", filepath.Join(inputFilesPath, "en.all.json"), "-o", outputPath, "--pseudo-expansion", "-10")
+		Ω(session.ExitCode()).Should(Equal(1))
+	})
+})
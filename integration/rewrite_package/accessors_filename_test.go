@@ -0,0 +1,87 @@
+package rewrite_package_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("rewrite-package [...] --accessors-filename some-file", func() {
+	var (
+		outputDir         string
+		rootPath          string
+		inputFilesPath    string
+		expectedFilesPath string
+	)
+
+	BeforeEach(func() {
+		dir, err := os.Getwd()
+		Ω(err).ShouldNot(HaveOccurred())
+		rootPath = filepath.Join(dir, "..", "..")
+
+		outputDir, err = ioutil.TempDir(rootPath, "i18n4go_integration")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		fixturesPath := filepath.Join("..", "..", "test_fixtures", "rewrite_package", "accessors_filename_option")
+		inputFilesPath = filepath.Join(fixturesPath, "input_files")
+		expectedFilesPath = filepath.Join(fixturesPath, "expected_output")
+
+		session := Runi18n("-c",
+			"rewrite-package",
+			"-f", filepath.Join(inputFilesPath, "app.go"),
+			"--i18n-strings-filename", filepath.Join(inputFilesPath, "en.all.json"),
+			"--accessors-filename", filepath.Join(inputFilesPath, "en.all.json"),
+			"-o", outputDir,
+			"--root-path", rootPath,
+			"-v",
+		)
+
+		Ω(session.ExitCode()).Should(Equal(0))
+	})
+
+	AfterEach(func() {
+		err := os.RemoveAll(outputDir)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("rewrites the T() calls of the strings with accessors to call their accessor", func() {
+		expectedOutput, err := ioutil.ReadFile(filepath.Join(expectedFilesPath, "app.go"))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		actualOutput, err := ioutil.ReadFile(filepath.Join(outputDir, "app.go"))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(string(actualOutput)).Should(Equal(string(expectedOutput)))
+	})
+
+	Context("with the -q qualifier", func() {
+		BeforeEach(func() {
+			session := Runi18n("-c",
+				"rewrite-package",
+				"-f", filepath.Join(inputFilesPath, "qualified.go"),
+				"--i18n-strings-filename", filepath.Join(inputFilesPath, "en.all.json"),
+				"--accessors-filename", filepath.Join(inputFilesPath, "en.all.json"),
+				"-o", outputDir,
+				"--root-path", rootPath,
+				"-q", "i18n",
+				"-v",
+			)
+
+			Ω(session.ExitCode()).Should(Equal(0))
+		})
+
+		It("rewrites the qualified calls and the calls of the other T funcs", func() {
+			expectedOutput, err := ioutil.ReadFile(filepath.Join(expectedFilesPath, "qualified.go"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			actualOutput, err := ioutil.ReadFile(filepath.Join(outputDir, "qualified.go"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(string(actualOutput)).Should(Equal(string(expectedOutput)))
+		})
+	})
+})
@@ -0,0 +1,63 @@
+package rewrite_package_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("rewrite-package with //i18n4go:ignore directives", func() {
+	var (
+		outputDir         string
+		expectedFilesPath string
+	)
+
+	BeforeEach(func() {
+		dir, err := os.Getwd()
+		Ω(err).ShouldNot(HaveOccurred())
+		rootPath := filepath.Join(dir, "..", "..")
+
+		outputDir, err = ioutil.TempDir(rootPath, "i18n4go_integration")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		fixturesPath := filepath.Join("..", "..", "test_fixtures", "rewrite_package", "directives_option")
+		expectedFilesPath = filepath.Join(fixturesPath, "expected_output")
+
+		session := Runi18n("-c",
+			"rewrite-package",
+			"-d", filepath.Join(fixturesPath, "input_files"),
+			"-o", outputDir,
+			"--root-path", rootPath,
+			"-v",
+		)
+
+		Ω(session.ExitCode()).Should(Equal(0))
+	})
+
+	AfterEach(func() {
+		err := os.RemoveAll(outputDir)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	compareOutput := func(fileName string) {
+		expectedOutput, err := ioutil.ReadFile(filepath.Join(expectedFilesPath, fileName))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		actualOutput, err := ioutil.ReadFile(filepath.Join(outputDir, fileName))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(string(actualOutput)).Should(Equal(string(expectedOutput)))
+	}
+
+	It("does not wrap the strings of the ignored lines and blocks with T()", func() {
+		compareOutput("app.go")
+	})
+
+	It("does not wrap the strings of an ignored file with T()", func() {
+		compareOutput("internal.go")
+	})
+})
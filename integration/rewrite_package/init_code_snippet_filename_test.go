@@ -135,4 +135,46 @@ var _ = Describe("rewrite-package [...] --init-code-snippet-filename some-file",
 			Ω(actualOutput).Should(Equal(expectedOutput))
 		})
 	})
+
+	Context("invokes rewrite-package command with --init-embed-dirname", func() {
+		BeforeEach(func() {
+			dir, err := os.Getwd()
+			Ω(err).ShouldNot(HaveOccurred())
+			rootPath = filepath.Join(dir, "..", "..")
+
+			outputDir, err = ioutil.TempDir(rootPath, "i18n4go_integration")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			fixturesPath = filepath.Join("..", "..", "test_fixtures", "rewrite_package")
+			inputFilesPath = filepath.Join(fixturesPath, "init_code_snippet_filename", "input_files")
+			expectedFilesPath = filepath.Join(fixturesPath, "init_code_snippet_filename", "expected_output")
+
+			session := Runi18n("-c",
+				"rewrite-package",
+				"-f", filepath.Join(inputFilesPath, "issue14.go"),
+				"-o", outputDir,
+				"--init-embed-dirname", filepath.Join("i18n", "resources"),
+				"--root-path", rootPath,
+				"-v",
+			)
+
+			Ω(session.ExitCode()).Should(Equal(0))
+		})
+
+		It("generates a i18n_init.go embedding the translation files", func() {
+			expectedOutputFile := filepath.Join(expectedFilesPath, "i18n_init_embed.go.txt")
+			bytes, err := ioutil.ReadFile(expectedOutputFile)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			expectedOutput := string(bytes)
+
+			generatedOutputFile := filepath.Join(outputDir, "i18n_init.go")
+			bytes, err = ioutil.ReadFile(generatedOutputFile)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			actualOutput := string(bytes)
+
+			Ω(actualOutput).Should(Equal(expectedOutput))
+		})
+	})
 })
@@ -0,0 +1,91 @@
+package rewrite_package_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("rewrite-package -d dirname --templates", func() {
+	var (
+		outputDir         string
+		rootPath          string
+		inputFilesPath    string
+		expectedFilesPath string
+	)
+
+	BeforeEach(func() {
+		dir, err := os.Getwd()
+		Ω(err).ShouldNot(HaveOccurred())
+		rootPath = filepath.Join(dir, "..", "..")
+
+		outputDir, err = ioutil.TempDir(rootPath, "i18n4go_integration")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		fixturesPath := filepath.Join("..", "..", "test_fixtures", "rewrite_package", "templates_option")
+		inputFilesPath = filepath.Join(fixturesPath, "input_files")
+		expectedFilesPath = filepath.Join(fixturesPath, "expected_output")
+	})
+
+	AfterEach(func() {
+		err := os.RemoveAll(outputDir)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	compareOutput := func(fileName string) {
+		expectedOutput, err := ioutil.ReadFile(filepath.Join(expectedFilesPath, fileName))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		actualOutput, err := ioutil.ReadFile(filepath.Join(outputDir, fileName))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(string(actualOutput)).Should(Equal(string(expectedOutput)))
+	}
+
+	Context("with --templates", func() {
+		BeforeEach(func() {
+			session := Runi18n("-c",
+				"rewrite-package",
+				"-d", inputFilesPath,
+				"--i18n-strings-dirname", inputFilesPath,
+				"--templates",
+				"-o", outputDir,
+				"--root-path", rootPath,
+				"-v",
+			)
+
+			Ω(session.ExitCode()).Should(Equal(0))
+		})
+
+		It("wraps the texts of the template files in {{T}} actions", func() {
+			compareOutput("page.tmpl")
+		})
+
+		It("wraps the texts of the template literals instead of the whole template", func() {
+			compareOutput("mail.go")
+		})
+	})
+
+	Context("without --templates", func() {
+		BeforeEach(func() {
+			session := Runi18n("-c",
+				"rewrite-package",
+				"-d", inputFilesPath,
+				"--i18n-strings-dirname", inputFilesPath,
+				"-o", outputDir,
+				"--root-path", rootPath,
+				"-v",
+			)
+
+			Ω(session.ExitCode()).Should(Equal(0))
+		})
+
+		It("does not rewrite the template files", func() {
+			Ω(filepath.Join(outputDir, "page.tmpl")).ShouldNot(BeAnExistingFile())
+		})
+	})
+})
@@ -0,0 +1,60 @@
+package show_missing_strings_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/EverlongProject/i18n4go/common"
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("show-missing-strings -format json", func() {
+	var (
+		inputFilesPath   string
+		languageFilePath string
+		session          *Session
+	)
+
+	BeforeEach(func() {
+		inputFilesPath = filepath.Join("..", "..", "test_fixtures", "show_missing_strings", "missing_usage_option", "input_files")
+
+		languageFilePath = filepath.Join(inputFilesPath, "app.go.en.json")
+		codeDirPath := filepath.Join(inputFilesPath, "code")
+		missingUsageFilePath := filepath.Join(inputFilesPath, "missing_usage.json")
+		session = Runi18n("-c", "show-missing-strings", "-d", codeDirPath, "--i18n-strings-filename", languageFilePath, "--missing-usage-filename", missingUsageFilePath, "-format", "json")
+
+		Eventually(session.ExitCode()).Should(Equal(1))
+	})
+
+	It("writes the missing strings with their position in the code or their caller", func() {
+		var findings []common.Finding
+		Ω(json.Unmarshal(session.Out.Contents(), &findings)).Should(Succeed())
+
+		Ω(findings).Should(Equal([]common.Finding{
+			{
+				RuleID:   common.RULE_MISSING_STRING,
+				Severity: common.SEVERITY_ERROR,
+				Message:  "\"I am a missing string\" is not in " + languageFilePath + ", it was used 3 times at runtime",
+				Locale:   "en",
+				Key:      "I am a missing string",
+				Filename: filepath.Join(inputFilesPath, "code", "app.go"),
+				Line:     4,
+				Column:   12,
+				Fix:      "Add \"I am a missing string\" to " + languageFilePath,
+			},
+			{
+				RuleID:   common.RULE_MISSING_AT_RUNTIME,
+				Severity: common.SEVERITY_ERROR,
+				Message:  "\"I am a dynamic string\" was missing in fr_FR at runtime, it was used 2 times",
+				Locale:   "fr_FR",
+				Key:      "I am a dynamic string",
+				Filename: "dynamic.go",
+				Line:     12,
+				Fix:      "Add \"I am a dynamic string\" to " + languageFilePath,
+			},
+		}))
+	})
+})
@@ -0,0 +1,41 @@
+package show_missing_strings_test
+
+import (
+	"path/filepath"
+
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("show-missing-strings --missing-usage-filename fileName", func() {
+	var (
+		inputFilesPath string
+		session        *Session
+	)
+
+	BeforeEach(func() {
+		inputFilesPath = filepath.Join("..", "..", "test_fixtures", "show_missing_strings", "missing_usage_option", "input_files")
+
+		languageFilePath := filepath.Join(inputFilesPath, "app.go.en.json")
+		codeDirPath := filepath.Join(inputFilesPath, "code")
+		missingUsageFilePath := filepath.Join(inputFilesPath, "missing_usage.json")
+		session = Runi18n("-c", "show-missing-strings", "-d", codeDirPath, "--i18n-strings-filename", languageFilePath, "--missing-usage-filename", missingUsageFilePath)
+
+		Eventually(session.ExitCode()).Should(Equal(1))
+	})
+
+	It("shows how many times the missing strings were used at runtime", func() {
+		Ω(session).Should(Say(`Missing: .*I am a missing string \(used 3 times at runtime\)`))
+	})
+
+	It("shows the strings only missing at runtime with their callers", func() {
+		Ω(session).Should(Say(`Missing at runtime: dynamic.go:12: I am a dynamic string \(fr_FR, used 2 times\)`))
+	})
+
+	It("does not show the strings of the resource file missing a translation", func() {
+		Ω(session).ShouldNot(Say("verified"))
+	})
+})
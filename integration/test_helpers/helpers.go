@@ -18,10 +18,13 @@ import (
 )
 
 func CompareExpectedToGeneratedPo(expectedFilePath string, generatedFilePath string) {
-	expectedTranslation := ReadPo(expectedFilePath)
-	generatedTranslation := ReadPo(generatedFilePath)
+	expectedPo, err := ioutil.ReadFile(expectedFilePath)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	generatedPo, err := ioutil.ReadFile(generatedFilePath)
+	Ω(err).ShouldNot(HaveOccurred())
 
-	Ω(reflect.DeepEqual(expectedTranslation, generatedTranslation)).Should(BeTrue())
+	Ω(string(generatedPo)).Should(Equal(string(expectedPo)), fmt.Sprintf("expected po %s to exactly match %s", expectedFilePath, generatedFilePath))
 }
 
 func CompareExpectedToGeneratedTraslationJson(expectedFilePath string, generatedFilePath string) {
@@ -0,0 +1,112 @@
+package translation_memory_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("translation memory", func() {
+	var (
+		inputFilesPath    string
+		expectedFilesPath string
+		workPath          string
+		tmFilename        string
+	)
+
+	BeforeEach(func() {
+		fixturesPath := filepath.Join("..", "..", "test_fixtures", "translation_memory")
+		inputFilesPath = filepath.Join(fixturesPath, "input_files")
+		expectedFilesPath = filepath.Join(fixturesPath, "expected_output")
+
+		var err error
+		workPath, err = ioutil.TempDir("", "i18n4go4go")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		for _, fileName := range []string{"en.all.json", "fr.all.json", "vendor.tmx"} {
+			content, err := ioutil.ReadFile(filepath.Join(inputFilesPath, fileName))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ioutil.WriteFile(filepath.Join(workPath, fileName), content, 0644)).Should(Succeed())
+		}
+
+		tmFilename = filepath.Join(workPath, "tm.jsonl")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(workPath)
+	})
+
+	compareFiles := func(expectedFilename, actualFilename string) {
+		expectedBytes, err := ioutil.ReadFile(expectedFilename)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		actualBytes, err := ioutil.ReadFile(actualFilename)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(string(actualBytes)).Should(Equal(string(expectedBytes)))
+	}
+
+	fillMemory := func() {
+		session := Runi18n("-c", "update-tm", "-v", "-f", filepath.Join(workPath, "en.all.json"), "--languages", "fr", "--tm", tmFilename)
+		Ω(session.ExitCode()).Should(Equal(0))
+
+		session = Runi18n("-c", "import-tmx", "-v", "--tm", tmFilename, "--tmx", filepath.Join(workPath, "vendor.tmx"))
+		Ω(session.ExitCode()).Should(Equal(0))
+	}
+
+	Context("update-tm and import-tmx", func() {
+		It("fill the memory with the reviewed translations and the TMX translations", func() {
+			fillMemory()
+
+			compareFiles(filepath.Join(expectedFilesPath, "tm.jsonl"), tmFilename)
+		})
+	})
+
+	Context("create-translations --tm", func() {
+		It("uses the exact and fuzzy matches of the memory and marks the others as modified", func() {
+			fillMemory()
+
+			outputPath := filepath.Join(workPath, "out")
+			session := Runi18n("-c", "create-translations", "-v", "-f", filepath.Join(workPath, "en.all.json"), "--languages", "fr,de", "--tm", tmFilename, "-o", outputPath)
+			Ω(session.ExitCode()).Should(Equal(0))
+
+			compareFiles(filepath.Join(expectedFilesPath, "fr.all.json"), filepath.Join(outputPath, "fr.all.json"))
+			compareFiles(filepath.Join(expectedFilesPath, "de.all.json"), filepath.Join(outputPath, "de.all.json"))
+		})
+
+		It("fills the memory from the existing translation files", func() {
+			session := Runi18n("-c", "create-translations", "-v", "-f", filepath.Join(workPath, "en.all.json"), "--languages", "fr", "--tm", tmFilename, "-o", workPath, "--tm-fuzzy-threshold", "1")
+			Ω(session.ExitCode()).Should(Equal(0))
+
+			content, err := ioutil.ReadFile(tmFilename)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(content)).Should(ContainSubstring(`"source":"Delete app {{.Name}}?","language":"fr","translation":"Supprimer l'app {{.Name}} ?"`))
+
+			translations, err := ioutil.ReadFile(filepath.Join(workPath, "fr.all.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(translations)).Should(ContainSubstring(`"translation": "Supprimer l'app {{.Name}} ?"`))
+			Ω(string(translations)).ShouldNot(ContainSubstring(`"translation": "Obtention des apps de l'org {{.Org}}...",
+      "modified": true`))
+		})
+	})
+
+	Context("export-tmx", func() {
+		It("exports a TMX file that imports back into the same memory", func() {
+			fillMemory()
+
+			tmxFilename := filepath.Join(workPath, "export.tmx")
+			session := Runi18n("-c", "export-tmx", "-v", "--tm", tmFilename, "--tmx", tmxFilename)
+			Ω(session.ExitCode()).Should(Equal(0))
+
+			importedTmFilename := filepath.Join(workPath, "imported.jsonl")
+			session = Runi18n("-c", "import-tmx", "-v", "--tm", importedTmFilename, "--tmx", tmxFilename)
+			Ω(session.ExitCode()).Should(Equal(0))
+
+			compareFiles(tmFilename, importedTmFilename)
+		})
+	})
+})
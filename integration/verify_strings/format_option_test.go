@@ -0,0 +1,159 @@
+package verify_strings_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+
+	"github.com/EverlongProject/i18n4go/common"
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("verify-strings -format", func() {
+	var (
+		inputFilesPath string
+		outputPath     string
+		args           []string
+	)
+
+	BeforeEach(func() {
+		inputFilesPath = filepath.Join("..", "..", "test_fixtures", "verify_strings", "format", "input_files")
+
+		var err error
+		outputPath, err = os.MkdirTemp("", "i18n4go4test")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		args = []string{"-c", "verify-strings", "-f", filepath.Join(inputFilesPath, "en.all.json"), "--languages", "fr", "-o", outputPath, "-d", filepath.Join(inputFilesPath, "meta")}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(outputPath)
+	})
+
+	Context("json", func() {
+		It("writes the findings, located in the Go files with the --meta files", func() {
+			session := Runi18n(append(args, "-format", "json")...)
+			Ω(session.ExitCode()).Should(Equal(1))
+
+			var findings []common.Finding
+			Ω(json.Unmarshal(session.Out.Contents(), &findings)).Should(Succeed())
+
+			frFilename := filepath.Join(inputFilesPath, "fr.all.json")
+			Ω(findings).Should(Equal([]common.Finding{
+				{
+					RuleID:   common.RULE_EXTRA_TRANSLATION,
+					Severity: common.SEVERITY_ERROR,
+					Message:  "The fr translation of \"Thank you\" has no string in the source translation file",
+					Locale:   "fr",
+					Key:      "Thank you",
+					Filename: frFilename,
+					Fix:      "Remove \"Thank you\" from " + frFilename,
+				},
+				{
+					RuleID:   common.RULE_INVALID_TEMPLATE,
+					Severity: common.SEVERITY_ERROR,
+					Message:  "The fr translation of \"Goodbye {{.Name}}\" does not have the args of the string",
+					Locale:   "fr",
+					Key:      "Goodbye {{.Name}}",
+					Filename: frFilename,
+					Fix:      "Use the args Name in the translation",
+				},
+				{
+					RuleID:   common.RULE_MISSING_TRANSLATION,
+					Severity: common.SEVERITY_ERROR,
+					Message:  "\"Welcome\" is not translated in fr",
+					Locale:   "fr",
+					Key:      "Welcome",
+					Filename: "app/app.go",
+					Line:     7,
+					Column:   16,
+					Fix:      "Add a translation of \"Welcome\" to " + frFilename,
+				},
+			}))
+		})
+	})
+
+	Context("sarif", func() {
+		It("writes a SARIF log with a result per finding", func() {
+			session := Runi18n(append(args, "-format", "sarif")...)
+			Ω(session.ExitCode()).Should(Equal(1))
+
+			var sarifLog struct {
+				Version string `json:"version"`
+				Runs    []struct {
+					Tool struct {
+						Driver struct {
+							Name  string `json:"name"`
+							Rules []struct {
+								ID string `json:"id"`
+							} `json:"rules"`
+						} `json:"driver"`
+					} `json:"tool"`
+					Results []struct {
+						RuleID    string `json:"ruleId"`
+						Level     string `json:"level"`
+						Locations []struct {
+							PhysicalLocation struct {
+								ArtifactLocation struct {
+									URI string `json:"uri"`
+								} `json:"artifactLocation"`
+								Region struct {
+									StartLine int `json:"startLine"`
+								} `json:"region"`
+							} `json:"physicalLocation"`
+						} `json:"locations"`
+					} `json:"results"`
+				} `json:"runs"`
+			}
+			Ω(json.Unmarshal(session.Out.Contents(), &sarifLog)).Should(Succeed())
+
+			Ω(sarifLog.Version).Should(Equal("2.1.0"))
+			Ω(sarifLog.Runs).Should(HaveLen(1))
+			Ω(sarifLog.Runs[0].Tool.Driver.Name).Should(Equal("i18n4go"))
+			Ω(sarifLog.Runs[0].Tool.Driver.Rules).Should(HaveLen(3))
+			Ω(sarifLog.Runs[0].Results).Should(HaveLen(3))
+			Ω(sarifLog.Runs[0].Results[2].RuleID).Should(Equal(common.RULE_MISSING_TRANSLATION))
+			Ω(sarifLog.Runs[0].Results[2].Level).Should(Equal("error"))
+			Ω(sarifLog.Runs[0].Results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI).Should(Equal("app/app.go"))
+			Ω(sarifLog.Runs[0].Results[2].Locations[0].PhysicalLocation.Region.StartLine).Should(Equal(7))
+		})
+	})
+
+	Context("junit", func() {
+		It("writes a failed test case per finding", func() {
+			session := Runi18n(append(args, "-format", "junit")...)
+			Ω(session.ExitCode()).Should(Equal(1))
+
+			var testSuites struct {
+				TestSuites []struct {
+					Name      string `xml:"name,attr"`
+					Tests     int    `xml:"tests,attr"`
+					Failures  int    `xml:"failures,attr"`
+					TestCases []struct {
+						ClassName string `xml:"classname,attr"`
+						Name      string `xml:"name,attr"`
+					} `xml:"testcase"`
+				} `xml:"testsuite"`
+			}
+			Ω(xml.Unmarshal(session.Out.Contents(), &testSuites)).Should(Succeed())
+
+			Ω(testSuites.TestSuites).Should(HaveLen(1))
+			Ω(testSuites.TestSuites[0].Name).Should(Equal("i18n4go verify-strings"))
+			Ω(testSuites.TestSuites[0].Tests).Should(Equal(3))
+			Ω(testSuites.TestSuites[0].Failures).Should(Equal(3))
+			Ω(testSuites.TestSuites[0].TestCases[2].ClassName).Should(Equal("verify-strings.missing-translation"))
+			Ω(testSuites.TestSuites[0].TestCases[2].Name).Should(Equal("fr: Welcome"))
+		})
+	})
+
+	Context("an unknown format", func() {
+		It("fails", func() {
+			session := Runi18n(append(args, "-v", "-format", "xml")...)
+			Ω(session.ExitCode()).Should(Equal(1))
+			Ω(session.Out.Contents()).Should(ContainSubstring("unknown format: xml"))
+		})
+	})
+})
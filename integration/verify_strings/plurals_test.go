@@ -0,0 +1,64 @@
+package verify_strings_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/EverlongProject/i18n4go/common"
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("verify-strings with plural translations", func() {
+	var (
+		inputFilesPath string
+		outputPath     string
+	)
+
+	BeforeEach(func() {
+		inputFilesPath = filepath.Join("..", "..", "test_fixtures", "verify_strings", "plurals", "input_files")
+
+		var err error
+		outputPath, err = os.MkdirTemp("", "i18n4go4test")
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(outputPath)
+	})
+
+	Context("target files with the plural categories of their language", func() {
+		It("passes verification", func() {
+			session := Runi18n("-c", "verify-strings", "-v", "-f", filepath.Join(inputFilesPath, "en.all.json"), "--languages", "\"ru\"", "-o", outputPath, "--source-language", "en")
+			Ω(session.ExitCode()).Should(Equal(0))
+			Ω(session.Out.Contents()).ShouldNot(ContainSubstring("plural categories not used in ru"))
+
+			_, err := os.Stat(filepath.Join(outputPath, "ru.all.json.invalid.diff.json"))
+			Ω(os.IsNotExist(err)).Should(BeTrue())
+		})
+	})
+
+	Context("target files missing plural categories of their language", func() {
+		It("fails verification", func() {
+			session := Runi18n("-c", "verify-strings", "-v", "-f", filepath.Join(inputFilesPath, "en.all.json"), "--languages", "\"pl\"", "-o", outputPath, "--source-language", "en")
+			Ω(session.ExitCode()).Should(Equal(1))
+			Ω(session).Should(gbytes.Say("missing plural categories for pl: other"))
+
+			invalidStringInfos, err := common.LoadI18nStringInfos(filepath.Join(outputPath, "pl.all.json.invalid.diff.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(invalidStringInfos).Should(HaveLen(1))
+			Ω(invalidStringInfos[0].ID).Should(Equal("Deleted {{.Count}} apps"))
+			Ω(invalidStringInfos[0].Plurals).Should(HaveKeyWithValue("one", "Usunięto {{.Count}} aplikację"))
+		})
+	})
+
+	Context("target files with a plain translation of a plural string", func() {
+		It("fails verification", func() {
+			session := Runi18n("-c", "verify-strings", "-v", "-f", filepath.Join(inputFilesPath, "en.all.json"), "--languages", "\"fr\"", "-o", outputPath, "--source-language", "en")
+			Ω(session.ExitCode()).Should(Equal(1))
+			Ω(session).Should(gbytes.Say("translation is not plural: Deleted"))
+		})
+	})
+})
@@ -0,0 +1,96 @@
+package xliff_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/EverlongProject/i18n4go/common"
+
+	. "github.com/EverlongProject/i18n4go/integration/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("export-xliff and import-xliff", func() {
+	var (
+		fixturesPath      string
+		inputFilesPath    string
+		expectedFilesPath string
+		outputPath        string
+	)
+
+	BeforeEach(func() {
+		fixturesPath = filepath.Join("..", "..", "test_fixtures", "xliff")
+		inputFilesPath = filepath.Join(fixturesPath, "input_files")
+		expectedFilesPath = filepath.Join(fixturesPath, "expected_output")
+
+		var err error
+		outputPath, err = ioutil.TempDir("", "i18n4go4go")
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(outputPath)
+	})
+
+	compareFiles := func(expectedFilePath, actualFilePath string) {
+		expectedBytes, err := ioutil.ReadFile(expectedFilePath)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		actualBytes, err := ioutil.ReadFile(actualFilePath)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(string(actualBytes)).Should(Equal(string(expectedBytes)))
+	}
+
+	for _, version := range []string{"1.2", "2.0"} {
+		version := version
+
+		Context("XLIFF "+version, func() {
+			var expectedXliffPath string
+
+			BeforeEach(func() {
+				expectedXliffPath = filepath.Join(expectedFilesPath, "fr.all.xlf")
+				if version == "2.0" {
+					expectedXliffPath = filepath.Join(expectedFilesPath, "xliff_2.0", "fr.all.xlf")
+				}
+
+				session := Runi18n("-c", "export-xliff", "-v", "-f", filepath.Join(inputFilesPath, "en.all.json"), "--languages", "fr",
+					"-d", filepath.Join(inputFilesPath, "meta"), "--xliff-version", version, "-o", outputPath)
+				Ω(session.ExitCode()).Should(Equal(0))
+			})
+
+			It("exports units with ids, states, notes and protected placeholders", func() {
+				compareFiles(expectedXliffPath, filepath.Join(outputPath, "fr.all.xlf"))
+			})
+
+			It("imports the exported file back", func() {
+				session := Runi18n("-c", "import-xliff", "-v", "-f", filepath.Join(inputFilesPath, "en.all.json"), "--xliff-files", filepath.Join(outputPath, "fr.all.xlf"))
+				Ω(session.ExitCode()).Should(Equal(0))
+
+				translations, err := common.LoadI18nStringInfos(filepath.Join(outputPath, "fr.all.json"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(translations).Should(Equal([]common.I18nStringInfo{
+					{ID: "Deleting app {{.Name}} in org {{.Org}}...", Translation: "Suppression de l'app {{.Name}} dans l'org {{.Org}}...", Modified: true},
+					{ID: "Hello <world> & \"friends\"", Translation: "Bonjour <monde> & \"amis\"", Modified: false},
+					{ID: "Not translated", Translation: "Not translated", Modified: true},
+				}))
+			})
+		})
+	}
+
+	Context("importing an invalid XLIFF file", func() {
+		It("fails for unknown ids and broken placeholders without writing the translation file", func() {
+			session := Runi18n("-c", "import-xliff", "-v", "-f", filepath.Join(inputFilesPath, "en.all.json"), "--xliff-files", filepath.Join(inputFilesPath, "invalid.xlf"), "-o", outputPath)
+			Ω(session.ExitCode()).Should(Equal(1))
+			Ω(session.Out).Should(gbytes.Say("Deleting app"))
+			Ω(session.Out).Should(gbytes.Say("placeholders do not match"))
+			Ω(session.Out).Should(gbytes.Say("Not in the source"))
+
+			_, err := os.Stat(filepath.Join(outputPath, "invalid.json"))
+			Ω(os.IsNotExist(err)).Should(BeTrue())
+		})
+	})
+})
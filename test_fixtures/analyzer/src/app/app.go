@@ -0,0 +1,32 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+)
+
+const GREETING = "Welcome back"
+
+func T(translationID string, args ...interface{}) string {
+	return translationID
+}
+
+type UI struct{}
+
+func (ui UI) Say(message string, args ...interface{}) {}
+
+func Run(ui UI, name string) error {
+	fmt.Printf("Starting the app\n") // want `string "Starting the app\\n" passed to Printf is not translated with T\(\)`
+	fmt.Printf("%s\n", name)
+	fmt.Println(T("Hello {{.Name}}", map[string]interface{}{"Name": name}))
+	fmt.Println(T("Goodbye"))
+	fmt.Println(T("See you later"))                 // want `T\(\) ID "See you later" is missing from .*en.all.json`
+	fmt.Println("Hello")                            // want `string "Hello" passed to Println is not translated with T\(\)`
+	fmt.Println(T("Goodbye"), "for good", 42, name) // want `string "for good" passed to Println is not translated with T\(\)`
+
+	ui.Say(GREETING)             // want `string "Welcome back" passed to Say is not translated with T\(\)`
+	ui.Say(("Loading..."), name) // want `string "Loading..." passed to Say is not translated with T\(\)`
+	ui.Say(T("Goodbye"), "Bye")  // want `string "Bye" passed to Say is not translated with T\(\)`
+
+	return errors.New("could not start") // want `string "could not start" passed to New is not translated with T\(\)`
+}
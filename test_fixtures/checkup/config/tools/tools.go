@@ -0,0 +1,11 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/EverlongProject/i18n4go/i18n"
+)
+
+func main() {
+	fmt.Println(i18n.Tr("Not in the translations"))
+}
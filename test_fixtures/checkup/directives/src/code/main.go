@@ -0,0 +1,10 @@
+package code
+
+import "fmt"
+
+func main() {
+	fmt.Println(T("Translated hello world!"))
+
+	//i18n4go:ignore
+	fmt.Println(T("Not yet translated"))
+}
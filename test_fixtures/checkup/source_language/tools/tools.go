@@ -0,0 +1,7 @@
+package tools
+
+import "fmt"
+
+func main() {
+	fmt.Println(T("Outside of the sources"))
+}
@@ -0,0 +1,8 @@
+package code
+
+import "fmt"
+
+func main() {
+	fmt.Println(T("Color"))
+	fmt.Println(T("Favorite flavor"))
+}
@@ -0,0 +1,18 @@
+package app
+
+import "fmt"
+
+func ShowStatus(running bool) {
+	if !running {
+		// i18n: the state of an app that is not running
+		// i18n:context=app state
+		fmt.Println("Stopped")
+	}
+
+	fmt.Println("Stopped") // i18n:context=button
+
+	/* i18n: the space key of the keyboard */
+	fmt.Println("Space")
+
+	fmt.Println("Done")
+}
@@ -0,0 +1,7 @@
+package app
+
+import "fmt"
+
+func Goodbye() {
+	fmt.Println("Goodbye world!")
+}
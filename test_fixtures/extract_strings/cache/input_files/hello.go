@@ -0,0 +1,7 @@
+package app
+
+import "fmt"
+
+func Hello() {
+	fmt.Println("Hello world!")
+}
@@ -0,0 +1,20 @@
+package app
+
+import "fmt"
+
+func main() {
+	fmt.Println("Hello world!")
+
+	//i18n4go:ignore
+	fmt.Println("Debug: main started")
+
+	fmt.Println("Goodbye world!") //i18n4go:ignore logged only
+
+	//i18n4go:ignore-start
+	fmt.Printf("%s=%s\n",
+		"LANG",
+		"en_US")
+	//i18n4go:ignore-end
+
+	fmt.Println("See you soon!")
+}
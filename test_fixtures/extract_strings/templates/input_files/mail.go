@@ -0,0 +1,11 @@
+package mail
+
+import "text/template"
+
+var mailTemplate = template.Must(template.New("mail").Parse(`Your order has shipped.
+{{T "Thank you for your order"}}
+`))
+
+func Subject() string {
+	return "Order shipped"
+}
@@ -0,0 +1,9 @@
+package code
+
+import "fmt"
+
+func main() {
+	fmt.Println(T("Translated hello world!"))
+	fmt.Println(T("I like {{.Count}} apples.", 2))
+	fmt.Println(T("{{.Count}} files", 3))
+}
@@ -0,0 +1,8 @@
+package code
+
+import "fmt"
+
+func main() {
+	fmt.Println(T("Colour"))
+	fmt.Println(T("Favourite flavour"))
+}
@@ -0,0 +1,28 @@
+// Code generated by i18n4go -c generate-accessors from en.all.json; DO NOT EDIT.
+
+package app
+
+// MsgDeletingApp translates "Deleting app {{.Name}}..."
+func MsgDeletingApp(name string) string {
+	return T("Deleting app {{.Name}}...", map[string]interface{}{"Name": name})
+}
+
+// MsgDeletedApps translates "Deleted {{.Count}} apps"
+func MsgDeletedApps(count int) string {
+	return T("Deleted {{.Count}} apps", count)
+}
+
+// MsgCopyingFromToOfType translates "Copying {{.Name}} from {{.Source}} to {{.Name}} of type {{.Type}}"
+func MsgCopyingFromToOfType(name string, source string, typeArg string) string {
+	return T("Copying {{.Name}} from {{.Source}} to {{.Name}} of type {{.Type}}", map[string]interface{}{"Name": name, "Source": source, "Type": typeArg})
+}
+
+// MsgWorld translates "world"
+func MsgWorld() string {
+	return T("world")
+}
+
+// MsgHelloWorld translates "Hello World!"
+func MsgHelloWorld() string {
+	return T("Hello World!")
+}
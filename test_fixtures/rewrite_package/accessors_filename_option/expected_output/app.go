@@ -0,0 +1,14 @@
+package app
+
+import (
+	"fmt"
+	"os"
+)
+
+func Run(apps []string) {
+	println(MsgDeletingApp(fmt.Sprint(os.Args[0])))
+	println(MsgDeletedApps(len(apps)))
+	println(MsgCopyingFromToOfType(fmt.Sprint(apps[0]), MsgWorld(), fmt.Sprint(1)))
+	println(T("Not an accessor"))
+	println(MsgHelloWorld())
+}
@@ -0,0 +1,16 @@
+//go:build ignore
+
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/example/app/i18n"
+)
+
+func RunQualified(apps []string) {
+	println(i18n.MsgDeletingApp(fmt.Sprint(os.Args[0])))
+	println(i18n.MsgCopyingFromToOfType(fmt.Sprint(apps[0]), i18n.MsgWorld(), fmt.Sprint(1)))
+	println(MsgDeletedApps(len(apps)))
+}
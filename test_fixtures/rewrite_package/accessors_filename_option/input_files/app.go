@@ -0,0 +1,11 @@
+package app
+
+import "os"
+
+func Run(apps []string) {
+	println(T("Deleting app {{.Name}}...", map[string]interface{}{"Name": os.Args[0]}))
+	println(T("Deleted {{.Count}} apps", len(apps)))
+	println(T("Copying {{.Name}} from {{.Source}} to {{.Name}} of type {{.Type}}", map[string]interface{}{"Name": apps[0], "Source": T("world"), "Type": 1}))
+	println(T("Not an accessor"))
+	println("Hello World!")
+}
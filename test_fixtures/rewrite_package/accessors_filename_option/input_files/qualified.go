@@ -0,0 +1,15 @@
+//go:build ignore
+
+package app
+
+import (
+	"os"
+
+	"github.com/example/app/i18n"
+)
+
+func RunQualified(apps []string) {
+	println(i18n.T("Deleting app {{.Name}}...", map[string]interface{}{"Name": os.Args[0]}))
+	println(i18n.T("Copying {{.Name}} from {{.Source}} to {{.Name}} of type {{.Type}}", map[string]interface{}{"Name": apps[0], "Source": i18n.T("world"), "Type": 1}))
+	println(t("Deleted {{.Count}} apps", len(apps)))
+}
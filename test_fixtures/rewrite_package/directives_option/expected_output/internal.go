@@ -0,0 +1,5 @@
+//i18n4go:ignore-file
+
+package app
+
+const internalState = "Not user facing"
@@ -0,0 +1,11 @@
+package mail
+
+import "text/template"
+
+var mailTemplate = template.Must(template.New("mail").Funcs(template.FuncMap{"T": T}).Parse(`<p>{{T "Your order has shipped."}}</p>
+{{T "Thank you for your order"}}
+`))
+
+func Subject() string {
+	return T("Order shipped")
+}
@@ -0,0 +1,206 @@
+// Package tm is a translation memory, the translations of source strings
+// per language kept in a JSON lines file and reused by create-translations
+package tm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/EverlongProject/i18n4go/common"
+)
+
+const DEFAULT_FUZZY_THRESHOLD = 0.75
+
+// Entry is the translation of a source string in a language
+type Entry struct {
+	SourceLanguage string `json:"source_language"`
+	Source         string `json:"source"`
+	Language       string `json:"language"`
+	Translation    string `json:"translation"`
+}
+
+// Match is an entry found for a source string, its score is 1 for an exact
+// match and the similarity of the source strings for a fuzzy match
+type Match struct {
+	Entry
+	Score float64
+}
+
+func (match Match) Exact() bool {
+	return match.Score == 1
+}
+
+// Memory holds the entries keyed by source language, source string and
+// language
+type Memory struct {
+	// FuzzyThreshold is the similarity score (0 to 1) from which a fuzzy
+	// match is returned, 1 only returns exact matches
+	FuzzyThreshold float64
+
+	entries map[string]Entry
+}
+
+func New() *Memory {
+	return &Memory{FuzzyThreshold: DEFAULT_FUZZY_THRESHOLD, entries: make(map[string]Entry)}
+}
+
+// Load reads a memory file, a file that does not exist is an empty memory
+func Load(fileName string) (*Memory, error) {
+	memory := New()
+
+	file, err := os.Open(fileName)
+	if os.IsNotExist(err) {
+		return memory, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var entry Entry
+		err = json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, fmt.Errorf("i18n4go: could not parse translation memory %s line %d: %s", fileName, line, err.Error())
+		}
+		memory.Add(entry)
+	}
+
+	return memory, scanner.Err()
+}
+
+// Save writes the entries sorted, one JSON object per line, so the file can
+// be diffed and merged
+func (memory *Memory) Save(fileName string) error {
+	var buffer bytes.Buffer
+	for _, entry := range memory.Entries() {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		buffer.Write(common.UnescapeHTML(line))
+		buffer.WriteString("\n")
+	}
+
+	return os.WriteFile(fileName, buffer.Bytes(), 0644)
+}
+
+func (memory *Memory) Len() int {
+	return len(memory.entries)
+}
+
+// Add adds or replaces the entry for its source string and language, it
+// returns whether the memory changed
+func (memory *Memory) Add(entry Entry) bool {
+	key := entryKey(entry.SourceLanguage, entry.Source, entry.Language)
+	if existingEntry, ok := memory.entries[key]; ok && existingEntry == entry {
+		return false
+	}
+
+	memory.entries[key] = entry
+	return true
+}
+
+// AddTranslations adds the translations of the strings of a source
+// translation file, the modified translations are not added since they
+// were not reviewed, it returns the number of entries added or changed
+func (memory *Memory) AddTranslations(sourceLanguage string, sourceI18nStringInfos []common.I18nStringInfo, language string, i18nStringInfos []common.I18nStringInfo) int {
+	translations := make(map[string]common.I18nStringInfo)
+	for _, i18nStringInfo := range i18nStringInfos {
+		translations[i18nStringInfo.ID] = i18nStringInfo
+	}
+
+	var count int
+	for _, sourceI18nStringInfo := range sourceI18nStringInfos {
+		translation, ok := translations[sourceI18nStringInfo.ID]
+		if !ok || translation.Modified || translation.Translation == "" || sourceI18nStringInfo.IsPlural() || translation.IsPlural() {
+			continue
+		}
+
+		if memory.Add(Entry{SourceLanguage: sourceLanguage, Source: sourceI18nStringInfo.Translation, Language: language, Translation: translation.Translation}) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Lookup returns the exact match of the source string in the language or
+// else the most similar fuzzy match with the same placeholders
+func (memory *Memory) Lookup(sourceLanguage, source, language string) (Match, bool) {
+	if entry, ok := memory.entries[entryKey(sourceLanguage, source, language)]; ok {
+		return Match{Entry: entry, Score: 1}, true
+	}
+
+	if memory.FuzzyThreshold >= 1 {
+		return Match{}, false
+	}
+
+	var bestMatch Match
+	placeholders := common.GetPlaceholders(source)
+	for _, entry := range memory.entries {
+		if !sameLanguage(entry.SourceLanguage, sourceLanguage) || !sameLanguage(entry.Language, language) {
+			continue
+		}
+
+		// ties are broken by source string so the lookup is deterministic
+		score := common.Similarity(source, entry.Source)
+		if score < memory.FuzzyThreshold || score < bestMatch.Score || (score == bestMatch.Score && entry.Source > bestMatch.Source) {
+			continue
+		}
+
+		if !reflect.DeepEqual(common.GetPlaceholders(entry.Source), placeholders) {
+			continue
+		}
+
+		bestMatch = Match{Entry: entry, Score: score}
+	}
+
+	return bestMatch, bestMatch.Score > 0
+}
+
+// Entries returns the entries sorted by source language, language and
+// source string
+func (memory *Memory) Entries() []Entry {
+	entries := make([]Entry, 0, len(memory.entries))
+	for _, entry := range memory.entries {
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].SourceLanguage != entries[j].SourceLanguage {
+			return entries[i].SourceLanguage < entries[j].SourceLanguage
+		}
+		if entries[i].Language != entries[j].Language {
+			return entries[i].Language < entries[j].Language
+		}
+		return entries[i].Source < entries[j].Source
+	})
+
+	return entries
+}
+
+func entryKey(sourceLanguage, source, language string) string {
+	return normalizeLanguage(sourceLanguage) + "\x00" + normalizeLanguage(language) + "\x00" + source
+}
+
+// normalizeLanguage makes fr_FR and fr-fr the same language
+func normalizeLanguage(language string) string {
+	return strings.ToLower(strings.Replace(language, "_", "-", -1))
+}
+
+func sameLanguage(language1, language2 string) bool {
+	return normalizeLanguage(language1) == normalizeLanguage(language2)
+}
@@ -0,0 +1,102 @@
+package tm_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/EverlongProject/i18n4go/common"
+	"github.com/EverlongProject/i18n4go/tm"
+)
+
+func TestLookup(t *testing.T) {
+	memory := tm.New()
+	memory.Add(tm.Entry{SourceLanguage: "en", Source: "Getting apps in org {{.Org}}...", Language: "fr_FR", Translation: "Obtention des apps de l'org {{.Org}}..."})
+	memory.Add(tm.Entry{SourceLanguage: "en", Source: "Delete app {{.Name}}?", Language: "fr_FR", Translation: "Supprimer l'app {{.Name}} ?"})
+
+	match, ok := memory.Lookup("en", "Delete app {{.Name}}?", "fr-fr")
+	if !ok || !match.Exact() || match.Translation != "Supprimer l'app {{.Name}} ?" {
+		t.Fatalf("got %#v %v", match, ok)
+	}
+
+	match, ok = memory.Lookup("en", "Getting all apps in org {{.Org}}...", "fr_FR")
+	if !ok || match.Exact() || match.Translation != "Obtention des apps de l'org {{.Org}}..." {
+		t.Fatalf("got %#v %v", match, ok)
+	}
+
+	for _, source := range []string{"Getting apps in space {{.Space}}...", "Completely different text", "Delete app {{.Name}}?"} {
+		language := "fr_FR"
+		if source == "Delete app {{.Name}}?" {
+			language = "de"
+		}
+
+		match, ok = memory.Lookup("en", source, language)
+		if ok {
+			t.Errorf("%q in %s: got %#v", source, language, match)
+		}
+	}
+
+	memory.FuzzyThreshold = 1
+	_, ok = memory.Lookup("en", "Getting all apps in org {{.Org}}...", "fr_FR")
+	if ok {
+		t.Error("got a fuzzy match with a threshold of 1")
+	}
+}
+
+func TestAddTranslations(t *testing.T) {
+	memory := tm.New()
+	count := memory.AddTranslations("en", []common.I18nStringInfo{
+		{ID: "Hello", Translation: "Hello"},
+		{ID: "Bye", Translation: "Bye"},
+		{ID: "Quota", Translation: "Quota"},
+	}, "fr", []common.I18nStringInfo{
+		{ID: "Hello", Translation: "Bonjour"},
+		{ID: "Bye", Translation: "Bye", Modified: true},
+	})
+
+	if count != 1 || memory.Len() != 1 {
+		t.Fatalf("added %d entries, memory has %d", count, memory.Len())
+	}
+
+	if count = memory.AddTranslations("en", []common.I18nStringInfo{{ID: "Hello", Translation: "Hello"}}, "fr", []common.I18nStringInfo{{ID: "Hello", Translation: "Bonjour"}}); count != 0 {
+		t.Fatalf("added %d entries again", count)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "memory.jsonl")
+
+	memory, err := tm.Load(fileName)
+	if err != nil || memory.Len() != 0 {
+		t.Fatalf("got %d entries, %v", memory.Len(), err)
+	}
+
+	memory.Add(tm.Entry{SourceLanguage: "en", Source: "Hello <{{.Name}}>", Language: "fr", Translation: "Bonjour <{{.Name}}>"})
+	memory.Add(tm.Entry{SourceLanguage: "en", Source: "Bye", Language: "de", Translation: "Tschüss"})
+	err = memory.Save(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"source_language":"en","source":"Bye","language":"de","translation":"Tschüss"}
+{"source_language":"en","source":"Hello <{{.Name}}>","language":"fr","translation":"Bonjour <{{.Name}}>"}
+`
+	if string(content) != expected {
+		t.Fatalf("got:\n%s", content)
+	}
+
+	loadedMemory, err := tm.Load(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(loadedMemory.Entries(), memory.Entries()) {
+		t.Fatalf("got %#v", loadedMemory.Entries())
+	}
+}
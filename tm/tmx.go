@@ -0,0 +1,191 @@
+package tm
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	TMX_VERSION = "1.4"
+
+	// the version of the TMX files created by i18n4go, required by TMX
+	TMX_CREATION_TOOL_VERSION = "1.0"
+)
+
+type tmxDocument struct {
+	XMLName xml.Name  `xml:"tmx"`
+	Version string    `xml:"version,attr"`
+	Header  tmxHeader `xml:"header"`
+	Units   []tmxUnit `xml:"body>tu"`
+}
+
+type tmxHeader struct {
+	CreationTool        string `xml:"creationtool,attr"`
+	CreationToolVersion string `xml:"creationtoolversion,attr"`
+	SegType             string `xml:"segtype,attr"`
+	OTmf                string `xml:"o-tmf,attr"`
+	AdminLang           string `xml:"adminlang,attr"`
+	SrcLang             string `xml:"srclang,attr"`
+	DataType            string `xml:"datatype,attr"`
+}
+
+type tmxUnit struct {
+	SrcLang  string       `xml:"srclang,attr,omitempty"`
+	Variants []tmxVariant `xml:"tuv"`
+}
+
+type tmxVariant struct {
+	Lang    string     `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	OldLang string     `xml:"lang,attr,omitempty"`
+	Segment tmxSegment `xml:"seg"`
+}
+
+type tmxSegment struct {
+	InnerXML string `xml:",innerxml"`
+}
+
+// WriteTMX writes the entries whose source language is the given one as a
+// TMX 1.4 document, the translations of a source string are in one unit
+func (memory *Memory) WriteTMX(w io.Writer, sourceLanguage string) error {
+	document := tmxDocument{
+		Version: TMX_VERSION,
+		Header: tmxHeader{
+			CreationTool:        "i18n4go",
+			CreationToolVersion: TMX_CREATION_TOOL_VERSION,
+			SegType:             "sentence",
+			OTmf:                "i18n4go",
+			AdminLang:           "en",
+			SrcLang:             sourceLanguage,
+			DataType:            "plaintext",
+		},
+	}
+
+	units := make(map[string]int)
+	for _, entry := range memory.Entries() {
+		if !sameLanguage(entry.SourceLanguage, sourceLanguage) {
+			continue
+		}
+
+		index, ok := units[entry.Source]
+		if !ok {
+			index = len(document.Units)
+			units[entry.Source] = index
+			document.Units = append(document.Units, tmxUnit{Variants: []tmxVariant{newTmxVariant(sourceLanguage, entry.Source)}})
+		}
+		document.Units[index].Variants = append(document.Units[index].Variants, newTmxVariant(entry.Language, entry.Translation))
+	}
+
+	content, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, xml.Header+string(content)+"\n")
+	return err
+}
+
+// SaveTMX writes the entries of the source language in a TMX file
+func (memory *Memory) SaveTMX(fileName string, sourceLanguage string) error {
+	var buffer bytes.Buffer
+	err := memory.WriteTMX(&buffer, sourceLanguage)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fileName, buffer.Bytes(), 0644)
+}
+
+// ReadTMX returns the entries of a TMX file, one per translation of the
+// source segment of each unit, the inline codes of the segments are kept as
+// text, e.g., <ph>{{.Name}}</ph> is {{.Name}}
+func ReadTMX(fileName string) ([]Entry, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var document tmxDocument
+	err = xml.Unmarshal(content, &document)
+	if err != nil {
+		return nil, fmt.Errorf("i18n4go: could not parse TMX file %s: %s", fileName, err.Error())
+	}
+
+	var entries []Entry
+	for i, unit := range document.Units {
+		sourceLanguage := unit.SrcLang
+		if sourceLanguage == "" || sourceLanguage == "*all*" {
+			sourceLanguage = document.Header.SrcLang
+		}
+
+		var source string
+		var found bool
+		for _, variant := range unit.Variants {
+			if sameLanguage(variant.lang(), sourceLanguage) {
+				source, err = variant.Segment.text()
+				found = true
+				break
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("i18n4go: TMX unit %d of %s has no %s segment", i+1, fileName, sourceLanguage)
+		}
+
+		for _, variant := range unit.Variants {
+			if sameLanguage(variant.lang(), sourceLanguage) {
+				continue
+			}
+
+			translation, err := variant.Segment.text()
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, Entry{SourceLanguage: sourceLanguage, Source: source, Language: variant.lang(), Translation: translation})
+		}
+	}
+
+	return entries, nil
+}
+
+func newTmxVariant(language, text string) tmxVariant {
+	var buffer bytes.Buffer
+	xml.EscapeText(&buffer, []byte(text))
+
+	return tmxVariant{Lang: language, Segment: tmxSegment{InnerXML: buffer.String()}}
+}
+
+// lang returns the language of the variant, TMX 1.1 used lang instead of
+// xml:lang
+func (variant tmxVariant) lang() string {
+	if variant.Lang != "" {
+		return variant.Lang
+	}
+
+	return variant.OldLang
+}
+
+// text returns the character data of the segment and of its inline codes
+func (segment tmxSegment) text() (string, error) {
+	var builder strings.Builder
+
+	decoder := xml.NewDecoder(strings.NewReader("<seg>" + segment.InnerXML + "</seg>"))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return builder.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if charData, ok := token.(xml.CharData); ok {
+			builder.Write(charData)
+		}
+	}
+}
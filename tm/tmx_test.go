@@ -0,0 +1,78 @@
+package tm_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/EverlongProject/i18n4go/tm"
+)
+
+func TestTMXRoundTrip(t *testing.T) {
+	memory := tm.New()
+	memory.Add(tm.Entry{SourceLanguage: "en", Source: "Hello <{{.Name}}> & co", Language: "fr", Translation: "Bonjour <{{.Name}}> & cie"})
+	memory.Add(tm.Entry{SourceLanguage: "en", Source: "Hello <{{.Name}}> & co", Language: "de", Translation: "Hallo <{{.Name}}> & Co"})
+	memory.Add(tm.Entry{SourceLanguage: "fr", Source: "Au revoir", Language: "en", Translation: "Bye"})
+
+	fileName := filepath.Join(t.TempDir(), "memory.tmx")
+	err := memory.SaveTMX(fileName, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Count(string(content), "<tu>") != 1 || !strings.Contains(string(content), `<tuv xml:lang="de">`) {
+		t.Fatalf("got:\n%s", content)
+	}
+
+	entries, err := tm.ReadTMX(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(entries, memory.Entries()[:2]) {
+		t.Fatalf("got %#v", entries)
+	}
+}
+
+func TestReadTMXInlineCodes(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "vendor.tmx")
+	err := os.WriteFile(fileName, []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<tmx version="1.4">
+  <header creationtool="cat" creationtoolversion="1" segtype="sentence" o-tmf="cat" adminlang="en-US" srclang="en-US" datatype="plaintext"/>
+  <body>
+    <tu>
+      <tuv xml:lang="ja"><seg><ph x="1">{{.Count}}</ph> 個のアプリ</seg></tuv>
+      <tuv xml:lang="en-US"><seg><ph x="1">{{.Count}}</ph> apps</seg></tuv>
+    </tu>
+  </body>
+</tmx>`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := tm.ReadTMX(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []tm.Entry{{SourceLanguage: "en-US", Source: "{{.Count}} apps", Language: "ja", Translation: "{{.Count}} 個のアプリ"}}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Fatalf("got %#v", entries)
+	}
+
+	var buffer bytes.Buffer
+	memory := tm.New()
+	memory.Add(entries[0])
+	err = memory.WriteTMX(&buffer, "en_US")
+	if err != nil || !strings.Contains(buffer.String(), "{{.Count}} 個のアプリ") {
+		t.Fatalf("got %v:\n%s", err, buffer.String())
+	}
+}
@@ -0,0 +1,173 @@
+package translators
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	DEFAULT_BATCH_SIZE      = 50
+	DEFAULT_CONCURRENCY     = 4
+	DEFAULT_MAX_RETRIES     = 3
+	DEFAULT_INITIAL_BACKOFF = time.Second
+	MAX_BACKOFF             = 30 * time.Second
+)
+
+// BatchOptions configures how TranslateAll sends the texts, a zero
+// BatchSize, Concurrency or InitialBackoff uses the default and a zero
+// RateLimit means no limit
+type BatchOptions struct {
+	BatchSize      int
+	Concurrency    int
+	MaxRetries     int
+	InitialBackoff time.Duration
+
+	// RateLimit is the maximum number of requests per second
+	RateLimit float64
+}
+
+// Result is the translation of a text, or why it could not be translated
+type Result struct {
+	Index       int
+	Text        string
+	Translation string
+	Err         error
+}
+
+// TranslateAll translates the texts in batches sent concurrently, each
+// batch is retried with an exponential backoff when the service is
+// unavailable, the placeholders are masked before sending and translations
+// whose placeholders did not survive are rejected, done is called with the
+// results of each batch, one batch at a time
+func TranslateAll(translator Translator, texts []string, sourceLanguage, targetLanguage string, options BatchOptions, done func(results []Result)) []Result {
+	options = options.withDefaults()
+
+	var limiter <-chan time.Time
+	if options.RateLimit > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / options.RateLimit))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	batches := make(chan []int)
+	go func() {
+		for start := 0; start < len(texts); start += options.BatchSize {
+			end := start + options.BatchSize
+			if end > len(texts) {
+				end = len(texts)
+			}
+
+			indexes := make([]int, 0, end-start)
+			for index := start; index < end; index++ {
+				indexes = append(indexes, index)
+			}
+			batches <- indexes
+		}
+		close(batches)
+	}()
+
+	results := make([]Result, len(texts))
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for worker := 0; worker < options.Concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for indexes := range batches {
+				batchResults := translateBatch(translator, texts, indexes, sourceLanguage, targetLanguage, options, limiter)
+
+				mutex.Lock()
+				for _, result := range batchResults {
+					results[result.Index] = result
+				}
+				if done != nil {
+					done(batchResults)
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func translateBatch(translator Translator, texts []string, indexes []int, sourceLanguage, targetLanguage string, options BatchOptions, limiter <-chan time.Time) []Result {
+	maskedTexts := make([]string, len(indexes))
+	placeholders := make([][]string, len(indexes))
+	for i, index := range indexes {
+		maskedTexts[i], placeholders[i] = MaskPlaceholders(texts[index])
+	}
+
+	translations, err := translateWithRetries(translator, maskedTexts, sourceLanguage, targetLanguage, options, limiter)
+
+	results := make([]Result, len(indexes))
+	for i, index := range indexes {
+		results[i] = Result{Index: index, Text: texts[index], Err: err}
+		if err != nil {
+			continue
+		}
+
+		results[i].Translation, results[i].Err = UnmaskPlaceholders(translations[i], placeholders[i])
+		if results[i].Err == nil {
+			results[i].Err = CheckPlaceholders(texts[index], results[i].Translation)
+		}
+	}
+
+	return results
+}
+
+func translateWithRetries(translator Translator, texts []string, sourceLanguage, targetLanguage string, options BatchOptions, limiter <-chan time.Time) ([]string, error) {
+	backoff := options.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		if limiter != nil {
+			<-limiter
+		}
+
+		translations, err := translator.Translate(texts, sourceLanguage, targetLanguage)
+		if err == nil {
+			return translations, nil
+		}
+
+		if attempt >= options.MaxRetries || !retryable(err) {
+			return nil, fmt.Errorf("%s (after %d attempts)", err.Error(), attempt+1)
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > MAX_BACKOFF {
+			backoff = MAX_BACKOFF
+		}
+	}
+}
+
+// retryable returns whether the request can succeed later, i.e., it is not
+// rejected by the service, e.g., because of a wrong API key
+func retryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return true
+}
+
+func (options BatchOptions) withDefaults() BatchOptions {
+	if options.BatchSize <= 0 {
+		options.BatchSize = DEFAULT_BATCH_SIZE
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = DEFAULT_CONCURRENCY
+	}
+	if options.MaxRetries < 0 {
+		options.MaxRetries = 0
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = DEFAULT_INITIAL_BACKOFF
+	}
+
+	return options
+}
@@ -0,0 +1,114 @@
+package translators_test
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EverlongProject/i18n4go/translators"
+	"github.com/EverlongProject/i18n4go/translators/translatorstest"
+)
+
+func TestMaskPlaceholders(t *testing.T) {
+	masked, placeholders := translators.MaskPlaceholders("{{.Name}} has %d apps in {{.Space}}")
+	if masked != "⟦0⟧ has ⟦1⟧ apps in ⟦2⟧" || !reflect.DeepEqual(placeholders, []string{"{{.Name}}", "%d", "{{.Space}}"}) {
+		t.Fatalf("got %q %q", masked, placeholders)
+	}
+
+	unmasked, err := translators.UnmaskPlaceholders("⟦2⟧ : ⟦0⟧ a ⟦1⟧ apps", placeholders)
+	if err != nil || unmasked != "{{.Space}} : {{.Name}} a %d apps" {
+		t.Fatalf("got %q %v", unmasked, err)
+	}
+
+	for _, translation := range []string{"⟦0⟧ a ⟦1⟧ apps", "⟦0⟧ a ⟦1⟧ apps ⟦2⟧ ⟦2⟧", "⟦0⟧ a ⟦1⟧ apps ⟦3⟧"} {
+		_, err = translators.UnmaskPlaceholders(translation, placeholders)
+		if err == nil {
+			t.Errorf("expected an error for %q", translation)
+		}
+	}
+}
+
+func TestTranslateAll(t *testing.T) {
+	server := translatorstest.NewServer()
+	defer server.Close()
+
+	translator, err := translators.New("http", translators.Config{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	texts := []string{"a", "b {{.Name}}", "c %s", "d", "e"}
+	var batches int
+	results := translators.TranslateAll(translator, texts, "en", "fr", translators.BatchOptions{BatchSize: 2, Concurrency: 2}, func(results []translators.Result) {
+		batches++
+	})
+
+	if batches != 3 || len(server.Requests()) != 3 {
+		t.Fatalf("got %d batches and %d requests", batches, len(server.Requests()))
+	}
+
+	for i, result := range results {
+		if result.Err != nil || result.Index != i || result.Translation != translatorstest.Translate(texts[i], "fr") {
+			t.Errorf("got %#v", result)
+		}
+	}
+
+	for _, request := range server.Requests() {
+		for _, text := range request.Texts {
+			if strings.Contains(text, "{{") || strings.Contains(text, "%s") {
+				t.Errorf("placeholders were not masked: %q", text)
+			}
+		}
+	}
+}
+
+func TestTranslateAllRetries(t *testing.T) {
+	server := translatorstest.NewServer()
+	defer server.Close()
+
+	translator, err := translators.New("http", translators.Config{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options := translators.BatchOptions{MaxRetries: 2, InitialBackoff: time.Millisecond, RateLimit: 1000}
+
+	server.FailNext(2, http.StatusServiceUnavailable)
+	results := translators.TranslateAll(translator, []string{"Hello"}, "en", "fr", options, nil)
+	if results[0].Err != nil || len(server.Requests()) != 3 {
+		t.Fatalf("got %v after %d requests", results[0].Err, len(server.Requests()))
+	}
+
+	server.FailNext(3, http.StatusTooManyRequests)
+	results = translators.TranslateAll(translator, []string{"Hello"}, "en", "fr", options, nil)
+	if results[0].Err == nil || results[0].Translation != "" || len(server.Requests()) != 6 {
+		t.Fatalf("got %#v after %d requests", results[0], len(server.Requests()))
+	}
+
+	server.FailNext(1, http.StatusForbidden)
+	results = translators.TranslateAll(translator, []string{"Hello"}, "en", "fr", options, nil)
+	if results[0].Err == nil || len(server.Requests()) != 7 {
+		t.Fatalf("got %v after %d requests, forbidden is not retried", results[0].Err, len(server.Requests()))
+	}
+}
+
+func TestTranslateAllRejectsBrokenPlaceholders(t *testing.T) {
+	server := translatorstest.NewServer()
+	defer server.Close()
+
+	server.TranslateFunc = func(text, targetLanguage string) string {
+		return strings.Replace(text, "⟦0⟧", "", -1)
+	}
+
+	translator, err := translators.New("http", translators.Config{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := translators.TranslateAll(translator, []string{"Hello {{.Name}}", "Bye"}, "en", "fr", translators.BatchOptions{}, nil)
+	if results[0].Err == nil || results[1].Err != nil || results[1].Translation != "Bye" {
+		t.Fatalf("got %#v", results)
+	}
+}
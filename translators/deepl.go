@@ -0,0 +1,68 @@
+package translators
+
+import (
+	"net/http"
+	"strings"
+)
+
+const DEEPL_URL = "https://api-free.deepl.com"
+
+// deepL uses the DeepL API v2, set the URL to https://api.deepl.com for the
+// DeepL API Pro
+type deepL struct {
+	config Config
+	client *http.Client
+}
+
+type deepLRequest struct {
+	Text       []string `json:"text"`
+	SourceLang string   `json:"source_lang,omitempty"`
+	TargetLang string   `json:"target_lang"`
+}
+
+type deepLResponse struct {
+	Translations []struct {
+		Text string `json:"text"`
+	} `json:"translations"`
+}
+
+func newDeepL(config Config, client *http.Client) (Translator, error) {
+	return &deepL{config: config, client: client}, nil
+}
+
+func (d *deepL) Name() string {
+	return "deepl"
+}
+
+func (d *deepL) Translate(texts []string, sourceLanguage, targetLanguage string) ([]string, error) {
+	request := deepLRequest{
+		Text:       texts,
+		SourceLang: strings.ToUpper(baseLanguage(sourceLanguage)),
+		TargetLang: deepLTargetLanguage(targetLanguage),
+	}
+
+	var response deepLResponse
+	err := postJSON(d.client, d.config.url(DEEPL_URL)+"/v2/translate", map[string]string{"Authorization": "DeepL-Auth-Key " + d.config.apiKey()}, request, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	translations := make([]string, len(response.Translations))
+	for i, translation := range response.Translations {
+		translations[i] = translation.Text
+	}
+
+	return checkTranslations(d.Name(), texts, translations)
+}
+
+// deepLTargetLanguage returns the DeepL target language, only English,
+// Portuguese and Chinese have variants, e.g., EN-GB, PT-BR and ZH-HANT
+func deepLTargetLanguage(language string) string {
+	language = strings.ToUpper(bcp47(language))
+	switch baseLanguage(language) {
+	case "EN", "PT", "ZH":
+		return language
+	}
+
+	return baseLanguage(language)
+}
@@ -0,0 +1,123 @@
+package translators
+
+import (
+	"errors"
+	"net/http"
+)
+
+const (
+	GOOGLE_URL      = "https://translation.googleapis.com"
+	GOOGLE_LOCATION = "global"
+)
+
+// google uses the Cloud Translation API v3, authenticated with an OAuth
+// access token, e.g., from `gcloud auth print-access-token`
+type google struct {
+	config Config
+	client *http.Client
+}
+
+type googleRequest struct {
+	Contents           []string `json:"contents"`
+	SourceLanguageCode string   `json:"sourceLanguageCode,omitempty"`
+	TargetLanguageCode string   `json:"targetLanguageCode"`
+	MimeType           string   `json:"mimeType"`
+}
+
+type googleResponse struct {
+	Translations []struct {
+		TranslatedText string `json:"translatedText"`
+	} `json:"translations"`
+}
+
+func newGoogle(config Config, client *http.Client) (Translator, error) {
+	if config.ProjectID == "" {
+		return nil, errors.New("i18n4go: the google translator requires a project_id")
+	}
+
+	if config.Location == "" {
+		config.Location = GOOGLE_LOCATION
+	}
+
+	return &google{config: config, client: client}, nil
+}
+
+func (g *google) Name() string {
+	return "google"
+}
+
+func (g *google) Translate(texts []string, sourceLanguage, targetLanguage string) ([]string, error) {
+	url := g.config.url(GOOGLE_URL) + "/v3/projects/" + g.config.ProjectID + "/locations/" + g.config.Location + ":translateText"
+	request := googleRequest{
+		Contents:           texts,
+		SourceLanguageCode: bcp47(sourceLanguage),
+		TargetLanguageCode: bcp47(targetLanguage),
+		MimeType:           "text/plain",
+	}
+
+	var response googleResponse
+	err := postJSON(g.client, url, map[string]string{"Authorization": "Bearer " + g.config.apiKey()}, request, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	translations := make([]string, len(response.Translations))
+	for i, translation := range response.Translations {
+		translations[i] = translation.TranslatedText
+	}
+
+	return checkTranslations(g.Name(), texts, translations)
+}
+
+// googleV2 uses the Translation API v2 with an API key, it is what the
+// --google-translate-api-key flag uses, the key is sent in a header rather
+// than in the URL
+type googleV2 struct {
+	config Config
+	client *http.Client
+}
+
+type googleV2Request struct {
+	Q      []string `json:"q"`
+	Source string   `json:"source,omitempty"`
+	Target string   `json:"target"`
+	Format string   `json:"format"`
+}
+
+type googleV2Response struct {
+	Data struct {
+		Translations []struct {
+			TranslatedText string `json:"translatedText"`
+		} `json:"translations"`
+	} `json:"data"`
+}
+
+func newGoogleV2(config Config, client *http.Client) (Translator, error) {
+	return &googleV2{config: config, client: client}, nil
+}
+
+func (g *googleV2) Name() string {
+	return "google-v2"
+}
+
+func (g *googleV2) Translate(texts []string, sourceLanguage, targetLanguage string) ([]string, error) {
+	request := googleV2Request{
+		Q:      texts,
+		Source: bcp47(sourceLanguage),
+		Target: bcp47(targetLanguage),
+		Format: "text",
+	}
+
+	var response googleV2Response
+	err := postJSON(g.client, g.config.url(GOOGLE_URL)+"/language/translate/v2", map[string]string{"X-Goog-Api-Key": g.config.apiKey()}, request, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	translations := make([]string, len(response.Data.Translations))
+	for i, translation := range response.Data.Translations {
+		translations[i] = translation.TranslatedText
+	}
+
+	return checkTranslations(g.Name(), texts, translations)
+}
@@ -0,0 +1,58 @@
+package translators
+
+import (
+	"errors"
+	"net/http"
+)
+
+// httpJSON posts the texts to any endpoint that accepts
+//
+//	{"source": "en", "target": "fr", "texts": ["Hello"]}
+//
+// and responds with
+//
+//	{"translations": ["Bonjour"]}
+type httpJSON struct {
+	config Config
+	client *http.Client
+}
+
+type HTTPRequest struct {
+	Source string   `json:"source"`
+	Target string   `json:"target"`
+	Texts  []string `json:"texts"`
+}
+
+type HTTPResponse struct {
+	Translations []string `json:"translations"`
+}
+
+func newHTTP(config Config, client *http.Client) (Translator, error) {
+	if config.URL == "" {
+		return nil, errors.New("i18n4go: the http translator requires a url")
+	}
+
+	return &httpJSON{config: config, client: client}, nil
+}
+
+func (h *httpJSON) Name() string {
+	return "http"
+}
+
+func (h *httpJSON) Translate(texts []string, sourceLanguage, targetLanguage string) ([]string, error) {
+	headers := make(map[string]string)
+	for name, value := range h.config.Headers {
+		headers[name] = value
+	}
+	if apiKey := h.config.apiKey(); apiKey != "" {
+		headers["Authorization"] = "Bearer " + apiKey
+	}
+
+	var response HTTPResponse
+	err := postJSON(h.client, h.config.URL, headers, HTTPRequest{Source: sourceLanguage, Target: targetLanguage, Texts: texts}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkTranslations(h.Name(), texts, response.Translations)
+}
@@ -0,0 +1,57 @@
+package translators
+
+import (
+	"net/http"
+)
+
+const LIBRETRANSLATE_URL = "https://libretranslate.com"
+
+// libreTranslate uses the LibreTranslate API, set the URL of a self-hosted
+// instance, the API key is only required by some instances
+type libreTranslate struct {
+	config Config
+	client *http.Client
+}
+
+type libreTranslateRequest struct {
+	Q      []string `json:"q"`
+	Source string   `json:"source"`
+	Target string   `json:"target"`
+	Format string   `json:"format"`
+	APIKey string   `json:"api_key,omitempty"`
+}
+
+type libreTranslateResponse struct {
+	TranslatedText []string `json:"translatedText"`
+}
+
+func newLibreTranslate(config Config, client *http.Client) (Translator, error) {
+	return &libreTranslate{config: config, client: client}, nil
+}
+
+func (l *libreTranslate) Name() string {
+	return "libretranslate"
+}
+
+func (l *libreTranslate) Translate(texts []string, sourceLanguage, targetLanguage string) ([]string, error) {
+	source := baseLanguage(sourceLanguage)
+	if source == "" {
+		source = "auto"
+	}
+
+	request := libreTranslateRequest{
+		Q:      texts,
+		Source: source,
+		Target: baseLanguage(targetLanguage),
+		Format: "text",
+		APIKey: l.config.apiKey(),
+	}
+
+	var response libreTranslateResponse
+	err := postJSON(l.client, l.config.url(LIBRETRANSLATE_URL)+"/translate", nil, request, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkTranslations(l.Name(), texts, response.TranslatedText)
+}
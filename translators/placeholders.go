@@ -0,0 +1,74 @@
+package translators
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/EverlongProject/i18n4go/common"
+)
+
+var (
+	placeholderRegexp = regexp.MustCompile(common.PLACEHOLDER_REGEXP)
+	maskRegexp        = regexp.MustCompile(`⟦\d+⟧`)
+)
+
+// MaskPlaceholders replaces the templated and interpolated placeholders of a
+// text with tokens that translation services keep, e.g., "Hello {{.Name}}"
+// becomes "Hello ⟦0⟧", the placeholders are returned to unmask the translation
+func MaskPlaceholders(text string) (string, []string) {
+	var placeholders []string
+	masked := placeholderRegexp.ReplaceAllStringFunc(text, func(placeholder string) string {
+		placeholders = append(placeholders, placeholder)
+		return "⟦" + strconv.Itoa(len(placeholders)-1) + "⟧"
+	})
+
+	return masked, placeholders
+}
+
+// UnmaskPlaceholders restores the placeholders of a translation, it fails
+// when a token is missing, duplicated or unknown
+func UnmaskPlaceholders(translation string, placeholders []string) (string, error) {
+	seen := make([]bool, len(placeholders))
+
+	var err error
+	unmasked := maskRegexp.ReplaceAllStringFunc(translation, func(token string) string {
+		index, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(token, "⟦"), "⟧"))
+		if index >= len(placeholders) || seen[index] {
+			err = fmt.Errorf("i18n4go: unexpected placeholder %s in translation: %s", token, translation)
+			return token
+		}
+
+		seen[index] = true
+		return placeholders[index]
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for index, ok := range seen {
+		if !ok {
+			return "", fmt.Errorf("i18n4go: placeholder %s is missing in translation: %s", placeholders[index], translation)
+		}
+	}
+
+	return unmasked, nil
+}
+
+// CheckPlaceholders fails when the translation does not have the same
+// placeholders as the text
+func CheckPlaceholders(text, translation string) error {
+	textPlaceholders := common.GetPlaceholders(text)
+	translationPlaceholders := common.GetPlaceholders(translation)
+	if len(textPlaceholders) == 0 && len(translationPlaceholders) == 0 {
+		return nil
+	}
+
+	if !reflect.DeepEqual(textPlaceholders, translationPlaceholders) {
+		return fmt.Errorf("i18n4go: the placeholders %v of the translation: %s do not match %v", translationPlaceholders, translation, textPlaceholders)
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package translators
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Translator machine translates texts from the source language to the
+// target language, the translations are in the same order as the texts
+type Translator interface {
+	Name() string
+	Translate(texts []string, sourceLanguage, targetLanguage string) ([]string, error)
+}
+
+// Config configures a translator, the fields used depend on the translator
+type Config struct {
+	// URL overrides the default endpoint, it is required by the http translator
+	URL string `json:"url,omitempty"`
+
+	// APIKey is the API key, or the OAuth access token for google, APIKeyEnv
+	// names an environment variable with it so it is not kept in the file
+	APIKey    string `json:"api_key,omitempty"`
+	APIKeyEnv string `json:"api_key_env,omitempty"`
+
+	// ProjectID and Location are the Google Cloud project and location
+	ProjectID string `json:"project_id,omitempty"`
+	Location  string `json:"location,omitempty"`
+
+	// Headers are added to the requests of the http translator
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// ConfigFile is the content of a translators configuration file, e.g.,
+//
+//	{
+//	   "translator": "deepl",
+//	   "translators": {
+//	      "deepl": {"api_key_env": "DEEPL_API_KEY"}
+//	   }
+//	}
+type ConfigFile struct {
+	Translator  string            `json:"translator"`
+	Translators map[string]Config `json:"translators"`
+}
+
+type factory func(config Config, client *http.Client) (Translator, error)
+
+var factories = map[string]factory{
+	"google":         newGoogle,
+	"google-v2":      newGoogleV2,
+	"deepl":          newDeepL,
+	"libretranslate": newLibreTranslate,
+	"http":           newHTTP,
+	"echo":           newEcho,
+	"pseudo":         newPseudo,
+}
+
+var defaultClient = &http.Client{Timeout: 60 * time.Second}
+
+// Names returns the names of the available translators
+func Names() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// New creates the translator with the given name
+func New(name string, config Config) (Translator, error) {
+	factory, ok := factories[name]
+	if !ok {
+		return nil, fmt.Errorf("i18n4go: unknown translator: %s, use one of: %s", name, strings.Join(Names(), ", "))
+	}
+
+	return factory(config, defaultClient)
+}
+
+// LoadConfigFile reads a JSON translators configuration file
+func LoadConfigFile(fileName string) (ConfigFile, error) {
+	var configFile ConfigFile
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return configFile, err
+	}
+
+	err = json.Unmarshal(content, &configFile)
+	if err != nil {
+		return configFile, fmt.Errorf("i18n4go: could not parse translators configuration file: %s\n%s", fileName, err.Error())
+	}
+
+	return configFile, nil
+}
+
+func (config Config) apiKey() string {
+	if config.APIKey == "" && config.APIKeyEnv != "" {
+		return os.Getenv(config.APIKeyEnv)
+	}
+
+	return config.APIKey
+}
+
+func (config Config) url(defaultURL string) string {
+	if config.URL != "" {
+		return strings.TrimSuffix(config.URL, "/")
+	}
+
+	return defaultURL
+}
+
+// postJSON posts the request as JSON and decodes the JSON response
+func postJSON(client *http.Client, url string, headers map[string]string, request, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	httpRequest, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		httpRequest.Header.Set(name, value)
+	}
+
+	httpResponse, err := client.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return &StatusError{StatusCode: httpResponse.StatusCode, Body: strings.TrimSpace(string(responseBody))}
+	}
+
+	return json.Unmarshal(responseBody, response)
+}
+
+// StatusError is returned when a translation service does not respond with 200 OK
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (err *StatusError) Error() string {
+	return fmt.Sprintf("i18n4go: translation service responded with status %d: %s", err.StatusCode, err.Body)
+}
+
+func checkTranslations(name string, texts, translations []string) ([]string, error) {
+	if len(translations) != len(texts) {
+		return nil, fmt.Errorf("i18n4go: %s returned %d translations for %d texts", name, len(translations), len(texts))
+	}
+
+	return translations, nil
+}
+
+// bcp47 returns the BCP 47 tag of a language, e.g., fr-FR for fr_FR
+func bcp47(language string) string {
+	return strings.Replace(language, "_", "-", -1)
+}
+
+// baseLanguage returns the language without its territory, e.g., fr for fr_FR
+func baseLanguage(language string) string {
+	return strings.SplitN(bcp47(language), "-", 2)[0]
+}
@@ -0,0 +1,112 @@
+package translators_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/EverlongProject/i18n4go/translators"
+	"github.com/EverlongProject/i18n4go/translators/translatorstest"
+)
+
+func TestTranslators(t *testing.T) {
+	server := translatorstest.NewServer()
+	defer server.Close()
+
+	for _, test := range []struct {
+		name     string
+		config   translators.Config
+		path     string
+		target   string
+		header   string
+		expected string
+	}{
+		{"google", translators.Config{URL: server.URL, APIKey: "token", ProjectID: "project"}, "/v3/projects/project/locations/global:translateText", "fr-FR", "Authorization", "Bearer token"},
+		{"google-v2", translators.Config{URL: server.URL, APIKey: "key"}, "/language/translate/v2", "fr-FR", "X-Goog-Api-Key", "key"},
+		{"deepl", translators.Config{URL: server.URL, APIKey: "key"}, "/v2/translate", "FR", "Authorization", "DeepL-Auth-Key key"},
+		{"libretranslate", translators.Config{URL: server.URL}, "/translate", "fr", "Content-Type", "application/json"},
+		{"http", translators.Config{URL: server.URL + "/mt", Headers: map[string]string{"X-Team": "i18n"}}, "/mt", "fr_FR", "X-Team", "i18n"},
+	} {
+		translator, err := translators.New(test.name, test.config)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		translations, err := translator.Translate([]string{"Hello", "Bye {{.Name}}"}, "en", "fr_FR")
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+
+		expected := []string{translatorstest.Translate("Hello", test.target), translatorstest.Translate("Bye {{.Name}}", test.target)}
+		if !reflect.DeepEqual(translations, expected) {
+			t.Errorf("%s: got %q, expected %q", test.name, translations, expected)
+		}
+
+		requests := server.Requests()
+		request := requests[len(requests)-1]
+		if request.Path != test.path || request.Header.Get(test.header) != test.expected {
+			t.Errorf("%s: got request to %s with %s: %q", test.name, request.Path, test.header, request.Header.Get(test.header))
+		}
+	}
+}
+
+func TestOfflineTranslators(t *testing.T) {
+	for name, expected := range map[string]string{"echo": "Hello {{.Name}}", "pseudo": "[Ĥéļļó {{.Name}}]"} {
+		translator, err := translators.New(name, translators.Config{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		translations, err := translator.Translate([]string{"Hello {{.Name}}"}, "en", "fr")
+		if err != nil || translations[0] != expected {
+			t.Errorf("%s: got %q, %v", name, translations, err)
+		}
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	for name, config := range map[string]translators.Config{"unknown": {}, "google": {}, "http": {}} {
+		_, err := translators.New(name, config)
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "quota exceeded", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	translator, err := translators.New("http", translators.Config{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = translator.Translate([]string{"Hello"}, "en", "fr")
+	statusErr, ok := err.(*translators.StatusError)
+	if !ok || statusErr.StatusCode != http.StatusTooManyRequests || statusErr.Body != "quota exceeded" {
+		t.Fatalf("got %#v", err)
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "translators.json")
+	err := os.WriteFile(fileName, []byte(`{"translator": "deepl", "translators": {"deepl": {"api_key_env": "DEEPL_API_KEY"}}}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configFile, err := translators.LoadConfigFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if configFile.Translator != "deepl" || configFile.Translators["deepl"].APIKeyEnv != "DEEPL_API_KEY" {
+		t.Fatalf("got %#v", configFile)
+	}
+}
@@ -0,0 +1,156 @@
+// Package translatorstest provides a fake translation service so that the
+// translators can be tested without network
+package translatorstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// Server answers the requests of the google, google-v2, deepl,
+// libretranslate and http translators, a text is translated by prefixing it
+// with the target language, e.g., "Hello" becomes "[fr] Hello"
+type Server struct {
+	*httptest.Server
+
+	// TranslateFunc, when set before sending requests, replaces Translate
+	TranslateFunc func(text, targetLanguage string) string
+
+	mutex      sync.Mutex
+	requests   []Request
+	failures   int
+	statusCode int
+}
+
+// Request is a request received by the server
+type Request struct {
+	Path   string
+	Header http.Header
+	Target string
+	Texts  []string
+}
+
+func NewServer() *Server {
+	server := &Server{}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+
+	return server
+}
+
+// Translate returns the translation of the fake service
+func Translate(text, targetLanguage string) string {
+	return "[" + targetLanguage + "] " + text
+}
+
+// Requests returns the requests received so far
+func (server *Server) Requests() []Request {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return append([]Request{}, server.requests...)
+}
+
+// FailNext makes the next count requests fail with the status code
+func (server *Server) FailNext(count, statusCode int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.failures, server.statusCode = count, statusCode
+}
+
+func (server *Server) handle(w http.ResponseWriter, r *http.Request) {
+	var body map[string]interface{}
+	if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&body) != nil {
+		http.Error(w, "expected a JSON POST", http.StatusBadRequest)
+		return
+	}
+
+	var texts []string
+	var target string
+	var response func(translations []string) interface{}
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/v3/") && strings.HasSuffix(r.URL.Path, ":translateText"):
+		texts, target = stringsOf(body["contents"]), stringOf(body["targetLanguageCode"])
+		response = func(translations []string) interface{} {
+			googleTranslations := make([]map[string]string, len(translations))
+			for i, translation := range translations {
+				googleTranslations[i] = map[string]string{"translatedText": translation}
+			}
+			return map[string]interface{}{"translations": googleTranslations}
+		}
+	case r.URL.Path == "/language/translate/v2":
+		texts, target = stringsOf(body["q"]), stringOf(body["target"])
+		response = func(translations []string) interface{} {
+			googleTranslations := make([]map[string]string, len(translations))
+			for i, translation := range translations {
+				googleTranslations[i] = map[string]string{"translatedText": translation}
+			}
+			return map[string]interface{}{"data": map[string]interface{}{"translations": googleTranslations}}
+		}
+	case r.URL.Path == "/v2/translate":
+		texts, target = stringsOf(body["text"]), stringOf(body["target_lang"])
+		response = func(translations []string) interface{} {
+			deepLTranslations := make([]map[string]string, len(translations))
+			for i, translation := range translations {
+				deepLTranslations[i] = map[string]string{"text": translation}
+			}
+			return map[string]interface{}{"translations": deepLTranslations}
+		}
+	case r.URL.Path == "/translate":
+		texts, target = stringsOf(body["q"]), stringOf(body["target"])
+		response = func(translations []string) interface{} {
+			return map[string]interface{}{"translatedText": translations}
+		}
+	default:
+		texts, target = stringsOf(body["texts"]), stringOf(body["target"])
+		response = func(translations []string) interface{} {
+			return map[string]interface{}{"translations": translations}
+		}
+	}
+
+	server.mutex.Lock()
+	server.requests = append(server.requests, Request{Path: r.URL.Path, Header: r.Header, Target: target, Texts: texts})
+	failures, statusCode := server.failures, server.statusCode
+	if failures > 0 {
+		server.failures--
+	}
+	server.mutex.Unlock()
+
+	if failures > 0 {
+		http.Error(w, http.StatusText(statusCode), statusCode)
+		return
+	}
+
+	translate := Translate
+	if server.TranslateFunc != nil {
+		translate = server.TranslateFunc
+	}
+
+	translations := make([]string, len(texts))
+	for i, text := range texts {
+		translations[i] = translate(text, target)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response(translations))
+}
+
+func stringOf(value interface{}) string {
+	aString, _ := value.(string)
+	return aString
+}
+
+func stringsOf(value interface{}) []string {
+	values, _ := value.([]interface{})
+
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = stringOf(value)
+	}
+
+	return strs
+}